@@ -109,22 +109,103 @@ func (d *defaultAttrEncoder) Encode(iter Iterator) string {
 		if i > 0 {
 			_, _ = buf.WriteRune(',')
 		}
-		copyAndEscape(buf, string(keyValue.Key))
+		encodeKeyValue(buf, keyValue)
+	}
+	return buf.String()
+}
 
-		_, _ = buf.WriteRune('=')
+// encodeKeyValue writes the default encoding of kv to buf.
+func encodeKeyValue(buf *bytes.Buffer, kv KeyValue) {
+	copyAndEscape(buf, string(kv.Key))
 
-		if keyValue.Value.Type() == STRING {
-			copyAndEscape(buf, keyValue.Value.AsString())
+	_, _ = buf.WriteRune('=')
+
+	if kv.Value.Type() == STRING {
+		copyAndEscape(buf, kv.Value.AsString())
+	} else {
+		_, _ = buf.WriteString(kv.Value.Emit())
+	}
+}
+
+// ID is a part of an implementation of the AttributeEncoder interface.
+func (*defaultAttrEncoder) ID() EncoderID {
+	return defaultEncoderID
+}
+
+// orderedAttrEncoder encodes attributes like the default encoder, with the
+// keys in order first.
+type orderedAttrEncoder struct {
+	id    EncoderID
+	order []Key
+	// rank is the position of each key in order.
+	rank map[Key]int
+}
+
+var _ Encoder = &orderedAttrEncoder{}
+
+// NewOrderedEncoder returns an attribute encoder that encodes attributes the
+// same way as DefaultEncoder, except for their order. The attributes with a
+// key in order are encoded first, in that order, followed by all other
+// attributes sorted by key like DefaultEncoder does.
+//
+// Attributes are always sorted and de-duplicated in a Set, the order they
+// were passed in is not kept. Use this encoder to reproduce an encoding that
+// relied on a fixed key order, like the insertion order used by another
+// system, without changing the identity of the encoded series.
+//
+// Each call returns an encoder with a new EncoderID, create it once and
+// reuse it.
+func NewOrderedEncoder(order ...Key) Encoder {
+	rank := make(map[Key]int, len(order))
+	keys := make([]Key, 0, len(order))
+	for _, k := range order {
+		if _, ok := rank[k]; ok {
+			continue
+		}
+		rank[k] = len(keys)
+		keys = append(keys, k)
+	}
+	return &orderedAttrEncoder{
+		id:    NewEncoderID(),
+		order: keys,
+		rank:  rank,
+	}
+}
+
+// Encode is a part of an implementation of the AttributeEncoder interface.
+func (e *orderedAttrEncoder) Encode(iter Iterator) string {
+	ordered := make([]*KeyValue, len(e.order))
+	var rest []KeyValue
+	for iter.Next() {
+		kv := iter.Attribute()
+		if i, ok := e.rank[kv.Key]; ok {
+			ordered[i] = &kv
 		} else {
-			_, _ = buf.WriteString(keyValue.Value.Emit())
+			rest = append(rest, kv)
+		}
+	}
+
+	var buf bytes.Buffer
+	write := func(kv KeyValue) {
+		if buf.Len() > 0 {
+			_, _ = buf.WriteRune(',')
+		}
+		encodeKeyValue(&buf, kv)
+	}
+	for _, kv := range ordered {
+		if kv != nil {
+			write(*kv)
 		}
 	}
+	for _, kv := range rest {
+		write(kv)
+	}
 	return buf.String()
 }
 
 // ID is a part of an implementation of the AttributeEncoder interface.
-func (*defaultAttrEncoder) ID() EncoderID {
-	return defaultEncoderID
+func (e *orderedAttrEncoder) ID() EncoderID {
+	return e.id
 }
 
 // copyAndEscape escapes `=`, `,` and its own escape character (`\`),
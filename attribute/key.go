@@ -14,6 +14,15 @@
 
 package attribute // import "go.opentelemetry.io/otel/attribute"
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidKey is returned by SafeKey for keys that contain characters
+// outside of the allowed character set.
+var ErrInvalidKey = errors.New("invalid attribute key")
+
 // Key represents the key part in key-value pairs. It's a string. The
 // allowed character set in the key depends on the use of the key.
 type Key string
@@ -132,3 +141,45 @@ func (k Key) StringSlice(v []string) KeyValue {
 func (k Key) Defined() bool {
 	return len(k) != 0
 }
+
+// SafeKey returns s as a Key if it is a valid key. An error wrapping
+// ErrInvalidKey is returned otherwise.
+//
+// A valid key is not empty and contains only ASCII letters, digits, and the
+// '.', '_', '-', and '/' characters. These keys can be exported without
+// escaping by the OTLP, Jaeger and Zipkin exporters. Other exporters may still
+// rewrite them, e.g. the Prometheus exporter replaces '.', '-' and '/' with '_'
+// and prefixes keys starting with a digit or '_'.
+func SafeKey(s string) (Key, error) {
+	if s == "" {
+		return "", fmt.Errorf("%w: empty key", ErrInvalidKey)
+	}
+	for i := 0; i < len(s); i++ {
+		if !isKeyChar(s[i]) {
+			return "", fmt.Errorf("%w: %q contains %q at index %d", ErrInvalidKey, s, s[i], i)
+		}
+	}
+	return Key(s), nil
+}
+
+// MustSafeKey is like SafeKey but panics if s is not a valid key. It is
+// intended for keys that are known to be valid, e.g. package-level
+// variables.
+func MustSafeKey(s string) Key {
+	k, err := SafeKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// isKeyChar returns true if c is allowed in a key returned by SafeKey.
+func isKeyChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	case c == '.', c == '_', c == '-', c == '/':
+		return true
+	}
+	return false
+}
@@ -99,3 +99,34 @@ func TestEmit(t *testing.T) {
 		})
 	}
 }
+
+func TestSafeKey(t *testing.T) {
+	for _, valid := range []string{
+		"a",
+		"http.method",
+		"service.instance_id",
+		"k8s.pod-name",
+		"net/peer/IP4",
+	} {
+		k, err := attribute.SafeKey(valid)
+		require.NoError(t, err, valid)
+		require.Equal(t, attribute.Key(valid), k)
+		require.Equal(t, attribute.Key(valid), attribute.MustSafeKey(valid))
+	}
+
+	for _, invalid := range []string{
+		"",
+		"with space",
+		"tab\tkey",
+		"new\nline",
+		"nul\x00",
+		"del\x7f",
+		"emoji😀",
+		"colon:key",
+	} {
+		k, err := attribute.SafeKey(invalid)
+		require.ErrorIs(t, err, attribute.ErrInvalidKey, "%q", invalid)
+		require.Equal(t, attribute.Key(""), k)
+		require.Panics(t, func() { attribute.MustSafeKey(invalid) }, "%q", invalid)
+	}
+}
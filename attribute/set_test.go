@@ -170,6 +170,28 @@ func TestUniqueness(t *testing.T) {
 	}
 }
 
+func TestOrderedEncoder(t *testing.T) {
+	set := attribute.NewSet(
+		attribute.String("service", "api"),
+		attribute.Int("code", 200),
+		attribute.String("method", "GET"),
+		attribute.String("a=b", "c,d"),
+	)
+
+	sorted := set.Encoded(attribute.DefaultEncoder())
+	require.Equal(t, `a\=b=c\,d,code=200,method=GET,service=api`, sorted)
+
+	enc := attribute.NewOrderedEncoder("service", "method", "unknown", "service")
+	require.Equal(t, `service=api,method=GET,a\=b=c\,d,code=200`, set.Encoded(enc))
+	require.True(t, enc.ID().Valid())
+	require.NotEqual(t, attribute.DefaultEncoder().ID(), enc.ID())
+	require.NotEqual(t, attribute.NewOrderedEncoder().ID(), enc.ID())
+
+	// Without an order the encoding is the same as the default one.
+	require.Equal(t, sorted, set.Encoded(attribute.NewOrderedEncoder()))
+	require.Equal(t, "", attribute.EmptySet().Encoded(enc))
+}
+
 func TestLookup(t *testing.T) {
 	set := attribute.NewSet(attribute.Int("C", 3), attribute.Int("A", 1), attribute.Int("B", 2))
 
@@ -315,6 +315,8 @@ type BridgeTracer struct {
 	warnOnce       sync.Once
 
 	propagator propagation.TextMapPropagator
+
+	copyBaggage bool
 }
 
 var _ ot.Tracer = &BridgeTracer{}
@@ -356,6 +358,22 @@ func (t *BridgeTracer) SetTextMapPropagator(propagator propagation.TextMapPropag
 	t.propagator = propagator
 }
 
+// SetCopyBaggage sets whether the BridgeTracer copies baggage between the
+// OpenTracing spans and the OpenTelemetry context. It is disabled by
+// default.
+//
+// When enabled, the baggage items of the parent OpenTracing span are
+// stored as OpenTelemetry baggage in the context passed to the
+// OpenTelemetry tracer on StartSpan, and the OpenTelemetry baggage of a
+// context is copied as baggage items to the OpenTracing span put in it
+// with ContextWithSpan, unless the span already has an item with the same
+// key. Contexts returned by NewHookedContext additionally expose all the
+// baggage items of the active OpenTracing span, including the ones it
+// inherited from its parent, as OpenTelemetry baggage.
+func (t *BridgeTracer) SetCopyBaggage(enabled bool) {
+	t.copyBaggage = enabled
+}
+
 // NewHookedContext returns a Context that has ctx as its parent and is
 // wrapped to handle baggage set and get operations.
 func (t *BridgeTracer) NewHookedContext(ctx context.Context) context.Context {
@@ -393,6 +411,16 @@ func (t *BridgeTracer) baggageGetHook(ctx context.Context, list iBaggage.List) i
 		return list
 	}
 	items := bSpan.extraBaggageItems
+	if t.copyBaggage {
+		items = make(map[string]string, bSpan.ctx.bag.Len()+len(bSpan.extraBaggageItems))
+		bSpan.ctx.ForeachBaggageItem(func(k, v string) bool {
+			items[k] = v
+			return true
+		})
+		for k, v := range bSpan.extraBaggageItems {
+			items[k] = v
+		}
+	}
 	if len(items) == 0 {
 		return list
 	}
@@ -426,6 +454,9 @@ func (t *BridgeTracer) StartSpan(operationName string, opts ...ot.StartSpanOptio
 	checkCtx := migration.WithDeferredSetup(context.Background())
 	if parentBridgeSC != nil {
 		checkCtx = trace.ContextWithRemoteSpanContext(checkCtx, parentBridgeSC.otelSpanContext)
+		if t.copyBaggage {
+			checkCtx = baggage.ContextWithBaggage(checkCtx, parentBridgeSC.bag)
+		}
 	}
 	checkCtx2, otelSpan := t.setTracer.tracer().Start(
 		checkCtx,
@@ -486,6 +517,13 @@ func (t *BridgeTracer) ContextWithSpanHook(ctx context.Context, span ot.Span) co
 		t.warningHandler("Encountered a foreign OpenTracing span, will not run a possible deferred context setup hook\n")
 		return ctx
 	}
+	if t.copyBaggage {
+		for _, m := range baggage.FromContext(ctx).Members() {
+			if bSpan.ctx.baggageItem(m.Key()).Key() == "" {
+				bSpan.setBaggageItemOnly(m.Key(), m.Value())
+			}
+		}
+	}
 	if bSpan.skipDeferHook {
 		return ctx
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -425,3 +426,52 @@ func TestBridgeTracer_StartSpan(t *testing.T) {
 		})
 	}
 }
+
+type baggageRecordingTracer struct {
+	trace.Tracer
+
+	bag baggage.Baggage
+}
+
+func (t *baggageRecordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.bag = baggage.FromContext(ctx)
+	return t.Tracer.Start(ctx, name, opts...)
+}
+
+func TestBridgeTracer_CopyBaggage(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		bridge := NewBridgeTracer()
+		bridge.SetCopyBaggage(enabled)
+		tracer := &baggageRecordingTracer{Tracer: NewWrapperTracer(bridge, otel.Tracer("test"))}
+		bridge.SetOpenTelemetryTracer(tracer)
+
+		parent := bridge.StartSpan("parent")
+		parent.SetBaggageItem("Foo", "bar")
+		child := bridge.StartSpan("child", ot.ChildOf(parent.Context()))
+
+		// OpenTracing baggage is passed to the OpenTelemetry tracer.
+		got := tracer.bag.Member("Foo").Value()
+		// Inherited OpenTracing baggage is readable from the context.
+		ctx := ot.ContextWithSpan(bridge.NewHookedContext(context.Background()), child)
+		gotCtx := baggage.FromContext(ctx).Member("Foo").Value()
+
+		// OpenTelemetry baggage is copied to the OpenTracing span.
+		m, err := baggage.NewMember("Baz", "qux")
+		assert.NoError(t, err)
+		bag, err := baggage.New(m)
+		assert.NoError(t, err)
+		span := bridge.StartSpan("span")
+		ot.ContextWithSpan(baggage.ContextWithBaggage(context.Background(), bag), span)
+		gotSpan := span.BaggageItem("Baz")
+
+		if enabled {
+			assert.Equal(t, "bar", got)
+			assert.Equal(t, "bar", gotCtx)
+			assert.Equal(t, "qux", gotSpan)
+		} else {
+			assert.Empty(t, got)
+			assert.Empty(t, gotCtx)
+			assert.Empty(t, gotSpan)
+		}
+	}
+}
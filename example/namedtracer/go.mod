@@ -17,6 +17,7 @@ require (
 
 require (
 	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/google/go-cmp v0.5.8 // indirect
 	golang.org/x/sys v0.0.0-20210423185535-09eb48e85fd7 // indirect
 )
 
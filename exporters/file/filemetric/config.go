@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filemetric // import "go.opentelemetry.io/otel/exporters/file/filemetric"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	defaultMaxFileSize int64 = 10 * 1024 * 1024
+	defaultMaxBackups        = 3
+	defaultAttrEncoder       = attribute.DefaultEncoder()
+)
+
+// config contains options for the file exporter.
+type config struct {
+	// MaxFileSize is the size in bytes a file can reach before it is
+	// rotated. If not positive, the file is never rotated. Default is
+	// 10 MiB.
+	MaxFileSize int64
+
+	// MaxBackups is the number of rotated files to keep. Default is 3.
+	MaxBackups int
+
+	// Encoder encodes the attributes.
+	Encoder attribute.Encoder
+}
+
+// newConfig creates a validated Config configured with options.
+func newConfig(options ...Option) config {
+	cfg := config{
+		MaxFileSize: defaultMaxFileSize,
+		MaxBackups:  defaultMaxBackups,
+		Encoder:     defaultAttrEncoder,
+	}
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	if cfg.MaxBackups < 0 {
+		cfg.MaxBackups = 0
+	}
+	return cfg
+}
+
+// Option sets the value of an option for a Config.
+type Option interface {
+	apply(config) config
+}
+
+// WithMaxFileSize sets the size in bytes a file can reach before it is
+// rotated. The file is rotated before a line that would exceed size is
+// written, lines are never split across files. If size is not positive, the
+// file is never rotated.
+func WithMaxFileSize(size int64) Option {
+	return maxFileSizeOption(size)
+}
+
+type maxFileSizeOption int64
+
+func (o maxFileSizeOption) apply(cfg config) config {
+	cfg.MaxFileSize = int64(o)
+	return cfg
+}
+
+// WithMaxBackups sets the number of rotated files to keep. Rotated files are
+// named after the exported file with a ".1" suffix for the newest up to a
+// ".n" suffix for the oldest. If n is zero, the file is truncated when it
+// is rotated.
+func WithMaxBackups(n int) Option {
+	return maxBackupsOption(n)
+}
+
+type maxBackupsOption int
+
+func (o maxBackupsOption) apply(cfg config) config {
+	cfg.MaxBackups = int(o)
+	return cfg
+}
+
+// WithAttributeEncoder sets the attribute encoder used in export.
+func WithAttributeEncoder(enc attribute.Encoder) Option {
+	return attrEncoderOption{enc}
+}
+
+type attrEncoderOption struct {
+	encoder attribute.Encoder
+}
+
+func (o attrEncoderOption) apply(cfg config) config {
+	cfg.Encoder = o.encoder
+	return cfg
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filemetric // import "go.opentelemetry.io/otel/exporters/file/filemetric"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var errShutdown = errors.New("exporter is shutdown")
+
+// Exporter is an OpenTelemetry metric exporter that writes telemetry to a
+// local file as newline-delimited JSON, one line per record.
+type Exporter struct {
+	config config
+
+	fileMu sync.Mutex
+	file   *rotatingFile
+}
+
+var (
+	_ export.Exporter = &Exporter{}
+)
+
+type line struct {
+	Name       string      `json:"Name"`
+	Sum        interface{} `json:"Sum,omitempty"`
+	Count      interface{} `json:"Count,omitempty"`
+	LastValue  interface{} `json:"Last,omitempty"`
+	Boundaries []float64   `json:"Boundaries,omitempty"`
+	Counts     []uint64    `json:"Counts,omitempty"`
+	StartTime  time.Time   `json:"StartTime"`
+	EndTime    time.Time   `json:"EndTime"`
+}
+
+// New creates an Exporter that appends to the file at path, creating it if
+// it does not exist, with the passed options.
+func New(path string, options ...Option) (*Exporter, error) {
+	cfg := newConfig(options...)
+	file, err := openRotatingFile(path, cfg.MaxFileSize, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		config: cfg,
+		file:   file,
+	}, nil
+}
+
+// TemporalityFor returns the Temporality to export for desc and kind.
+func (e *Exporter) TemporalityFor(desc *sdkapi.Descriptor, kind aggregation.Kind) aggregation.Temporality {
+	return aggregation.StatelessTemporalitySelector().TemporalityFor(desc, kind)
+}
+
+// Export writes one line for each record in reader to the file.
+func (e *Exporter) Export(_ context.Context, res *resource.Resource, reader export.InstrumentationLibraryReader) error {
+	var batch []line
+	aggError := reader.ForEach(func(lib instrumentation.Library, mr export.Reader) error {
+		var instAttrs []attribute.KeyValue
+		if name := lib.Name; name != "" {
+			instAttrs = append(instAttrs, attribute.String("instrumentation.name", name))
+			if version := lib.Version; version != "" {
+				instAttrs = append(instAttrs, attribute.String("instrumentation.version", version))
+			}
+			if schema := lib.SchemaURL; schema != "" {
+				instAttrs = append(instAttrs, attribute.String("instrumentation.schema_url", schema))
+			}
+		}
+		instSet := attribute.NewSet(instAttrs...)
+		encodedInstAttrs := instSet.Encoded(e.config.Encoder)
+
+		return mr.ForEach(e, func(record export.Record) error {
+			recordRes := res
+			if r := record.Resource(); r != nil {
+				var err error
+				if recordRes, err = resource.Merge(res, r); err != nil {
+					return err
+				}
+			}
+
+			l, err := e.line(record, recordRes.Encoded(e.config.Encoder), encodedInstAttrs)
+			if err != nil {
+				return err
+			}
+			batch = append(batch, l)
+			return nil
+		})
+	})
+	if len(batch) == 0 {
+		return aggError
+	}
+
+	e.fileMu.Lock()
+	defer e.fileMu.Unlock()
+	if e.file == nil {
+		return errShutdown
+	}
+	for _, l := range batch {
+		data, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		if _, err := e.file.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	if err := e.file.Flush(); err != nil {
+		return err
+	}
+
+	return aggError
+}
+
+// line returns the line exported for record.
+func (e *Exporter) line(record export.Record, encodedResource, encodedInstAttrs string) (line, error) {
+	desc := record.Descriptor()
+	agg := record.Aggregation()
+	kind := desc.NumberKind()
+
+	l := line{
+		StartTime: record.StartTime(),
+		EndTime:   record.EndTime(),
+	}
+
+	if sum, ok := agg.(aggregation.Sum); ok {
+		value, err := sum.Sum()
+		if err != nil {
+			return l, err
+		}
+		l.Sum = value.AsInterface(kind)
+	}
+	if count, ok := agg.(aggregation.Count); ok {
+		value, err := count.Count()
+		if err != nil {
+			return l, err
+		}
+		l.Count = value
+	}
+	if lv, ok := agg.(aggregation.LastValue); ok {
+		value, _, err := lv.LastValue()
+		if err != nil {
+			return l, err
+		}
+		l.LastValue = value.AsInterface(kind)
+	}
+	if hist, ok := agg.(aggregation.Histogram); ok {
+		buckets, err := hist.Histogram()
+		if err != nil {
+			return l, err
+		}
+		l.Boundaries = buckets.Boundaries
+		l.Counts = buckets.Counts
+	}
+
+	var encodedAttrs string
+	if record.Attributes().Len() > 0 {
+		encodedAttrs = record.Attributes().Encoded(e.config.Encoder)
+	}
+
+	var parts []string
+	for _, p := range []string{encodedResource, encodedInstAttrs, encodedAttrs} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	l.Name = desc.Name()
+	if len(parts) > 0 {
+		l.Name += "{" + strings.Join(parts, ",") + "}"
+	}
+
+	return l, nil
+}
+
+// Shutdown flushes and closes the file. Export returns an error once
+// Shutdown has been called.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.fileMu.Lock()
+	defer e.fileMu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
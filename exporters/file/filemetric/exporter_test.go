@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filemetric_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/file/filemetric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var testResource = resource.NewSchemaless(attribute.String("R", "V"))
+
+func readLines(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var l map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &l))
+		lines = append(lines, l)
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func newController(exp *filemetric.Exporter) *controller.Controller {
+	return controller.New(
+		processor.NewFactory(processortest.AggregatorSelector(), aggregation.StatelessTemporalitySelector()),
+		controller.WithExporter(exp),
+		controller.WithResource(testResource),
+	)
+}
+
+// export collects cont and exports it with exp.
+func export(ctx context.Context, cont *controller.Controller, exp *filemetric.Exporter) error {
+	if err := cont.Collect(ctx); err != nil {
+		return err
+	}
+	return exp.Export(ctx, cont.Resource(), cont)
+}
+
+func TestExporterWritesLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	exp, err := filemetric.New(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	cont := newController(exp)
+	require.NoError(t, cont.Start(ctx))
+	meter := cont.Meter("test")
+
+	counter, err := meter.SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	counter.Add(ctx, 3, attribute.String("A", "B"))
+	gauge, err := meter.SyncFloat64().UpDownCounter("updowncounter.sum")
+	require.NoError(t, err)
+	gauge.Add(ctx, 1.5)
+	histogram, err := meter.SyncInt64().Histogram("histogram.histogram")
+	require.NoError(t, err)
+	histogram.Record(ctx, 1)
+	histogram.Record(ctx, 100)
+
+	require.NoError(t, cont.Stop(ctx))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 3)
+	byName := map[string]map[string]interface{}{}
+	for _, l := range lines {
+		byName[l["Name"].(string)] = l
+		assert.Contains(t, l, "StartTime")
+		assert.Contains(t, l, "EndTime")
+	}
+	assert.Equal(t, 3.0, byName["counter.sum{R=V,instrumentation.name=test,A=B}"]["Sum"])
+	assert.Equal(t, 1.5, byName["updowncounter.sum{R=V,instrumentation.name=test}"]["Sum"])
+
+	hist := byName["histogram.histogram{R=V,instrumentation.name=test}"]
+	assert.Equal(t, 101.0, hist["Sum"])
+	assert.Equal(t, 2.0, hist["Count"])
+	require.Contains(t, hist, "Boundaries")
+	require.Contains(t, hist, "Counts")
+	boundaries := hist["Boundaries"].([]interface{})
+	counts := hist["Counts"].([]interface{})
+	require.Len(t, counts, len(boundaries)+1)
+	var total float64
+	for _, c := range counts {
+		total += c.(float64)
+	}
+	assert.Equal(t, 2.0, total)
+}
+
+func TestExporterRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	exp, err := filemetric.New(path, filemetric.WithMaxFileSize(200), filemetric.WithMaxBackups(2))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	cont := newController(exp)
+	counter, err := cont.Meter("test").SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		counter.Add(ctx, 1)
+		require.NoError(t, export(ctx, cont, exp))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(200))
+		assert.NotEmpty(t, readLines(t, p))
+	}
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestExporterShutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	exp, err := filemetric.New(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	cont := newController(exp)
+	counter, err := cont.Meter("test").SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+	assert.Error(t, export(ctx, cont, exp))
+}
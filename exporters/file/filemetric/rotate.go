@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filemetric // import "go.opentelemetry.io/otel/exporters/file/filemetric"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// rotatingFile writes to the file at path, rotating it when it would grow
+// beyond maxSize.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	buf  *bufio.Writer
+	size int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the file at f.path for appending.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file = file
+	f.buf = bufio.NewWriter(file)
+	f.size = info.Size()
+	return nil
+}
+
+// Write writes p to the file. If writing p would grow a non-empty file
+// beyond maxSize the file is rotated first. If the file could not be reopened
+// after a failed rotation, it is opened again before writing.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.buf.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate closes the file, shifts it and its backups, and opens a new file.
+// If the file cannot be shifted, the current file is reopened so later writes
+// still succeed and retry the rotation.
+func (f *rotatingFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := f.shift(); err != nil {
+		_ = f.open()
+		return err
+	}
+	return f.open()
+}
+
+// shift removes the closed file, or renames it and its backups when
+// maxBackups is set.
+func (f *rotatingFile) shift() error {
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	for i := f.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(f.backup(i), f.backup(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(f.path, f.backup(1))
+}
+
+// backup returns the path of the ith backup.
+func (f *rotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
+
+// Flush writes any buffered data to the file.
+func (f *rotatingFile) Flush() error {
+	if f.file == nil {
+		return nil
+	}
+	return f.buf.Flush()
+}
+
+// Close flushes and closes the file. If flushing fails the file is left open
+// so the buffered data is not lost.
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	if err := f.buf.Flush(); err != nil {
+		return err
+	}
+	err := f.file.Close()
+	f.file, f.buf = nil, nil
+	return err
+}
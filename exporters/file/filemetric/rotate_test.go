@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filemetric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRotatingFileRotatesAtMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	f, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	write := func(s string) {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	write("1234\n")
+	write("abcd\n") // 10 bytes, at the threshold.
+	require.NoError(t, f.Flush())
+	assert.Equal(t, "1234\nabcd\n", readFile(t, path))
+	assert.NoFileExists(t, path+".1")
+
+	write("x\n")
+	write("y\n")
+	require.NoError(t, f.Flush())
+	assert.Equal(t, "x\ny\n", readFile(t, path))
+	assert.Equal(t, "1234\nabcd\n", readFile(t, path+".1"))
+
+	write("0123456789abc\n") // Larger than the max size.
+	write("z\n")
+	write("w\n")
+	require.NoError(t, f.Close())
+	assert.Equal(t, "z\nw\n", readFile(t, path))
+	assert.Equal(t, "0123456789abc\n", readFile(t, path+".1"))
+	assert.Equal(t, "x\ny\n", readFile(t, path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestRotatingFileAppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	require.NoError(t, os.WriteFile(path, []byte("12345678\n"), 0600))
+
+	f, err := openRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("a\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "a\n", readFile(t, path))
+	assert.Equal(t, "12345678\n", readFile(t, path+".1"))
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	f, err := openRotatingFile(path, 4, 0)
+	require.NoError(t, err)
+	for _, s := range []string{"ab\n", "cd\n"} {
+		_, err = f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "cd\n", readFile(t, path))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestRotatingFileWithoutMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	f, err := openRotatingFile(path, 0, 1)
+	require.NoError(t, err)
+	for _, s := range []string{"ab\n", "cd\n"} {
+		_, err = f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "ab\ncd\n", readFile(t, path))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestRotatingFileRecoversFromFailedRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	f, err := openRotatingFile(path, 4, 1)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("ab\n"))
+	require.NoError(t, err)
+
+	// A directory in place of the backup makes the rotation fail.
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "dir"), 0755))
+	_, err = f.Write([]byte("cd\n"))
+	assert.Error(t, err)
+	assert.Equal(t, "ab\n", readFile(t, path))
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = f.Write([]byte("cd\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "cd\n", readFile(t, path))
+	assert.Equal(t, "ab\n", readFile(t, path+".1"))
+}
@@ -61,6 +61,8 @@ type agentClientUDPParams struct {
 	Logger                   *log.Logger
 	AttemptReconnecting      bool
 	AttemptReconnectInterval time.Duration
+	MaxReconnectBackoff      time.Duration
+	ReconnectCallback        func(error)
 }
 
 // newAgentClientUDP creates a client that sends spans to Jaeger Agent over UDP.
@@ -89,7 +91,7 @@ func newAgentClientUDP(params agentClientUDPParams) (*agentClientUDP, error) {
 
 	if params.AttemptReconnecting {
 		// host is hostname, setup resolver loop in case host record changes during operation
-		connUDP, err = newReconnectingUDPConn(hostPort, params.MaxPacketSize, params.AttemptReconnectInterval, net.ResolveUDPAddr, net.DialUDP, params.Logger)
+		connUDP, err = newReconnectingUDPConn(hostPort, params.MaxPacketSize, params.AttemptReconnectInterval, net.ResolveUDPAddr, net.DialUDP, params.Logger, params.MaxReconnectBackoff, params.ReconnectCallback)
 		if err != nil {
 			return nil, err
 		}
@@ -39,6 +39,8 @@ const (
 	keyStatusMessage                 = "otel.status_description"
 	keyDroppedAttributeCount         = "otel.event.dropped_attributes_count"
 	keyEventName                     = "event"
+	keyErrorType                     = "error.type"
+	keyErrorMessage                  = "error.message"
 )
 
 // New returns an OTel Exporter implementation that exports the collected
@@ -189,6 +191,9 @@ func spanToThrift(ss sdktrace.ReadOnlySpan) *gen.Span {
 				fields = append(fields, tag)
 			}
 		}
+		if a.Name == semconv.ExceptionEventName {
+			fields = append(fields, errorFields(a.Attributes)...)
+		}
 		if a.DroppedAttributeCount != 0 {
 			fields = append(fields, getInt64Tag(keyDroppedAttributeCount, int64(a.DroppedAttributeCount)))
 		}
@@ -198,7 +203,19 @@ func spanToThrift(ss sdktrace.ReadOnlySpan) *gen.Span {
 		})
 	}
 
+	// The parent is referenced as CHILD_OF, in addition to the
+	// ParentSpanId, and each link as FOLLOWS_FROM.
 	var refs []*gen.SpanRef
+	if parent := ss.Parent(); parent.IsValid() {
+		tid := parent.TraceID()
+		sid := parent.SpanID()
+		refs = append(refs, &gen.SpanRef{
+			TraceIdHigh: int64(binary.BigEndian.Uint64(tid[0:8])),
+			TraceIdLow:  int64(binary.BigEndian.Uint64(tid[8:16])),
+			SpanId:      int64(binary.BigEndian.Uint64(sid[:])),
+			RefType:     gen.SpanRefType_CHILD_OF,
+		})
+	}
 	for _, link := range ss.Links() {
 		tid := link.SpanContext.TraceID()
 		sid := link.SpanContext.SpanID()
@@ -228,6 +245,22 @@ func spanToThrift(ss sdktrace.ReadOnlySpan) *gen.Span {
 	}
 }
 
+// errorFields returns the Jaeger error log fields for the attributes of an
+// exception event. The exception type and message are duplicated as the
+// "error.type" and "error.message" fields Jaeger uses to display errors.
+func errorFields(attrs []attribute.KeyValue) []*gen.Tag {
+	var fields []*gen.Tag
+	for _, kv := range attrs {
+		switch kv.Key {
+		case semconv.ExceptionTypeKey:
+			fields = append(fields, getStringTag(keyErrorType, kv.Value.Emit()))
+		case semconv.ExceptionMessageKey:
+			fields = append(fields, getStringTag(keyErrorMessage, kv.Value.Emit()))
+		}
+	}
+	return fields
+}
+
 func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 	var tag *gen.Tag
 	switch keyValue.Value.Type() {
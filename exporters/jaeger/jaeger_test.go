@@ -350,6 +350,12 @@ func TestSpanSnapshotToThrift(t *testing.T) {
 					{Key: keyInstrumentationLibraryVersion, VType: gen.TagType_STRING, VStr: &instrLibVersion},
 				},
 				References: []*gen.SpanRef{
+					{
+						RefType:     gen.SpanRefType_CHILD_OF,
+						TraceIdHigh: int64(binary.BigEndian.Uint64(traceID[0:8])),
+						TraceIdLow:  int64(binary.BigEndian.Uint64(traceID[8:16])),
+						SpanId:      int64(binary.BigEndian.Uint64(parentSpanID[:])),
+					},
 					{
 						RefType:     gen.SpanRefType_FOLLOWS_FROM,
 						TraceIdHigh: int64(binary.BigEndian.Uint64(linkTraceID[0:8])),
@@ -400,6 +406,14 @@ func TestSpanSnapshotToThrift(t *testing.T) {
 					{Key: keyInstrumentationLibraryName, VType: gen.TagType_STRING, VStr: &instrLibName},
 					{Key: keyInstrumentationLibraryVersion, VType: gen.TagType_STRING, VStr: &instrLibVersion},
 				},
+				References: []*gen.SpanRef{
+					{
+						RefType:     gen.SpanRefType_CHILD_OF,
+						TraceIdHigh: int64(binary.BigEndian.Uint64(traceID[0:8])),
+						TraceIdLow:  int64(binary.BigEndian.Uint64(traceID[8:16])),
+						SpanId:      int64(binary.BigEndian.Uint64(parentSpanID[:])),
+					},
+				},
 			},
 		},
 	}
@@ -419,6 +433,73 @@ func TestSpanSnapshotToThrift(t *testing.T) {
 	}
 }
 
+func TestSpanEventsToThriftLogs(t *testing.T) {
+	now := time.Now()
+	first, second := now.Add(time.Millisecond), now.Add(2*time.Millisecond)
+	errType, errMsg := "*errors.errorString", "boom"
+
+	stub := tracetest.SpanStub{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+		}),
+		Name:      "/foo",
+		StartTime: now,
+		EndTime:   now.Add(time.Second),
+		Events: []sdktrace.Event{
+			{
+				Name:       "first",
+				Attributes: []attribute.KeyValue{attribute.String("k", "v")},
+				Time:       first,
+			},
+			{
+				Name: "second",
+				Time: second,
+			},
+			{
+				Name: semconv.ExceptionEventName,
+				Attributes: []attribute.KeyValue{
+					semconv.ExceptionTypeKey.String(errType),
+					semconv.ExceptionMessageKey.String(errMsg),
+				},
+				Time: second,
+			},
+		},
+	}
+
+	str := func(s string) *string { return &s }
+	want := []*gen.Log{
+		{
+			Timestamp: first.UnixNano() / 1000,
+			Fields: []*gen.Tag{
+				{Key: keyEventName, VType: gen.TagType_STRING, VStr: str("first")},
+				{Key: "k", VType: gen.TagType_STRING, VStr: str("v")},
+			},
+		},
+		{
+			Timestamp: second.UnixNano() / 1000,
+			Fields: []*gen.Tag{
+				{Key: keyEventName, VType: gen.TagType_STRING, VStr: str("second")},
+			},
+		},
+		{
+			Timestamp: second.UnixNano() / 1000,
+			Fields: []*gen.Tag{
+				{Key: keyEventName, VType: gen.TagType_STRING, VStr: str(semconv.ExceptionEventName)},
+				{Key: string(semconv.ExceptionTypeKey), VType: gen.TagType_STRING, VStr: &errType},
+				{Key: string(semconv.ExceptionMessageKey), VType: gen.TagType_STRING, VStr: &errMsg},
+				{Key: keyErrorType, VType: gen.TagType_STRING, VStr: &errType},
+				{Key: keyErrorMessage, VType: gen.TagType_STRING, VStr: &errMsg},
+			},
+		},
+	}
+
+	got := spanToThrift(stub.Snapshot())
+	if diff := cmp.Diff(want, got.Logs); diff != "" {
+		t.Errorf("Diff%v", diff)
+	}
+}
+
 func TestExporterShutdownHonorsCancel(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
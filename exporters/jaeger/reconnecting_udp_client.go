@@ -29,10 +29,17 @@ type reconnectingUDPConn struct {
 	// `sync/atomic` expects the first word in an allocated struct to be 64-bit
 	// aligned on both ARM and x86-32. See https://goo.gl/zW7dgq for more details.
 	bufferBytes int64
+	// retryAfter is the unix nano time before which Write does not attempt
+	// to reconnect, it is only set when backing off.
+	retryAfter  int64
 	hostPort    string
 	resolveFunc resolveFunc
 	dialFunc    dialFunc
 	logger      *log.Logger
+	// maxBackoff is the maximum delay between failed attempts, it is zero
+	// when not backing off.
+	maxBackoff  time.Duration
+	onReconnect func(error)
 
 	connMtx   sync.RWMutex
 	conn      *net.UDPConn
@@ -45,17 +52,27 @@ type dialFunc func(network string, laddr, raddr *net.UDPAddr) (*net.UDPConn, err
 
 // newReconnectingUDPConn returns a new udpConn that resolves hostPort every resolveTimeout, if the resolved address is
 // different than the current conn then the new address is dialed and the conn is swapped.
-func newReconnectingUDPConn(hostPort string, bufferBytes int, resolveTimeout time.Duration, resolveFunc resolveFunc, dialFunc dialFunc, logger *log.Logger) (*reconnectingUDPConn, error) {
+//
+// If maxBackoff is greater than resolveTimeout, the delay between failed attempts is doubled up to maxBackoff, and
+// Write does not attempt to reconnect until the next scheduled attempt. onReconnect, if not nil, is called with the
+// error of every failed attempt and with nil every time a newly resolved address is dialed.
+func newReconnectingUDPConn(hostPort string, bufferBytes int, resolveTimeout time.Duration, resolveFunc resolveFunc, dialFunc dialFunc, logger *log.Logger, maxBackoff time.Duration, onReconnect func(error)) (*reconnectingUDPConn, error) {
+	if maxBackoff <= resolveTimeout {
+		maxBackoff = 0
+	}
 	conn := &reconnectingUDPConn{
 		hostPort:    hostPort,
 		resolveFunc: resolveFunc,
 		dialFunc:    dialFunc,
 		logger:      logger,
+		maxBackoff:  maxBackoff,
+		onReconnect: onReconnect,
 		closeChan:   make(chan struct{}),
 		bufferBytes: int64(bufferBytes),
 	}
 
-	if err := conn.attemptResolveAndDial(); err != nil {
+	if err := conn.attemptReconnect(); err != nil {
+		conn.backOff(resolveTimeout)
 		conn.logf("failed resolving destination address on connection startup, with err: %q. retrying in %s", err.Error(), resolveTimeout)
 	}
 
@@ -71,25 +88,65 @@ func (c *reconnectingUDPConn) logf(format string, args ...interface{}) {
 }
 
 func (c *reconnectingUDPConn) reconnectLoop(resolveTimeout time.Duration) {
-	ticker := time.NewTicker(resolveTimeout)
-	defer ticker.Stop()
+	delay := resolveTimeout
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-c.closeChan:
 			return
-		case <-ticker.C:
-			if err := c.attemptResolveAndDial(); err != nil {
+		case <-timer.C:
+			if err := c.attemptReconnect(); err != nil {
 				c.logf("%s", err.Error())
+				delay = c.nextDelay(delay)
+				c.backOff(delay)
+			} else {
+				delay = resolveTimeout
 			}
+			timer.Reset(delay)
 		}
 	}
 }
 
-func (c *reconnectingUDPConn) attemptResolveAndDial() error {
+// nextDelay returns the delay to wait before the next attempt after an attempt following delay failed. The delay is
+// doubled up to maxBackoff when backing off, otherwise it stays the same.
+func (c *reconnectingUDPConn) nextDelay(delay time.Duration) time.Duration {
+	if delay >= c.maxBackoff {
+		return delay
+	}
+	if delay *= 2; delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+	return delay
+}
+
+// backOff prevents Write from attempting to reconnect for delay when backing off, that is when maxBackoff is greater
+// than resolveTimeout.
+func (c *reconnectingUDPConn) backOff(delay time.Duration) {
+	if c.maxBackoff > 0 {
+		atomic.StoreInt64(&c.retryAfter, time.Now().Add(delay).UnixNano())
+	}
+}
+
+// attemptReconnect calls attemptResolveAndDial and reports the result to onReconnect.
+func (c *reconnectingUDPConn) attemptReconnect() error {
+	dialed, err := c.attemptResolveAndDial()
+	if err == nil {
+		atomic.StoreInt64(&c.retryAfter, 0)
+	}
+	if c.onReconnect != nil && (err != nil || dialed) {
+		c.onReconnect(err)
+	}
+	return err
+}
+
+// attemptResolveAndDial resolves hostPort and dials the resolved address if it differs from the current one. It
+// returns true if a new address was dialed.
+func (c *reconnectingUDPConn) attemptResolveAndDial() (bool, error) {
 	newAddr, err := c.resolveFunc("udp", c.hostPort)
 	if err != nil {
-		return fmt.Errorf("failed to resolve new addr for host %q, with err: %w", c.hostPort, err)
+		return false, fmt.Errorf("failed to resolve new addr for host %q, with err: %w", c.hostPort, err)
 	}
 
 	c.connMtx.RLock()
@@ -98,14 +155,14 @@ func (c *reconnectingUDPConn) attemptResolveAndDial() error {
 
 	// dont attempt dial if an addr was successfully dialed previously and, resolved addr is the same as current conn
 	if curAddr != nil && newAddr.String() == curAddr.String() {
-		return nil
+		return false, nil
 	}
 
 	if err := c.attemptDialNewAddr(newAddr); err != nil {
-		return fmt.Errorf("failed to dial newly resolved addr '%s', with err: %w", newAddr, err)
+		return false, fmt.Errorf("failed to dial newly resolved addr '%s', with err: %w", newAddr, err)
 	}
 
-	return nil
+	return true, nil
 }
 
 func (c *reconnectingUDPConn) attemptDialNewAddr(newAddr *net.UDPAddr) error {
@@ -135,6 +192,7 @@ func (c *reconnectingUDPConn) attemptDialNewAddr(newAddr *net.UDPAddr) error {
 }
 
 // Write calls net.udpConn.Write, if it fails an attempt is made to connect to a new addr, if that succeeds the write is retried before returning.
+// While backing off after a failed attempt, no attempt is made and the original error is returned.
 func (c *reconnectingUDPConn) Write(b []byte) (int, error) {
 	var bytesWritten int
 	var err error
@@ -154,8 +212,12 @@ func (c *reconnectingUDPConn) Write(b []byte) (int, error) {
 		return bytesWritten, nil
 	}
 
+	if time.Now().UnixNano() < atomic.LoadInt64(&c.retryAfter) {
+		return bytesWritten, err
+	}
+
 	// attempt to resolve and dial new address in case that's the problem, if resolve and dial succeeds, try write again
-	if reconnErr := c.attemptResolveAndDial(); reconnErr == nil {
+	if reconnErr := c.attemptReconnect(); reconnErr == nil {
 		c.connMtx.RLock()
 		conn := c.conn
 		c.connMtx.RUnlock()
@@ -176,7 +176,7 @@ func TestNewResolvedUDPConn(t *testing.T) {
 		Return(clientConn, nil).
 		Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -211,7 +211,7 @@ func TestResolvedUDPConnWrites(t *testing.T) {
 		Return(clientConn, nil).
 		Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -248,7 +248,7 @@ func TestResolvedUDPConnEventuallyDials(t *testing.T) {
 		On("DialUDP", "udp", (*net.UDPAddr)(nil), mockUDPAddr).
 		Return(clientConn, nil).Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -299,7 +299,7 @@ func TestResolvedUDPConnNoSwapIfFail(t *testing.T) {
 		On("DialUDP", "udp", (*net.UDPAddr)(nil), mockUDPAddr).
 		Return(clientConn, nil).Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -340,7 +340,7 @@ func TestResolvedUDPConnWriteRetry(t *testing.T) {
 		On("DialUDP", "udp", (*net.UDPAddr)(nil), mockUDPAddr).
 		Return(clientConn, nil).Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -370,7 +370,7 @@ func TestResolvedUDPConnWriteRetryFails(t *testing.T) {
 
 	dialer := mockDialer{}
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -427,7 +427,7 @@ func TestResolvedUDPConnChanges(t *testing.T) {
 		On("DialUDP", "udp", (*net.UDPAddr)(nil), mockUDPAddr2).
 		Return(clientConn2, nil).Once()
 
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 
@@ -480,7 +480,7 @@ func TestResolvedUDPConnLoopWithoutChanges(t *testing.T) {
 		Once()
 
 	resolveTimeout := 500 * time.Millisecond
-	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, resolveTimeout, resolver.ResolveUDPAddr, dialer.DialUDP, nil)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, resolveTimeout, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 0, nil)
 	assert.NoError(t, err)
 	require.NotNil(t, conn)
 	assert.Equal(t, mockUDPAddr, conn.destAddr)
@@ -498,3 +498,126 @@ func TestResolvedUDPConnLoopWithoutChanges(t *testing.T) {
 	resolver.AssertExpectations(t)
 	dialer.AssertExpectations(t)
 }
+
+func TestResolvedUDPConnReconnectCallback(t *testing.T) {
+	hostPort := "blahblah:34322"
+
+	mockServer, clientConn, err := newUDPConn()
+	require.NoError(t, err)
+	defer mockServer.Close()
+
+	mockUDPAddr := newMockUDPAddr(t, 34322)
+
+	resolveErr := fmt.Errorf("failed to resolve")
+	resolver := mockResolver{}
+	resolver.
+		On("ResolveUDPAddr", "udp", hostPort).
+		Return(nil, resolveErr).Once().
+		On("ResolveUDPAddr", "udp", hostPort).
+		Return(mockUDPAddr, nil)
+
+	dialer := mockDialer{}
+	dialer.
+		On("DialUDP", "udp", (*net.UDPAddr)(nil), mockUDPAddr).
+		Return(clientConn, nil).Once()
+
+	results := make(chan error, 2)
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Millisecond*10, resolver.ResolveUDPAddr, dialer.DialUDP, nil, time.Second, func(err error) {
+		results <- err
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, conn)
+
+	for _, want := range []error{resolveErr, nil} {
+		select {
+		case got := <-results:
+			assert.ErrorIs(t, got, want)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for reconnect callback")
+		}
+	}
+
+	assertConnWritable(t, conn, mockServer)
+
+	err = conn.Close()
+	assert.NoError(t, err)
+
+	resolver.AssertExpectations(t)
+	dialer.AssertExpectations(t)
+}
+
+func TestResolvedUDPConnWriteBacksOff(t *testing.T) {
+	hostPort := "blahblah:34322"
+
+	resolver := mockResolver{}
+	resolver.
+		On("ResolveUDPAddr", "udp", hostPort).
+		Return(nil, fmt.Errorf("failed to resolve")).Once()
+
+	dialer := mockDialer{}
+
+	var calls int
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil, 2*time.Hour, func(err error) {
+		assert.Error(t, err)
+		calls++
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, conn)
+
+	// Writes do not block on reconnecting while backing off.
+	_, err = conn.Write([]byte("yo this is a test"))
+	assert.Error(t, err)
+	_, err = conn.Write([]byte("yo this is a test"))
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	err = conn.Close()
+	assert.NoError(t, err)
+
+	resolver.AssertExpectations(t)
+	dialer.AssertExpectations(t)
+}
+
+func TestResolvedUDPConnWriteNoBackOffBelowResolveTimeout(t *testing.T) {
+	hostPort := "blahblah:34322"
+
+	resolver := mockResolver{}
+	resolver.
+		On("ResolveUDPAddr", "udp", hostPort).
+		Return(nil, fmt.Errorf("failed to resolve")).Times(3)
+
+	dialer := mockDialer{}
+
+	var calls int
+	conn, err := newReconnectingUDPConn(hostPort, udpPacketMaxLength, time.Hour, resolver.ResolveUDPAddr, dialer.DialUDP, nil, time.Minute, func(err error) {
+		assert.Error(t, err)
+		calls++
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, conn)
+	assert.Zero(t, conn.maxBackoff)
+
+	// A maximum backoff below resolveTimeout does not back off, every
+	// failed write attempts to reconnect.
+	_, err = conn.Write([]byte("yo this is a test"))
+	assert.Error(t, err)
+	_, err = conn.Write([]byte("yo this is a test"))
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+
+	err = conn.Close()
+	assert.NoError(t, err)
+
+	resolver.AssertExpectations(t)
+	dialer.AssertExpectations(t)
+}
+
+func TestReconnectingUDPConnNextDelay(t *testing.T) {
+	conn := &reconnectingUDPConn{maxBackoff: 5 * time.Second}
+	assert.Equal(t, 2*time.Second, conn.nextDelay(time.Second))
+	assert.Equal(t, 5*time.Second, conn.nextDelay(4*time.Second))
+	assert.Equal(t, 5*time.Second, conn.nextDelay(5*time.Second))
+
+	conn.maxBackoff = 0
+	assert.Equal(t, time.Second, conn.nextDelay(time.Second))
+}
@@ -136,6 +136,29 @@ func WithAttemptReconnectingInterval(interval time.Duration) AgentEndpointOption
 	})
 }
 
+// WithMaxReconnectBackoff sets the maximum interval between attempts to re
+// resolve the agent endpoint. When set greater than the interval set with
+// WithAttemptReconnectingInterval, the interval is doubled after every failed
+// attempt up to max, and spans are not sent while waiting for the next
+// attempt instead of blocking on a new attempt. Otherwise, failed attempts
+// are not backed off.
+func WithMaxReconnectBackoff(max time.Duration) AgentEndpointOption {
+	return agentEndpointOptionFunc(func(o agentEndpointConfig) agentEndpointConfig {
+		o.MaxReconnectBackoff = max
+		return o
+	})
+}
+
+// WithReconnectCallback sets a function called with the error of every
+// failed attempt to re resolve and dial the agent endpoint, and with nil
+// every time a newly resolved endpoint is dialed.
+func WithReconnectCallback(fn func(err error)) AgentEndpointOption {
+	return agentEndpointOptionFunc(func(o agentEndpointConfig) agentEndpointConfig {
+		o.ReconnectCallback = fn
+		return o
+	})
+}
+
 // WithMaxPacketSize sets the maximum UDP packet size for transport to the Jaeger agent.
 func WithMaxPacketSize(size int) AgentEndpointOption {
 	return agentEndpointOptionFunc(func(o agentEndpointConfig) agentEndpointConfig {
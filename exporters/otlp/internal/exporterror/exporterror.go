@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exporterror classifies the errors the OTLP trace and metric
+// clients fail to export with.
+package exporterror // import "go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// The errors an export can fail with. The otlptrace and otlpmetric packages
+// export them for users to identify the failure modes with errors.Is.
+var (
+	// ErrExportTimeout is returned when an export did not complete before
+	// its deadline.
+	ErrExportTimeout = errors.New("export timeout")
+
+	// ErrCollectorUnavailable is returned when the collector could not be
+	// reached, or it responded that it was temporarily unable to handle the
+	// export. Sending the same data again at a later time may succeed.
+	ErrCollectorUnavailable = errors.New("collector unavailable")
+
+	// ErrCollectorRejected is returned when the collector received the
+	// export and refused it. Sending the same data again will not succeed.
+	ErrCollectorRejected = errors.New("collector rejected export")
+)
+
+// Error is an export error classified as one of the export errors. The
+// original error remains available with errors.As.
+type Error struct {
+	// Kind is the export error Err is classified as.
+	Kind error
+	// Err is the error returned by the underlying transport.
+	Err error
+}
+
+// Wrap returns err wrapped in an Error classified as kind. If err is nil,
+// kind is nil or err is already classified, err is returned as is.
+func Wrap(kind, err error) error {
+	if err == nil || kind == nil || errors.As(err, new(Error)) {
+		return err
+	}
+	return Error{Kind: kind, Err: err}
+}
+
+// Classify returns the export error err is an instance of, or nil if it is
+// none of them. Only the properties of err independent of the transport are
+// considered, clients check their transport specific errors first.
+func Classify(err error) error {
+	var cErr Error
+	if errors.As(err, &cErr) {
+		return cErr.Kind
+	}
+
+	var tErr interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &tErr) && tErr.Timeout()) {
+		return ErrExportTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	var uErr *url.Error
+	if errors.As(err, &uErr) {
+		// The collector could not be reached at all.
+		return ErrCollectorUnavailable
+	}
+	return nil
+}
+
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is the export error e is classified as.
+func (e Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Timeout returns true if e is classified as an ErrExportTimeout.
+func (e Error) Timeout() bool {
+	return e.Kind == ErrExportTimeout
+}
+
+// The gRPC status codes, as defined by the gRPC specification, the export
+// errors are classified from.
+const (
+	grpcOK                = 0
+	grpcCanceled          = 1
+	grpcDeadlineExceeded  = 4
+	grpcResourceExhausted = 8
+	grpcAborted           = 10
+	grpcOutOfRange        = 11
+	grpcUnavailable       = 14
+	grpcDataLoss          = 15
+)
+
+// ClassifyGRPCCode returns the export error a gRPC export failing with the
+// status code is an instance of, or nil if the code is OK.
+func ClassifyGRPCCode(code uint32) error {
+	switch code {
+	case grpcOK:
+		return nil
+	case grpcDeadlineExceeded:
+		return ErrExportTimeout
+	case grpcCanceled,
+		grpcResourceExhausted,
+		grpcAborted,
+		grpcOutOfRange,
+		grpcUnavailable,
+		grpcDataLoss:
+		return ErrCollectorUnavailable
+	}
+	return ErrCollectorRejected
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"other", errors.New("other"), nil},
+		{"canceled", context.Canceled, nil},
+		{"deadline", fmt.Errorf("export: %w", context.DeadlineExceeded), ErrExportTimeout},
+		{"url", &url.Error{Op: "Post", URL: "http://localhost", Err: errors.New("refused")}, ErrCollectorUnavailable},
+		{"classified", Error{Kind: ErrCollectorRejected, Err: errors.New("rejected")}, ErrCollectorRejected},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Classify(tc.err))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	err := errors.New("unavailable")
+	wrapped := Wrap(ErrCollectorUnavailable, err)
+	assert.ErrorIs(t, wrapped, ErrCollectorUnavailable)
+	assert.ErrorIs(t, wrapped, err)
+	assert.NotErrorIs(t, wrapped, ErrCollectorRejected)
+	assert.Equal(t, err.Error(), wrapped.Error())
+
+	assert.Equal(t, wrapped, Wrap(ErrCollectorRejected, wrapped), "classified error reclassified")
+	assert.Equal(t, err, Wrap(nil, err))
+	assert.Nil(t, Wrap(ErrCollectorRejected, nil))
+}
+
+func TestClassifyGRPCCode(t *testing.T) {
+	assert.Nil(t, ClassifyGRPCCode(grpcOK))
+	assert.Equal(t, ErrExportTimeout, ClassifyGRPCCode(grpcDeadlineExceeded))
+	assert.Equal(t, ErrCollectorUnavailable, ClassifyGRPCCode(grpcUnavailable))
+	assert.Equal(t, ErrCollectorRejected, ClassifyGRPCCode(3))
+}
@@ -97,6 +97,12 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 				return nil
 			}
 
+			// The deadline of the caller passed or it gave up on the
+			// request, a retry would fail the same way.
+			if ctx.Err() != nil {
+				return err
+			}
+
 			retryable, throttle := evaluate(err)
 			if !retryable {
 				return err
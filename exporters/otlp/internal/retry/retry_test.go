@@ -150,6 +150,28 @@ func TestBackoffRetry(t *testing.T) {
 	}), assert.AnError)
 }
 
+func TestNoRetryAfterContextDone(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	var calls int
+	assert.ErrorIs(t, reqFunc(ctx, func(ctx context.Context) error {
+		calls++
+		return ctx.Err()
+	}), context.DeadlineExceeded)
+	assert.Equal(t, 1, calls)
+}
+
 func TestThrottledRetryGreaterThanMaxElapsedTime(t *testing.T) {
 	// Ensure the throttle delay is used by making longer than backoff delay.
 	tDelay, bDelay := time.Hour, time.Nanosecond
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetric // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
+)
+
+// The errors an export can fail with. Clients wrap the error returned by the
+// underlying transport so errors.Is can be used to identify these failure
+// modes while the original error remains available with errors.As. They are
+// the same errors the otlptrace clients fail with.
+var (
+	// ErrExportTimeout is returned when an export did not complete before
+	// its deadline.
+	ErrExportTimeout = exporterror.ErrExportTimeout
+
+	// ErrCollectorUnavailable is returned when the collector could not be
+	// reached, or it responded that it was temporarily unable to handle the
+	// export. Sending the same data again at a later time may succeed.
+	ErrCollectorUnavailable = exporterror.ErrCollectorUnavailable
+
+	// ErrCollectorRejected is returned when the collector received the
+	// export and refused it. Sending the same data again will not succeed.
+	ErrCollectorRejected = exporterror.ErrCollectorRejected
+)
+
+// PartialSuccessError is sent to the global error handler when the collector
+// accepted an export but rejected some of the data points it contained. The
+// export itself succeeded and the accepted data points must not be sent again.
+type PartialSuccessError struct {
+	// RejectedDataPoints is the number of data points the collector
+	// rejected.
+	RejectedDataPoints int64
+	// Message is the explanation the collector gave for the rejection, if
+	// any.
+	Message string
+}
+
+func (e *PartialSuccessError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("partial success: %d data points rejected", e.RejectedDataPoints)
+	}
+	return fmt.Sprintf("partial success: %d data points rejected: %s", e.RejectedDataPoints, e.Message)
+}
@@ -34,6 +34,8 @@ var (
 type Exporter struct {
 	client              Client
 	temporalitySelector aggregation.TemporalitySelector
+	// coercer is nil unless unknown number kinds are exported as int64.
+	coercer *numberKindCoercer
 
 	mu      sync.RWMutex
 	started bool
@@ -44,19 +46,22 @@ type Exporter struct {
 
 // Export exports a batch of metrics.
 func (e *Exporter) Export(ctx context.Context, res *resource.Resource, ilr export.InstrumentationLibraryReader) error {
-	rm, err := metrictransform.InstrumentationLibraryReader(ctx, e, res, ilr, 1)
+	if e.coercer != nil {
+		ilr = e.coercer.libraryReader(ilr)
+	}
+	rms, err := metrictransform.InstrumentationLibraryReader(ctx, e, res, ilr, 1)
 	if err != nil {
 		return err
 	}
-	if rm == nil {
-		return nil
-	}
 
-	// TODO: There is never more than one resource emitted by this
-	// call, as per the specification.  We can change the
-	// signature of UploadMetrics correspondingly. Here create a
-	// singleton list to reduce the size of the current PR:
-	return e.client.UploadMetrics(ctx, rm)
+	// More than one resource is only emitted when records are associated
+	// with their own Resource, each one is uploaded separately.
+	for _, rm := range rms {
+		if err := e.client.UploadMetrics(ctx, rm); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Start establishes a connection to the receiving endpoint.
@@ -94,7 +99,11 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 	return err
 }
 
-// TemporalityFor returns the accepted temporality for a metric measurment.
+// TemporalityFor returns the accepted temporality for a metric measurement.
+//
+// This is the AggregationTemporality the exporter sets on the OTLP Sum and
+// Histogram data it exports for the descriptor and aggregation kind, as
+// determined by the configured aggregation.TemporalitySelector.
 func (e *Exporter) TemporalityFor(descriptor *sdkapi.Descriptor, kind aggregation.Kind) aggregation.Temporality {
 	return e.temporalitySelector.TemporalityFor(descriptor, kind)
 }
@@ -127,6 +136,9 @@ func NewUnstarted(client Client, opts ...Option) *Exporter {
 		client:              client,
 		temporalitySelector: cfg.temporalitySelector,
 	}
+	if cfg.coerceUnknownNumberKinds {
+		e.coercer = &numberKindCoercer{}
+	}
 
 	return e
 }
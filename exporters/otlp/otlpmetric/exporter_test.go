@@ -17,6 +17,7 @@ package otlpmetric_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,9 +26,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/metrictransform"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
@@ -725,6 +728,53 @@ func TestStatelessAggregationTemporality(t *testing.T) {
 	}
 }
 
+func TestTemporalityFor(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		selector aggregation.TemporalitySelector
+		want     map[sdkapi.InstrumentKind]aggregation.Temporality
+	}{
+		{
+			name: "default",
+			want: map[sdkapi.InstrumentKind]aggregation.Temporality{
+				sdkapi.CounterInstrumentKind:               aggregation.CumulativeTemporality,
+				sdkapi.UpDownCounterInstrumentKind:         aggregation.CumulativeTemporality,
+				sdkapi.HistogramInstrumentKind:             aggregation.CumulativeTemporality,
+				sdkapi.CounterObserverInstrumentKind:       aggregation.CumulativeTemporality,
+				sdkapi.UpDownCounterObserverInstrumentKind: aggregation.CumulativeTemporality,
+			},
+		},
+		{
+			name:     "stateless",
+			selector: aggregation.StatelessTemporalitySelector(),
+			want: map[sdkapi.InstrumentKind]aggregation.Temporality{
+				sdkapi.CounterInstrumentKind:               aggregation.DeltaTemporality,
+				sdkapi.UpDownCounterInstrumentKind:         aggregation.DeltaTemporality,
+				sdkapi.HistogramInstrumentKind:             aggregation.DeltaTemporality,
+				sdkapi.CounterObserverInstrumentKind:       aggregation.CumulativeTemporality,
+				sdkapi.UpDownCounterObserverInstrumentKind: aggregation.CumulativeTemporality,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []otlpmetric.Option
+			if tc.selector != nil {
+				opts = append(opts, otlpmetric.WithMetricAggregationTemporalitySelector(tc.selector))
+			}
+			exp, _ := newExporter(t, opts...)
+
+			for ikind, want := range tc.want {
+				desc := metrictest.NewDescriptor("instrument", ikind, number.Int64Kind)
+				akind := aggregation.SumKind
+				if ikind == sdkapi.HistogramInstrumentKind {
+					akind = aggregation.HistogramKind
+				}
+				assert.Equal(t, want, exp.TemporalityFor(&desc, akind), ikind.String())
+			}
+		})
+	}
+}
+
 func runMetricExportTests(t *testing.T, opts []otlpmetric.Option, res *resource.Resource, records []testRecord, expected []*metricpb.ResourceMetrics) {
 	exp, driver := newExporter(t, opts...)
 
@@ -846,3 +896,77 @@ func TestEmptyMetricExport(t *testing.T) {
 		assert.Equal(t, test.want, driver.rm)
 	}
 }
+
+func TestUnknownNumberKindAsInt64(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	desc := metrictest.NewDescriptor("foo", sdkapi.CounterInstrumentKind, number.Kind(-1))
+	intDesc := metrictest.NewDescriptor("foo", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	agg := &sum.New(1)[0]
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(3), &intDesc))
+	attrs := attribute.NewSet(attribute.String("abc", "def"))
+	reader := processortest.MultiInstrumentationLibraryReader(map[instrumentation.Library][]export.Record{
+		{Name: testLibName}: {export.NewRecord(&desc, &attrs, agg.Aggregation(), intervalStart, intervalEnd)},
+	})
+
+	// The metric is dropped by default.
+	exp, driver := newExporter(t)
+	require.NoError(t, exp.Export(context.Background(), resource.Empty(), reader))
+	assert.Empty(t, driver.rm)
+	assert.Empty(t, errs)
+
+	exp, driver = newExporter(t, otlpmetric.WithUnknownNumberKindAsInt64())
+	for i := 0; i < 2; i++ {
+		require.NoError(t, exp.Export(context.Background(), resource.Empty(), reader))
+	}
+	// Only the first export of the instrument is reported.
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], metrictransform.ErrUnknownValueType)
+
+	require.Len(t, driver.rm, 2)
+	ms := driver.rm[0].ScopeMetrics[0].Metrics
+	require.Len(t, ms, 1)
+	assert.Equal(t, "foo", ms[0].Name)
+	dps := ms[0].GetSum().GetDataPoints()
+	require.Len(t, dps, 1)
+	assert.Equal(t, int64(3), dps[0].GetAsInt())
+}
+
+func TestRecordResourceExport(t *testing.T) {
+	desc := metrictest.NewDescriptor("foo", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	agg := &sum.New(1)[0]
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(3), &desc))
+	attrs := attribute.NewSet(attribute.String("abc", "def"))
+	podResource := resource.NewSchemaless(attribute.String("pod", "p1"))
+	reader := processortest.MultiInstrumentationLibraryReader(map[instrumentation.Library][]export.Record{
+		{Name: testLibName}: {
+			export.NewRecord(&desc, &attrs, agg.Aggregation(), intervalStart, intervalEnd),
+			export.NewRecordWithResource(&desc, &attrs, podResource, agg.Aggregation(), intervalStart, intervalEnd),
+		},
+	})
+
+	exp, driver := newExporter(t)
+	require.NoError(t, exp.Export(context.Background(), testerAResource, reader))
+
+	// Records of different resources are not exported as duplicate points
+	// of the same ResourceMetrics.
+	require.Len(t, driver.rm, 2)
+	got := map[string]int{}
+	for _, rm := range driver.rm {
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		var keys []string
+		for _, kv := range rm.Resource.Attributes {
+			keys = append(keys, kv.Key+"="+kv.Value.GetStringValue())
+		}
+		got[strings.Join(keys, ",")] = len(rm.ScopeMetrics[0].Metrics[0].GetSum().GetDataPoints())
+	}
+	assert.Equal(t, map[string]int{
+		"instance=tester-a":        1,
+		"instance=tester-a,pod=p1": 1,
+	}, got)
+}
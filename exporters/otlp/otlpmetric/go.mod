@@ -10,7 +10,7 @@ require (
 	go.opentelemetry.io/otel/metric v0.31.0
 	go.opentelemetry.io/otel/sdk v1.9.0
 	go.opentelemetry.io/otel/sdk/metric v0.31.0
-	go.opentelemetry.io/proto/otlp v0.18.0
+	go.opentelemetry.io/proto/otlp v0.19.0
 	google.golang.org/grpc v1.46.2
 	google.golang.org/protobuf v1.28.0
 )
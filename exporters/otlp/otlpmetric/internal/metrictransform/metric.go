@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
@@ -58,7 +59,17 @@ var (
 // result is the product of transforming Records into OTLP Metrics.
 type result struct {
 	Metric *metricpb.Metric
-	Err    error
+	// Resource is the Resource associated with the transformed Record, or
+	// nil if there is none.
+	Resource *resource.Resource
+	Err      error
+}
+
+// resourceMetrics are the OTLP Metrics transformed from the Records
+// associated with the same Resource.
+type resourceMetrics struct {
+	Resource *resource.Resource
+	Metrics  []*metricpb.Metric
 }
 
 // toNanos returns the number of nanoseconds since the UNIX epoch.
@@ -71,8 +82,13 @@ func toNanos(t time.Time) uint64 {
 
 // InstrumentationLibraryReader transforms all records contained in a checkpoint into
 // batched OTLP ResourceMetrics.
-func InstrumentationLibraryReader(ctx context.Context, temporalitySelector aggregation.TemporalitySelector, res *resource.Resource, ilmr export.InstrumentationLibraryReader, numWorkers uint) (*metricpb.ResourceMetrics, error) {
-	var sms []*metricpb.ScopeMetrics
+//
+// Records associated with a Resource, see export.Metadata.Resource, are
+// batched in their own ResourceMetrics with that Resource merged into res.
+// All other records are batched in a ResourceMetrics with res.
+func InstrumentationLibraryReader(ctx context.Context, temporalitySelector aggregation.TemporalitySelector, res *resource.Resource, ilmr export.InstrumentationLibraryReader, numWorkers uint) ([]*metricpb.ResourceMetrics, error) {
+	var rms []*metricpb.ResourceMetrics
+	byResource := map[attribute.Distinct]*metricpb.ResourceMetrics{}
 
 	err := ilmr.ForEach(func(lib instrumentation.Library, mr export.Reader) error {
 		records, errc := source(ctx, temporalitySelector, mr)
@@ -93,7 +109,7 @@ func InstrumentationLibraryReader(ctx context.Context, temporalitySelector aggre
 		}()
 
 		// Synchronously collect the transformed records and transmit.
-		ms, err := sink(ctx, transformed)
+		grouped, err := sink(ctx, transformed)
 		if err != nil {
 			return nil
 		}
@@ -102,30 +118,38 @@ func InstrumentationLibraryReader(ctx context.Context, temporalitySelector aggre
 		if err := <-errc; err != nil {
 			return err
 		}
-		if len(ms) == 0 {
-			return nil
-		}
 
-		sms = append(sms, &metricpb.ScopeMetrics{
-			Metrics:   ms,
-			SchemaUrl: lib.SchemaURL,
-			Scope: &commonpb.InstrumentationScope{
-				Name:    lib.Name,
-				Version: lib.Version,
-			},
-		})
+		for _, g := range grouped {
+			rm, ok := byResource[g.Resource.Equivalent()]
+			if !ok {
+				merged := res
+				if g.Resource != nil {
+					var err error
+					if merged, err = resource.Merge(res, g.Resource); err != nil {
+						return err
+					}
+				}
+				rm = &metricpb.ResourceMetrics{
+					Resource:  Resource(merged),
+					SchemaUrl: merged.SchemaURL(),
+				}
+				byResource[g.Resource.Equivalent()] = rm
+				rms = append(rms, rm)
+			}
+			rm.ScopeMetrics = append(rm.ScopeMetrics, &metricpb.ScopeMetrics{
+				Metrics:   g.Metrics,
+				SchemaUrl: lib.SchemaURL,
+				Scope: &commonpb.InstrumentationScope{
+					Name:    lib.Name,
+					Version: lib.Version,
+				},
+			})
+		}
 		return nil
 	})
-	if len(sms) == 0 {
+	if len(rms) == 0 {
 		return nil, err
 	}
-
-	rms := &metricpb.ResourceMetrics{
-		Resource:     Resource(res),
-		SchemaUrl:    res.SchemaURL(),
-		ScopeMetrics: sms,
-	}
-
 	return rms, err
 }
 
@@ -161,8 +185,9 @@ func transformer(ctx context.Context, temporalitySelector aggregation.Temporalit
 			continue
 		}
 		res := result{
-			Metric: m,
-			Err:    err,
+			Metric:   m,
+			Resource: r.Resource(),
+			Err:      err,
 		}
 		select {
 		case <-ctx.Done():
@@ -172,26 +197,36 @@ func transformer(ctx context.Context, temporalitySelector aggregation.Temporalit
 	}
 }
 
-// sink collects transformed Records and batches them.
+// sink collects transformed Records and batches them by their Resource.
 //
 // Any errors encountered transforming input will be reported with an
 // ErrTransforming as well as the completed ResourceMetrics. It is up to the
 // caller to handle any incorrect data in these ResourceMetric.
-func sink(ctx context.Context, in <-chan result) ([]*metricpb.Metric, error) {
+func sink(ctx context.Context, in <-chan result) ([]resourceMetrics, error) {
 	var errStrings []string
 
-	// Group by the MetricDescriptor.
-	grouped := map[string]*metricpb.Metric{}
+	type groupKey struct {
+		resource attribute.Distinct
+		name     string
+	}
+
+	// Group by the Resource and MetricDescriptor.
+	grouped := map[groupKey]*metricpb.Metric{}
+	resources := map[attribute.Distinct]*resource.Resource{}
 	for res := range in {
 		if res.Err != nil {
 			errStrings = append(errStrings, res.Err.Error())
 			continue
 		}
 
-		mID := res.Metric.GetName()
+		mID := groupKey{
+			resource: res.Resource.Equivalent(),
+			name:     res.Metric.GetName(),
+		}
 		m, ok := grouped[mID]
 		if !ok {
 			grouped[mID] = res.Metric
+			resources[mID.resource] = res.Resource
 			continue
 		}
 		// Note: There is extra work happening in this code that can be
@@ -206,6 +241,8 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.Metric, error) {
 			m.GetSum().DataPoints = append(m.GetSum().DataPoints, res.Metric.GetSum().DataPoints...)
 		case *metricpb.Metric_Histogram:
 			m.GetHistogram().DataPoints = append(m.GetHistogram().DataPoints, res.Metric.GetHistogram().DataPoints...)
+		case *metricpb.Metric_ExponentialHistogram:
+			m.GetExponentialHistogram().DataPoints = append(m.GetExponentialHistogram().DataPoints, res.Metric.GetExponentialHistogram().DataPoints...)
 		case *metricpb.Metric_Summary:
 			m.GetSummary().DataPoints = append(m.GetSummary().DataPoints, res.Metric.GetSummary().DataPoints...)
 		default:
@@ -218,16 +255,23 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.Metric, error) {
 		return nil, nil
 	}
 
-	ms := make([]*metricpb.Metric, 0, len(grouped))
-	for _, m := range grouped {
-		ms = append(ms, m)
+	byResource := map[attribute.Distinct]int{}
+	var rms []resourceMetrics
+	for k, m := range grouped {
+		i, ok := byResource[k.resource]
+		if !ok {
+			i = len(rms)
+			byResource[k.resource] = i
+			rms = append(rms, resourceMetrics{Resource: resources[k.resource]})
+		}
+		rms[i].Metrics = append(rms[i].Metrics, m)
 	}
 
 	// Report any transform errors.
 	if len(errStrings) > 0 {
-		return ms, fmt.Errorf("%w:\n -%s", ErrTransforming, strings.Join(errStrings, "\n -"))
+		return rms, fmt.Errorf("%w:\n -%s", ErrTransforming, strings.Join(errStrings, "\n -"))
 	}
-	return ms, nil
+	return rms, nil
 }
 
 // Record transforms a Record into an OTLP Metric. An ErrIncompatibleAgg
@@ -242,6 +286,13 @@ func Record(temporalitySelector aggregation.TemporalitySelector, r export.Record
 		}
 		return histogramPoint(r, temporalitySelector.TemporalityFor(r.Descriptor(), aggregation.HistogramKind), h)
 
+	case aggregation.ExponentialHistogramKind:
+		h, ok := agg.(aggregation.ExponentialHistogram)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return exponentialHistogramPoint(r, temporalitySelector.TemporalityFor(r.Descriptor(), aggregation.ExponentialHistogramKind), h)
+
 	case aggregation.SumKind:
 		s, ok := agg.(aggregation.Sum)
 		if !ok {
@@ -435,3 +486,82 @@ func histogramPoint(record export.Record, temporality aggregation.Temporality, a
 	}
 	return m, nil
 }
+
+// exponentialBuckets transforms ExponentialBuckets into their OTLP
+// representation.
+func exponentialBuckets(b aggregation.ExponentialBuckets, err error) (*metricpb.ExponentialHistogramDataPoint_Buckets, error) {
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]uint64, b.Len())
+	for i := range counts {
+		counts[i] = b.At(uint32(i))
+	}
+	return &metricpb.ExponentialHistogramDataPoint_Buckets{
+		Offset:       b.Offset(),
+		BucketCounts: counts,
+	}, nil
+}
+
+// exponentialHistogramPoint transforms an ExponentialHistogram
+// Aggregator into an OTLP Metric.
+func exponentialHistogramPoint(record export.Record, temporality aggregation.Temporality, a aggregation.ExponentialHistogram) (*metricpb.Metric, error) {
+	desc := record.Descriptor()
+	attrs := record.Attributes()
+
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+
+	scale, err := a.Scale()
+	if err != nil {
+		return nil, err
+	}
+
+	zeroCount, err := a.ZeroCount()
+	if err != nil {
+		return nil, err
+	}
+
+	positive, err := exponentialBuckets(a.Positive())
+	if err != nil {
+		return nil, err
+	}
+
+	negative, err := exponentialBuckets(a.Negative())
+	if err != nil {
+		return nil, err
+	}
+
+	sumFloat64 := sum.CoerceToFloat64(desc.NumberKind())
+	m := &metricpb.Metric{
+		Name:        desc.Name(),
+		Description: desc.Description(),
+		Unit:        string(desc.Unit()),
+		Data: &metricpb.Metric_ExponentialHistogram{
+			ExponentialHistogram: &metricpb.ExponentialHistogram{
+				AggregationTemporality: sdkTemporalityToTemporality(temporality),
+				DataPoints: []*metricpb.ExponentialHistogramDataPoint{
+					{
+						Sum:               &sumFloat64,
+						Attributes:        Iterator(attrs.Iter()),
+						StartTimeUnixNano: toNanos(record.StartTime()),
+						TimeUnixNano:      toNanos(record.EndTime()),
+						Count:             count,
+						Scale:             scale,
+						ZeroCount:         zeroCount,
+						Positive:          positive,
+						Negative:          negative,
+					},
+				},
+			},
+		},
+	}
+	return m, nil
+}
@@ -26,6 +26,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
 	"go.opentelemetry.io/otel/sdk/metric/export"
@@ -200,6 +201,51 @@ func TestLastValueIntDataPoints(t *testing.T) {
 	}
 }
 
+func TestExponentialHistogramDataPoints(t *testing.T) {
+	desc := metrictest.NewDescriptor("", sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	attrs := attribute.NewSet(attribute.String("one", "1"))
+	aggs := exponential.New(2, &desc, exponential.WithMaxScale(0))
+	agg, ckpt := &aggs[0], &aggs[1]
+
+	for _, v := range []float64{0, 1, 2, -4} {
+		assert.NoError(t, agg.Update(context.Background(), number.NewFloat64Number(v), &desc))
+	}
+	require.NoError(t, agg.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &attrs, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	sum := -1.0
+	m, err := Record(aggregation.DeltaTemporalitySelector(), record)
+	require.NoError(t, err)
+	assert.Equal(t, &metricpb.ExponentialHistogram{
+		AggregationTemporality: otelDelta,
+		DataPoints: []*metricpb.ExponentialHistogramDataPoint{{
+			StartTimeUnixNano: uint64(intervalStart.UnixNano()),
+			TimeUnixNano:      uint64(intervalEnd.UnixNano()),
+			Attributes: []*commonpb.KeyValue{
+				{
+					Key:   "one",
+					Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "1"}},
+				},
+			},
+			Count:     4,
+			Sum:       &sum,
+			Scale:     0,
+			ZeroCount: 1,
+			Positive: &metricpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       0,
+				BucketCounts: []uint64{1, 1},
+			},
+			Negative: &metricpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       2,
+				BucketCounts: []uint64{1},
+			},
+		}},
+	}, m.GetExponentialHistogram())
+	assert.Nil(t, m.GetHistogram())
+	assert.Nil(t, m.GetSum())
+	assert.Nil(t, m.GetGauge())
+}
+
 func TestSumErrUnknownValueType(t *testing.T) {
 	desc := metrictest.NewDescriptor("", sdkapi.HistogramInstrumentKind, number.Kind(-1))
 	attrs := attribute.NewSet()
@@ -289,6 +335,12 @@ func TestRecordAggregatorIncompatibleErrors(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, mpb)
 	require.True(t, errors.Is(err, ErrIncompatibleAgg))
+
+	mpb, err = makeMpb(aggregation.ExponentialHistogramKind, &sum.New(1)[0])
+
+	require.Error(t, err)
+	require.Nil(t, mpb)
+	require.True(t, errors.Is(err, ErrIncompatibleAgg))
 }
 
 func TestRecordAggregatorUnexpectedErrors(t *testing.T) {
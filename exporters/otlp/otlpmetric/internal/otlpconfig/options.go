@@ -70,6 +70,7 @@ type (
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+		MaxMessageSize     int
 	}
 )
 
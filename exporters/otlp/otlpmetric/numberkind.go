@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetric // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/metrictransform"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+)
+
+// numberKindCoercer coerces the Records of instruments with an unknown
+// number.Kind to number.Int64Kind.
+type numberKindCoercer struct {
+	// warned holds the names of the instruments already reported to the
+	// error handler.
+	warned sync.Map
+}
+
+// libraryReader returns ilr with the Records of all its Readers coerced.
+func (c *numberKindCoercer) libraryReader(ilr export.InstrumentationLibraryReader) export.InstrumentationLibraryReader {
+	return coercingLibraryReader{ilr, c}
+}
+
+type coercingLibraryReader struct {
+	export.InstrumentationLibraryReader
+	coercer *numberKindCoercer
+}
+
+func (r coercingLibraryReader) ForEach(readerFunc func(instrumentation.Library, export.Reader) error) error {
+	return r.InstrumentationLibraryReader.ForEach(func(lib instrumentation.Library, mr export.Reader) error {
+		return readerFunc(lib, coercingReader{mr, r.coercer})
+	})
+}
+
+type coercingReader struct {
+	export.Reader
+	coercer *numberKindCoercer
+}
+
+func (r coercingReader) ForEach(selector aggregation.TemporalitySelector, recordFunc func(export.Record) error) error {
+	return r.Reader.ForEach(selector, func(rec export.Record) error {
+		return recordFunc(r.coercer.coerce(rec))
+	})
+}
+
+// coerce returns rec unchanged if its number.Kind is known, otherwise it
+// returns rec with its number.Kind set to number.Int64Kind, reporting it to
+// the error handler the first time for each instrument.
+func (c *numberKindCoercer) coerce(rec export.Record) export.Record {
+	desc := rec.Descriptor()
+	switch desc.NumberKind() {
+	case number.Int64Kind, number.Float64Kind:
+		return rec
+	}
+
+	if _, loaded := c.warned.LoadOrStore(desc.Name(), struct{}{}); !loaded {
+		otel.Handle(fmt.Errorf(
+			"%w: %v of instrument %q, exporting it as int64",
+			metrictransform.ErrUnknownValueType, desc.NumberKind(), desc.Name(),
+		))
+	}
+
+	coerced := sdkapi.NewDescriptor(desc.Name(), desc.InstrumentKind(), number.Int64Kind, desc.Description(), desc.Unit())
+	return export.NewRecordWithResource(&coerced, rec.Attributes(), rec.Resource(), rec.Aggregation(), rec.StartTime(), rec.EndTime())
+}
@@ -28,7 +28,8 @@ func (fn exporterOptionFunc) apply(cfg config) config {
 }
 
 type config struct {
-	temporalitySelector aggregation.TemporalitySelector
+	temporalitySelector      aggregation.TemporalitySelector
+	coerceUnknownNumberKinds bool
 }
 
 // WithMetricAggregationTemporalitySelector defines the aggregation.TemporalitySelector used
@@ -41,3 +42,15 @@ func WithMetricAggregationTemporalitySelector(selector aggregation.TemporalitySe
 		return cfg
 	})
 }
+
+// WithUnknownNumberKindAsInt64 configures the exporter to export the metrics
+// of instruments with an unknown number.Kind as int64 values. The first
+// export of each such instrument is reported to the OpenTelemetry error
+// handler. If not specified, these metrics fail to transform and are not
+// exported.
+func WithUnknownNumberKindAsInt64() Option {
+	return exporterOptionFunc(func(cfg config) config {
+		cfg.coerceUnknownNumberKinds = true
+		return cfg
+	})
+}
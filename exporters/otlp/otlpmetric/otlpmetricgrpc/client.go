@@ -26,6 +26,8 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
@@ -40,6 +42,10 @@ type client struct {
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
 
+	// maxMessageSize is the maximum size of an export request. If not
+	// positive, requests are not split.
+	maxMessageSize int
+
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
 	stopCtx context.Context
@@ -77,6 +83,8 @@ func newClient(opts ...Option) *client {
 		stopCtx:       ctx,
 		stopFunc:      cancel,
 		conn:          cfg.GRPCConn,
+
+		maxMessageSize: cfg.MaxMessageSize,
 	}
 
 	if len(cfg.Metrics.Headers) > 0 {
@@ -179,7 +187,11 @@ var errShutdown = errors.New("the client is shutdown")
 // UploadMetrics sends a batch of spans.
 //
 // Retryable errors from the server will be handled according to any
-// RetryConfig the client was created with.
+// RetryConfig the client was created with. The returned error matches the
+// otlpmetric export error it is classified as with errors.Is. If the server
+// accepts the batch but rejects some of the data points, an
+// *otlpmetric.PartialSuccessError is sent to the global error handler, nil is
+// returned and nothing is retried.
 func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	// Hold a read lock to ensure a shut down initiated after this starts does
 	// not abandon the export. This read lock acquire has less priority than a
@@ -195,17 +207,51 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		_, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
+	if c.maxMessageSize <= 0 {
+		return c.export(ctx, protoMetrics)
+	}
+
+	// Every part is sent, even if an earlier one failed, so a single
+	// rejected request does not drop the data points of all the others.
+	var firstErr error
+	for _, rm := range splitResourceMetrics(protoMetrics, c.maxMessageSize) {
+		if err := c.export(ctx, rm); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// export sends protoMetrics in a single export request.
+func (c *client) export(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		resp, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
 			ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 		})
 		// nil is converted to OK.
 		if status.Code(err) == codes.OK {
-			// Success.
+			// Success, but the collector may have rejected part of the
+			// data points. That is not retry-able as it would resend the
+			// accepted data points.
+			handlePartialSuccess(resp.GetPartialSuccess())
 			return nil
 		}
 		return err
 	})
+	return wrapError(err)
+}
+
+// handlePartialSuccess sends an *otlpmetric.PartialSuccessError describing
+// ps to the global error handler if ps reports any rejected data points or a
+// message.
+func handlePartialSuccess(ps *colmetricpb.ExportMetricsPartialSuccess) {
+	if ps.GetRejectedDataPoints() == 0 && ps.GetErrorMessage() == "" {
+		return
+	}
+	otel.Handle(&otlpmetric.PartialSuccessError{
+		RejectedDataPoints: ps.GetRejectedDataPoints(),
+		Message:            ps.GetErrorMessage(),
+	})
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -246,16 +292,9 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 // retryable returns if err identifies a request that can be retried and a
 // duration to wait for if an explicit throttle time is included in err.
 func retryable(err error) (bool, time.Duration) {
-	//func retryable(err error) (bool, time.Duration) {
-	s := status.Convert(err)
-	switch s.Code() {
-	case codes.Canceled,
-		codes.DeadlineExceeded,
-		codes.ResourceExhausted,
-		codes.Aborted,
-		codes.OutOfRange,
-		codes.Unavailable,
-		codes.DataLoss:
+	switch classify(err) {
+	case otlpmetric.ErrExportTimeout, otlpmetric.ErrCollectorUnavailable:
+		s, _ := statusOf(err)
 		return true, throttleDelay(s)
 	}
 
@@ -263,6 +302,59 @@ func retryable(err error) (bool, time.Duration) {
 	return false, 0
 }
 
+// classify returns the otlpmetric export error err is an instance of, or nil
+// if it is none of them.
+func classify(err error) error {
+	if s, ok := statusOf(err); ok {
+		return exporterror.ClassifyGRPCCode(uint32(s.Code()))
+	}
+	return exporterror.Classify(err)
+}
+
+// statusOf returns the status of the gRPC error wrapped in err, if any.
+func statusOf(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus(), true
+	}
+	return nil, false
+}
+
+// exportError is an export error classified as one of the otlpmetric export
+// errors that can still be inspected with the status package.
+type exportError struct {
+	classified exporterror.Error
+}
+
+// wrapError returns err wrapped so it matches the otlpmetric export error it
+// is classified as. If err is not classified, it is returned as is.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if kind := classify(err); kind != nil {
+		return exportError{classified: exporterror.Error{Kind: kind, Err: err}}
+	}
+	return err
+}
+
+func (e exportError) Error() string {
+	return e.classified.Error()
+}
+
+func (e exportError) Unwrap() error {
+	return e.classified
+}
+
+// GRPCStatus returns the status of the wrapped error so e can still be
+// inspected with the status package.
+func (e exportError) GRPCStatus() *status.Status {
+	if s, ok := statusOf(e.classified.Err); ok {
+		return s
+	}
+	return status.New(codes.Unknown, e.classified.Err.Error())
+}
+
 // throttleDelay returns a duration to wait for if an explicit throttle time
 // is included in the response status.
 func throttleDelay(s *status.Status) time.Duration {
@@ -29,10 +29,15 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/sdk/resource"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
 var (
@@ -329,3 +334,97 @@ func TestFailedMetricTransform(t *testing.T) {
 
 	assert.Error(t, exp.Export(ctx, testResource, otlpmetrictest.FailReader{}))
 }
+
+func TestPartialSuccess(t *testing.T) {
+	mc := runMockCollector(t)
+	mc.metricSvc.partialSuccess = &colmetricpb.ExportMetricsPartialSuccess{
+		RejectedDataPoints: 2,
+		ErrorMessage:       "partially successful",
+	}
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	require.NoError(t, exp.Export(ctx, testResource, oneRecord))
+	require.Len(t, errs, 1)
+	var psErr *otlpmetric.PartialSuccessError
+	require.ErrorAs(t, errs[0], &psErr)
+	assert.Equal(t, int64(2), psErr.RejectedDataPoints)
+	assert.Equal(t, "partially successful", psErr.Message)
+	// The accepted data points are delivered once and not retried.
+	assert.Len(t, mc.getMetrics(), 1)
+}
+
+func TestUploadMetricsMaxMessageSize(t *testing.T) {
+	mc := runMockCollector(t)
+	defer func() {
+		_ = mc.stop()
+	}()
+
+	<-time.After(5 * time.Millisecond)
+
+	const maxSize = 4096
+	ctx := context.Background()
+	client := otlpmetricgrpc.NewClient(
+		otlpmetricgrpc.WithInsecure(),
+		otlpmetricgrpc.WithEndpoint(mc.endpoint),
+		otlpmetricgrpc.WithMaxMessageSize(maxSize),
+	)
+	require.NoError(t, client.Start(ctx))
+	defer func() {
+		assert.NoError(t, client.Stop(ctx))
+	}()
+
+	const n = 1000
+	dPts := make([]*metricpb.NumberDataPoint, n)
+	for i := range dPts {
+		dPts[i] = &metricpb.NumberDataPoint{
+			Attributes: []*commonpb.KeyValue{{
+				Key: "index",
+				Value: &commonpb.AnyValue{
+					Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(i)},
+				},
+			}},
+			Value: &metricpb.NumberDataPoint_AsInt{AsInt: int64(i)},
+		}
+	}
+	rm := &metricpb.ResourceMetrics{
+		ScopeMetrics: []*metricpb.ScopeMetrics{{
+			Scope: &commonpb.InstrumentationScope{Name: "test"},
+			Metrics: []*metricpb.Metric{{
+				Name: "counter",
+				Data: &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+					DataPoints:             dPts,
+					AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+					IsMonotonic:            true,
+				}},
+			}},
+		}},
+	}
+	require.NoError(t, client.UploadMetrics(ctx, rm))
+
+	sizes := mc.getRequestSizes()
+	assert.Greater(t, len(sizes), 1, "metrics not split across requests")
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, maxSize)
+	}
+
+	var got int
+	for _, m := range mc.getMetrics() {
+		assert.Equal(t, "counter", m.Name)
+		sum := m.GetSum()
+		require.NotNil(t, sum)
+		assert.True(t, sum.IsMonotonic)
+		assert.Equal(t, metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+		got += len(sum.DataPoints)
+	}
+	assert.Equal(t, n, got)
+}
@@ -16,6 +16,8 @@ package otlpmetricgrpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -24,7 +26,14 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 )
 
 func TestThrottleDuration(t *testing.T) {
@@ -123,6 +132,39 @@ func TestRetryable(t *testing.T) {
 	}
 }
 
+func TestClassify(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"OK", status.Error(codes.OK, ""), nil},
+		{"not gRPC", errors.New("other"), nil},
+		{"context deadline", context.DeadlineExceeded, otlpmetric.ErrExportTimeout},
+		{"DeadlineExceeded", status.Error(codes.DeadlineExceeded, ""), otlpmetric.ErrExportTimeout},
+		{"Unavailable", status.Error(codes.Unavailable, ""), otlpmetric.ErrCollectorUnavailable},
+		{"wrapped Unavailable", fmt.Errorf("max retry time elapsed: %w", status.Error(codes.Unavailable, "")), otlpmetric.ErrCollectorUnavailable},
+		{"InvalidArgument", status.Error(codes.InvalidArgument, ""), otlpmetric.ErrCollectorRejected},
+		{"Unknown", status.Error(codes.Unknown, ""), otlpmetric.ErrCollectorRejected},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, classify(tc.err))
+
+			err := wrapError(tc.err)
+			if tc.want == nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.err.Error(), err.Error())
+		})
+	}
+}
+
 func TestUnstartedStop(t *testing.T) {
 	client := NewClient()
 	assert.ErrorIs(t, client.Stop(context.Background()), errAlreadyStopped)
@@ -191,3 +233,89 @@ func TestExportContextLinksStopSignal(t *testing.T) {
 		return false
 	}, 10*time.Second, time.Microsecond)
 }
+
+func TestSplitResourceMetricsLimit(t *testing.T) {
+	attrs := func(i int) []*commonpb.KeyValue {
+		return []*commonpb.KeyValue{{
+			Key: "index",
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(i)},
+			},
+		}}
+	}
+	const n = 300
+	var (
+		numberPts    []*metricpb.NumberDataPoint
+		histogramPts []*metricpb.HistogramDataPoint
+	)
+	for i := 0; i < n; i++ {
+		numberPts = append(numberPts, &metricpb.NumberDataPoint{
+			Attributes: attrs(i),
+			Value:      &metricpb.NumberDataPoint_AsInt{AsInt: int64(i)},
+		})
+		histogramPts = append(histogramPts, &metricpb.HistogramDataPoint{
+			Attributes:     attrs(i),
+			Count:          uint64(i),
+			BucketCounts:   []uint64{1, 2, 3},
+			ExplicitBounds: []float64{1, 10},
+		})
+	}
+	rm := &metricpb.ResourceMetrics{
+		Resource: &resourcepb.Resource{Attributes: attrs(-1)},
+		ScopeMetrics: []*metricpb.ScopeMetrics{{
+			Scope: &commonpb.InstrumentationScope{Name: "test"},
+			Metrics: []*metricpb.Metric{
+				{
+					Name: "sum",
+					Data: &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+						DataPoints:             numberPts,
+						AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+						IsMonotonic:            true,
+					}},
+				},
+				{Name: "empty", Data: &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{}}},
+				{
+					Name: "histogram",
+					Data: &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{
+						DataPoints:             histogramPts,
+						AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					}},
+				},
+			},
+		}, {
+			Scope: &commonpb.InstrumentationScope{Name: "gauge"},
+			Metrics: []*metricpb.Metric{{
+				Name: "gauge",
+				Data: &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{DataPoints: numberPts}},
+			}},
+		}},
+	}
+	wantPts := 3 * n
+
+	for _, limit := range []int{150, 200, 500, 1000, 4096, 1 << 16} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			parts := splitResourceMetrics(rm, limit)
+			if limit < proto.Size(&colmetricpb.ExportMetricsServiceRequest{
+				ResourceMetrics: []*metricpb.ResourceMetrics{rm},
+			}) {
+				assert.Greater(t, len(parts), 1, "metrics not split")
+			}
+
+			var gotPts int
+			for _, part := range parts {
+				size := proto.Size(&colmetricpb.ExportMetricsServiceRequest{
+					ResourceMetrics: []*metricpb.ResourceMetrics{part},
+				})
+				assert.LessOrEqual(t, size, limit)
+				for _, sm := range part.ScopeMetrics {
+					for _, m := range sm.Metrics {
+						gotPts += len(m.GetSum().GetDataPoints()) +
+							len(m.GetGauge().GetDataPoints()) +
+							len(m.GetHistogram().GetDataPoints())
+					}
+				}
+			}
+			assert.Equal(t, wantPts, gotPts)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
 	collectormetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
@@ -43,8 +44,10 @@ func makeMockCollector(t *testing.T, mockConfig *mockConfig) *mockCollector {
 type mockMetricService struct {
 	collectormetricpb.UnimplementedMetricsServiceServer
 
-	requests int
-	errors   []error
+	requests       int
+	errors         []error
+	partialSuccess *collectormetricpb.ExportMetricsPartialSuccess
+	sizes          []int
 
 	headers metadata.MD
 	mu      sync.RWMutex
@@ -64,6 +67,12 @@ func (mms *mockMetricService) getMetrics() []*metricpb.Metric {
 	return mms.storage.GetMetrics()
 }
 
+func (mms *mockMetricService) getRequestSizes() []int {
+	mms.mu.RLock()
+	defer mms.mu.RUnlock()
+	return mms.sizes
+}
+
 func (mms *mockMetricService) Export(ctx context.Context, exp *collectormetricpb.ExportMetricsServiceRequest) (*collectormetricpb.ExportMetricsServiceResponse, error) {
 	if mms.delay > 0 {
 		time.Sleep(mms.delay)
@@ -75,13 +84,17 @@ func (mms *mockMetricService) Export(ctx context.Context, exp *collectormetricpb
 		mms.mu.Unlock()
 	}()
 
-	reply := &collectormetricpb.ExportMetricsServiceResponse{}
+	reply := &collectormetricpb.ExportMetricsServiceResponse{
+		PartialSuccess: mms.partialSuccess,
+	}
 	if mms.requests < len(mms.errors) {
 		idx := mms.requests
 		return reply, mms.errors[idx]
 	}
 
 	mms.headers, _ = metadata.FromIncomingContext(ctx)
+	// Size a clone so the cached sizes of exp do not affect comparisons.
+	mms.sizes = append(mms.sizes, proto.Size(proto.Clone(exp)))
 	mms.storage.AddMetrics(exp)
 	return reply, nil
 }
@@ -131,6 +144,10 @@ func (mc *mockCollector) getHeaders() metadata.MD {
 	return mc.metricSvc.getHeaders()
 }
 
+func (mc *mockCollector) getRequestSizes() []int {
+	return mc.metricSvc.getRequestSizes()
+}
+
 func (mc *mockCollector) getMetrics() []*metricpb.Metric {
 	return mc.metricSvc.getMetrics()
 }
@@ -161,6 +161,24 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// WithMaxMessageSize sets the maximum size, in bytes, of each export request
+// sent to the target endpoint. A batch of metrics that would encode to a
+// larger request is split across multiple requests. Each request repeats the
+// Resource, InstrumentationScope, and Metric description of the data points
+// it contains so it is valid on its own. A single data point that exceeds the
+// size is still sent on its own.
+//
+// This is useful to stay below the maximum message size accepted by the
+// gRPC server of the target endpoint, which is 4MB by default.
+//
+// If unset or not positive, batches of metrics are never split.
+func WithMaxMessageSize(bytes int) Option {
+	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
+		cfg.MaxMessageSize = bytes
+		return cfg
+	})}
+}
+
 // WithTimeout sets the max amount of time a client will attempt to export a
 // batch of spans. This takes precedence over any retry settings defined with
 // WithRetry, once this time limit has been reached the export is abandoned
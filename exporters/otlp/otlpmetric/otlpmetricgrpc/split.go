@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// fieldOverhead is the upper bound of bytes used to encode the tag and
+// length prefix of an embedded message field. Tags of all the fields
+// involved fit in one byte and a length prefix never exceeds five.
+const fieldOverhead = 1 + 5
+
+// aggregationOverhead is the upper bound of bytes the length prefix of the
+// aggregation of a Metric grows by when data points are added to it. The
+// size of a Metric without data points already counts one byte for it.
+const aggregationOverhead = 5 - 1
+
+// splitResourceMetrics partitions the data points of rm into ResourceMetrics
+// that each encode to an ExportMetricsServiceRequest of no more than limit
+// bytes.
+//
+// The Resource, InstrumentationScope, and Metric description of every data
+// point are repeated in each ResourceMetrics the data point is placed in so
+// all of them are valid requests on their own. A single data point that on
+// its own exceeds limit is placed in a ResourceMetrics by itself, there is no
+// way to split it further.
+func splitResourceMetrics(rm *metricpb.ResourceMetrics, limit int) []*metricpb.ResourceMetrics {
+	var (
+		out   []*metricpb.ResourceMetrics
+		cur   *metricpb.ResourceMetrics
+		curSM *metricpb.ScopeMetrics
+		size  int
+	)
+
+	rmSize := fieldOverhead + proto.Size(&metricpb.ResourceMetrics{
+		Resource:  rm.Resource,
+		SchemaUrl: rm.SchemaUrl,
+	})
+	for _, sm := range rm.ScopeMetrics {
+		smSize := fieldOverhead + proto.Size(&metricpb.ScopeMetrics{
+			Scope:     sm.Scope,
+			SchemaUrl: sm.SchemaUrl,
+		})
+		// Each ScopeMetrics needs its own copy in the current group.
+		curSM = nil
+
+		for _, m := range sm.Metrics {
+			mSize := fieldOverhead + aggregationOverhead + proto.Size(metricSlice(m, 0, 0))
+			sizes := dataPointSizes(m)
+
+			// Data points [start, i) are pending to be added to curSM.
+			start := 0
+			for i, dpSize := range sizes {
+				added := dpSize
+				if i == start {
+					added += mSize
+				}
+				if curSM == nil {
+					added += smSize
+				}
+				if cur == nil {
+					added += rmSize
+				}
+
+				if cur != nil && size+added > limit {
+					if i > start {
+						curSM.Metrics = append(curSM.Metrics, metricSlice(m, start, i))
+					}
+					out = append(out, cur)
+					cur, curSM, size = nil, nil, 0
+					start = i
+					added = dpSize + mSize + smSize + rmSize
+				}
+
+				if cur == nil {
+					cur = &metricpb.ResourceMetrics{
+						Resource:  rm.Resource,
+						SchemaUrl: rm.SchemaUrl,
+					}
+				}
+				if curSM == nil {
+					curSM = &metricpb.ScopeMetrics{
+						Scope:     sm.Scope,
+						SchemaUrl: sm.SchemaUrl,
+					}
+					cur.ScopeMetrics = append(cur.ScopeMetrics, curSM)
+				}
+				size += added
+			}
+
+			if len(sizes) == 0 {
+				// Nothing to split, keep the metric as is with the
+				// current group.
+				if cur == nil {
+					cur = &metricpb.ResourceMetrics{
+						Resource:  rm.Resource,
+						SchemaUrl: rm.SchemaUrl,
+					}
+					size += rmSize
+				}
+				if curSM == nil {
+					curSM = &metricpb.ScopeMetrics{
+						Scope:     sm.Scope,
+						SchemaUrl: sm.SchemaUrl,
+					}
+					cur.ScopeMetrics = append(cur.ScopeMetrics, curSM)
+					size += smSize
+				}
+				curSM.Metrics = append(curSM.Metrics, m)
+				size += mSize
+			} else if start < len(sizes) {
+				curSM.Metrics = append(curSM.Metrics, metricSlice(m, start, len(sizes)))
+			}
+		}
+	}
+	if cur != nil {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// dataPointSizes returns the bytes used to encode each data point of m as a
+// field of its aggregation.
+func dataPointSizes(m *metricpb.Metric) []int {
+	var sizes []int
+	add := func(dp proto.Message) {
+		sizes = append(sizes, 1+protowire.SizeBytes(proto.Size(dp)))
+	}
+	switch data := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		for _, dp := range data.Gauge.DataPoints {
+			add(dp)
+		}
+	case *metricpb.Metric_Sum:
+		for _, dp := range data.Sum.DataPoints {
+			add(dp)
+		}
+	case *metricpb.Metric_Histogram:
+		for _, dp := range data.Histogram.DataPoints {
+			add(dp)
+		}
+	case *metricpb.Metric_ExponentialHistogram:
+		for _, dp := range data.ExponentialHistogram.DataPoints {
+			add(dp)
+		}
+	case *metricpb.Metric_Summary:
+		for _, dp := range data.Summary.DataPoints {
+			add(dp)
+		}
+	}
+	return sizes
+}
+
+// metricSlice returns a copy of m only containing the data points in the
+// range [i, j).
+func metricSlice(m *metricpb.Metric, i, j int) *metricpb.Metric {
+	out := &metricpb.Metric{
+		Name:        m.Name,
+		Description: m.Description,
+		Unit:        m.Unit,
+	}
+	switch data := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		out.Data = &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{
+			DataPoints: data.Gauge.DataPoints[i:j],
+		}}
+	case *metricpb.Metric_Sum:
+		out.Data = &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+			DataPoints:             data.Sum.DataPoints[i:j],
+			AggregationTemporality: data.Sum.AggregationTemporality,
+			IsMonotonic:            data.Sum.IsMonotonic,
+		}}
+	case *metricpb.Metric_Histogram:
+		out.Data = &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{
+			DataPoints:             data.Histogram.DataPoints[i:j],
+			AggregationTemporality: data.Histogram.AggregationTemporality,
+		}}
+	case *metricpb.Metric_ExponentialHistogram:
+		out.Data = &metricpb.Metric_ExponentialHistogram{ExponentialHistogram: &metricpb.ExponentialHistogram{
+			DataPoints:             data.ExponentialHistogram.DataPoints[i:j],
+			AggregationTemporality: data.ExponentialHistogram.AggregationTemporality,
+		}}
+	case *metricpb.Metric_Summary:
+		out.Data = &metricpb.Metric_Summary{Summary: &metricpb.Summary{
+			DataPoints: data.Summary.DataPoints[i:j],
+		}}
+	}
+	return out
+}
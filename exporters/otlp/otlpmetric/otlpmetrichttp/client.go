@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,6 +30,8 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
@@ -122,6 +125,11 @@ func (d *client) Stop(ctx context.Context) error {
 }
 
 // UploadMetrics sends a batch of metrics to the collector.
+//
+// The returned error matches the otlpmetric export error it is classified
+// as with errors.Is. If the collector accepts the batch but rejects some of
+// the data points, an *otlpmetric.PartialSuccessError is sent to the global
+// error handler, nil is returned and nothing is retried.
 func (d *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
@@ -139,7 +147,7 @@ func (d *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	err = d.requestFunc(ctx, func(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -155,7 +163,10 @@ func (d *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		var rErr error
 		switch resp.StatusCode {
 		case http.StatusOK:
-			// Success, do not retry.
+			// Success, do not retry. The collector may have rejected part
+			// of the data points, but retrying would resend the accepted
+			// ones.
+			handlePartialSuccess(resp.Body)
 		case http.StatusTooManyRequests,
 			http.StatusServiceUnavailable:
 			// Retry-able failure.
@@ -167,7 +178,10 @@ func (d *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 				return err
 			}
 		default:
-			rErr = fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+			rErr = exporterror.Error{
+				Kind: otlpmetric.ErrCollectorRejected,
+				Err:  fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status),
+			}
 		}
 
 		if err := resp.Body.Close(); err != nil {
@@ -175,6 +189,33 @@ func (d *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 		return rErr
 	})
+	return wrapError(err)
+}
+
+// handlePartialSuccess reads the ExportMetricsServiceResponse in body and
+// sends an *otlpmetric.PartialSuccessError to the global error handler if it
+// reports any rejected data points or a message. The export succeeded
+// regardless, so a body that cannot be read or is not an
+// ExportMetricsServiceResponse, as some collectors respond with, is ignored.
+func handlePartialSuccess(body io.Reader) {
+	raw, err := io.ReadAll(body)
+	if err != nil || len(raw) == 0 {
+		return
+	}
+
+	var resp colmetricpb.ExportMetricsServiceResponse
+	if err := proto.Unmarshal(raw, &resp); err != nil {
+		return
+	}
+
+	ps := resp.GetPartialSuccess()
+	if ps.GetRejectedDataPoints() == 0 && ps.GetErrorMessage() == "" {
+		return
+	}
+	otel.Handle(&otlpmetric.PartialSuccessError{
+		RejectedDataPoints: ps.GetRejectedDataPoints(),
+		Message:            ps.GetErrorMessage(),
+	})
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
@@ -276,6 +317,23 @@ func evaluate(err error) (bool, time.Duration) {
 	return true, time.Duration(rErr.throttle)
 }
 
+// wrapError returns err wrapped so it matches the otlpmetric export error it
+// is classified as. If err is already classified or cannot be classified, it
+// is returned as is.
+func wrapError(err error) error {
+	return exporterror.Wrap(classify(err), err)
+}
+
+// classify returns the otlpmetric export error err is an instance of, or nil
+// if it is none of them.
+func classify(err error) error {
+	if errors.As(err, new(retryableError)) {
+		// The collector asked for the request to be retried later.
+		return otlpmetric.ErrCollectorUnavailable
+	}
+	return exporterror.Classify(err)
+}
+
 func (d *client) getScheme() string {
 	if d.cfg.Insecure {
 		return "http"
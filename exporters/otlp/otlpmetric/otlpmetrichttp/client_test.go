@@ -17,17 +17,23 @@ package otlpmetrichttp_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/sdk/resource"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 )
 
 const (
@@ -162,6 +168,41 @@ func TestTimeout(t *testing.T) {
 	}()
 	err = exporter.Export(ctx, testResource, oneRecord)
 	assert.Equalf(t, true, os.IsTimeout(err), "expected timeout error, got: %v", err)
+	assert.ErrorIs(t, err, otlpmetric.ErrExportTimeout)
+}
+
+func TestExportErrors(t *testing.T) {
+	testcases := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"BadRequest", http.StatusBadRequest, otlpmetric.ErrCollectorRejected},
+		{"ServiceUnavailable", http.StatusServiceUnavailable, otlpmetric.ErrCollectorUnavailable},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := runMockCollector(t, mockCollectorConfig{
+				InjectHTTPStatus: []int{tc.status},
+			})
+			defer mc.MustStop(t)
+			driver := otlpmetrichttp.NewClient(
+				otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+				otlpmetrichttp.WithInsecure(),
+				otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}),
+			)
+			ctx := context.Background()
+			exporter, err := otlpmetric.New(ctx, driver)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(ctx))
+			}()
+			err = exporter.Export(ctx, testResource, oneRecord)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Empty(t, mc.GetMetrics())
+		})
+	}
 }
 
 func TestEmptyData(t *testing.T) {
@@ -184,6 +225,74 @@ func TestEmptyData(t *testing.T) {
 	assert.NotEmpty(t, mc.GetMetrics())
 }
 
+func TestPartialSuccess(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		PartialSuccess: &colmetricpb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: 2,
+			ErrorMessage:       "partially successful",
+		},
+	})
+	defer mc.MustStop(t)
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+		otlpmetrichttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	errs := handleErrors(t)
+	require.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+
+	require.Len(t, *errs, 1)
+	var psErr *otlpmetric.PartialSuccessError
+	require.ErrorAs(t, (*errs)[0], &psErr)
+	assert.Equal(t, int64(2), psErr.RejectedDataPoints)
+	assert.Equal(t, "partially successful", psErr.Message)
+	// The accepted data points are delivered once and not retried.
+	assert.Len(t, mc.GetMetrics(), 1)
+}
+
+func TestUnparseableSuccessBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(strings.TrimPrefix(srv.URL, "http://")),
+		otlpmetrichttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	errs := handleErrors(t)
+	assert.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+	assert.Empty(t, *errs)
+}
+
+// handleErrors collects the errors sent to the global error handler until
+// the end of the test.
+func handleErrors(t *testing.T) *[]error {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+	return &errs
+}
+
 func TestCancelledContext(t *testing.T) {
 	statuses := []int{
 		http.StatusBadRequest,
@@ -233,6 +342,7 @@ func TestDeadlineContext(t *testing.T) {
 	defer cancel()
 	err = exporter.Export(ctx, testResource, oneRecord)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, otlpmetric.ErrExportTimeout)
 	assert.Empty(t, mc.GetMetrics())
 }
 
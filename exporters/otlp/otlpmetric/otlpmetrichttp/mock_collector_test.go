@@ -45,6 +45,7 @@ type mockCollector struct {
 
 	injectHTTPStatus  []int
 	injectContentType string
+	partialSuccess    *collectormetricpb.ExportMetricsPartialSuccess
 	delay             <-chan struct{}
 
 	clientTLSConfig *tls.Config
@@ -86,7 +87,9 @@ func (c *mockCollector) serveMetrics(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	response := collectormetricpb.ExportMetricsServiceResponse{}
+	response := collectormetricpb.ExportMetricsServiceResponse{
+		PartialSuccess: c.partialSuccess,
+	}
 	rawResponse, err := proto.Marshal(&response)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -187,6 +190,7 @@ type mockCollectorConfig struct {
 	Delay             <-chan struct{}
 	WithTLS           bool
 	ExpectedHeaders   map[string]string
+	PartialSuccess    *collectormetricpb.ExportMetricsPartialSuccess
 }
 
 func (c *mockCollectorConfig) fillInDefaults() {
@@ -206,6 +210,7 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		metricsStorage:    otlpmetrictest.NewMetricsStorage(),
 		injectHTTPStatus:  cfg.InjectHTTPStatus,
 		injectContentType: cfg.InjectContentType,
+		partialSuccess:    cfg.PartialSuccess,
 		delay:             cfg.Delay,
 		expectedHeaders:   cfg.ExpectedHeaders,
 	}
@@ -27,6 +27,7 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
+	iattribute "go.opentelemetry.io/otel/internal/attribute"
 )
 
 const (
@@ -52,17 +53,32 @@ type (
 		GRPCCredentials credentials.TransportCredentials
 	}
 
+	// BatchingConfig defines how the HTTP driver coalesces concurrent
+	// exports into a single request.
+	BatchingConfig struct {
+		Delay       time.Duration
+		MaxRequests int
+		MaxBytes    int
+	}
+
 	Config struct {
 		// Signal specific configurations
 		Traces SignalConfig
 
 		RetryConfig retry.Config
 
+		// KeyPrefixer rewrites the attribute keys of exported spans.
+		KeyPrefixer *iattribute.KeyPrefixer
+
+		// HTTP configurations
+		Batching *BatchingConfig
+
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+		MaxMessageSize     int
 	}
 )
 
@@ -268,6 +284,15 @@ func WithRetry(rc retry.Config) GenericOption {
 	})
 }
 
+// WithBatching enables the coalescing of concurrent exports by the HTTP
+// driver.
+func WithBatching(bc BatchingConfig) HTTPOption {
+	return NewHTTPOption(func(cfg Config) Config {
+		cfg.Batching = &bc
+		return cfg
+	})
+}
+
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Traces.TLSCfg = tlsCfg.Clone()
@@ -305,3 +330,10 @@ func WithTimeout(duration time.Duration) GenericOption {
 		return cfg
 	})
 }
+
+func WithAttributeKeyPrefix(prefix string, onlyKeys ...string) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.KeyPrefixer = iattribute.NewKeyPrefixer(prefix, onlyKeys...)
+		return cfg
+	})
+}
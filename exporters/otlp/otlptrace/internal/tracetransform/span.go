@@ -17,9 +17,11 @@ package tracetransform // import "go.opentelemetry.io/otel/exporters/otlp/otlptr
 import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	iattribute "go.opentelemetry.io/otel/internal/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
@@ -186,6 +188,33 @@ func spanEvents(es []tracesdk.Event) []*tracepb.Span_Event {
 	return events
 }
 
+// PrefixKeys rewrites the attribute keys of all spans, span events, and span
+// links in rss using p. The KeyValues of rss are updated in place. Resource
+// and instrumentation scope attributes are not changed.
+func PrefixKeys(p *iattribute.KeyPrefixer, rss []*tracepb.ResourceSpans) {
+	if p == nil {
+		return
+	}
+	prefix := func(kvs []*commonpb.KeyValue) {
+		for _, kv := range kvs {
+			kv.Key = p.Key(kv.Key)
+		}
+	}
+	for _, rs := range rss {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				prefix(s.Attributes)
+				for _, e := range s.Events {
+					prefix(e.Attributes)
+				}
+				for _, l := range s.Links {
+					prefix(l.Attributes)
+				}
+			}
+		}
+	}
+}
+
 // spanKind transforms a SpanKind to an OTLP span kind.
 func spanKind(kind trace.SpanKind) tracepb.Span_SpanKind {
 	switch kind {
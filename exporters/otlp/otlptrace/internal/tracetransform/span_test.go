@@ -15,6 +15,7 @@
 package tracetransform
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -325,3 +326,28 @@ func TestRootSpanData(t *testing.T) {
 func TestSpanDataNilResource(t *testing.T) {
 	assert.NotPanics(t, func() { Spans(tracetest.SpanStubs{{}}.Snapshots()) })
 }
+
+func TestPartialSpanEndTime(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	bsp := tracesdk.NewBatchSpanProcessor(
+		exp,
+		tracesdk.WithPartialSpanExport(time.Millisecond),
+		tracesdk.WithBatchTimeout(time.Millisecond),
+	)
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(bsp))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	_, span := tp.Tracer("TestPartialSpanEndTime").Start(context.Background(), "stream")
+	defer span.End()
+	require.Eventually(t, func() bool {
+		return len(exp.GetSpans()) > 0
+	}, 5*time.Second, time.Millisecond, "partial span not exported")
+
+	got := Spans(exp.GetSpans().Snapshots())
+	require.Len(t, got, 1)
+	require.Len(t, got[0].ScopeSpans, 1)
+	require.Len(t, got[0].ScopeSpans[0].Spans, 1)
+	partial := got[0].ScopeSpans[0].Spans[0]
+	assert.GreaterOrEqual(t, partial.EndTimeUnixNano, partial.StartTimeUnixNano)
+	assert.LessOrEqual(t, partial.EndTimeUnixNano, uint64(time.Now().UnixNano()))
+}
@@ -26,9 +26,13 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracetransform"
+	iattribute "go.opentelemetry.io/otel/internal/attribute"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -40,6 +44,13 @@ type client struct {
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
 
+	// maxMessageSize is the maximum size of an export request. If not
+	// positive, requests are not split.
+	maxMessageSize int
+
+	// keyPrefixer rewrites the attribute keys of exported spans.
+	keyPrefixer *iattribute.KeyPrefixer
+
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
 	stopCtx context.Context
@@ -77,6 +88,9 @@ func newClient(opts ...Option) *client {
 		stopCtx:       ctx,
 		stopFunc:      cancel,
 		conn:          cfg.GRPCConn,
+
+		maxMessageSize: cfg.MaxMessageSize,
+		keyPrefixer:    cfg.KeyPrefixer,
 	}
 
 	if len(cfg.Traces.Headers) > 0 {
@@ -179,7 +193,9 @@ var errShutdown = errors.New("the client is shutdown")
 // UploadTraces sends a batch of spans.
 //
 // Retryable errors from the server will be handled according to any
-// RetryConfig the client was created with.
+// RetryConfig the client was created with. If the server accepts the batch
+// but rejects some of the spans, an *otlptrace.PartialSuccessError is sent to
+// the global error handler, nil is returned and nothing is retried.
 func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	// Hold a read lock to ensure a shut down initiated after this starts does
 	// not abandon the export. This read lock acquire has less priority than a
@@ -195,17 +211,52 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		_, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
+	tracetransform.PrefixKeys(c.keyPrefixer, protoSpans)
+
+	if c.maxMessageSize <= 0 {
+		return c.export(ctx, protoSpans)
+	}
+
+	// Every group is sent, even if an earlier one failed, so a single
+	// rejected request does not drop the spans of all the others.
+	var firstErr error
+	for _, group := range splitResourceSpans(protoSpans, c.maxMessageSize) {
+		if err := c.export(ctx, group); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// export sends protoSpans in a single export request.
+func (c *client) export(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		resp, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
 			ResourceSpans: protoSpans,
 		})
 		// nil is converted to OK.
 		if status.Code(err) == codes.OK {
-			// Success.
+			// Success, but the collector may have rejected part of the
+			// spans. That is not retry-able as it would resend the
+			// accepted spans.
+			handlePartialSuccess(resp.GetPartialSuccess())
 			return nil
 		}
 		return err
 	})
+	return wrapError(err)
+}
+
+// handlePartialSuccess sends an *otlptrace.PartialSuccessError describing ps
+// to the global error handler if ps reports any rejected spans or a message.
+func handlePartialSuccess(ps *coltracepb.ExportTracePartialSuccess) {
+	if ps.GetRejectedSpans() == 0 && ps.GetErrorMessage() == "" {
+		return
+	}
+	otel.Handle(&otlptrace.PartialSuccessError{
+		RejectedSpans: ps.GetRejectedSpans(),
+		Message:       ps.GetErrorMessage(),
+	})
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -246,16 +297,9 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 // retryable returns if err identifies a request that can be retried and a
 // duration to wait for if an explicit throttle time is included in err.
 func retryable(err error) (bool, time.Duration) {
-	//func retryable(err error) (bool, time.Duration) {
-	s := status.Convert(err)
-	switch s.Code() {
-	case codes.Canceled,
-		codes.DeadlineExceeded,
-		codes.ResourceExhausted,
-		codes.Aborted,
-		codes.OutOfRange,
-		codes.Unavailable,
-		codes.DataLoss:
+	switch classify(err) {
+	case otlptrace.ErrExportTimeout, otlptrace.ErrCollectorUnavailable:
+		s, _ := statusOf(err)
 		return true, throttleDelay(s)
 	}
 
@@ -263,6 +307,59 @@ func retryable(err error) (bool, time.Duration) {
 	return false, 0
 }
 
+// classify returns the otlptrace export error err is an instance of, or nil
+// if it is none of them.
+func classify(err error) error {
+	if s, ok := statusOf(err); ok {
+		return exporterror.ClassifyGRPCCode(uint32(s.Code()))
+	}
+	return exporterror.Classify(err)
+}
+
+// statusOf returns the status of the gRPC error wrapped in err, if any.
+func statusOf(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus(), true
+	}
+	return nil, false
+}
+
+// exportError is an export error classified as one of the otlptrace export
+// errors that can still be inspected with the status package.
+type exportError struct {
+	classified exporterror.Error
+}
+
+// wrapError returns err wrapped so it matches the otlptrace export error it
+// is classified as. If err is not classified, it is returned as is.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if kind := classify(err); kind != nil {
+		return exportError{classified: exporterror.Error{Kind: kind, Err: err}}
+	}
+	return err
+}
+
+func (e exportError) Error() string {
+	return e.classified.Error()
+}
+
+func (e exportError) Unwrap() error {
+	return e.classified
+}
+
+// GRPCStatus returns the status of the wrapped error so e can still be
+// inspected with the status package.
+func (e exportError) GRPCStatus() *status.Status {
+	if s, ok := statusOf(e.classified.Err); ok {
+		return s
+	}
+	return status.New(codes.Unknown, e.classified.Err.Error())
+}
+
 // throttleDelay returns a duration to wait for if an explicit throttle time
 // is included in the response status.
 func throttleDelay(s *status.Status) time.Duration {
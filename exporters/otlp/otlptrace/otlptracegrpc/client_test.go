@@ -29,12 +29,17 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 )
 
@@ -236,6 +241,123 @@ func TestExportSpansTimeoutHonored(t *testing.T) {
 	close(exportBlock)
 
 	require.Equal(t, codes.DeadlineExceeded, status.Convert(err).Code())
+	assert.ErrorIs(t, err, otlptrace.ErrExportTimeout)
+}
+
+func TestExportSpansErrors(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "Unavailable",
+			err:  status.Error(codes.Unavailable, "unavailable"),
+			want: otlptrace.ErrCollectorUnavailable,
+		},
+		{
+			name: "ResourceExhausted",
+			err:  status.Error(codes.ResourceExhausted, "resource exhausted"),
+			want: otlptrace.ErrCollectorUnavailable,
+		},
+		{
+			name: "InvalidArgument",
+			err:  status.Error(codes.InvalidArgument, "invalid argument"),
+			want: otlptrace.ErrCollectorRejected,
+		},
+		{
+			name: "Unauthenticated",
+			err:  status.Error(codes.Unauthenticated, "unauthenticated"),
+			want: otlptrace.ErrCollectorRejected,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := runMockCollectorWithConfig(t, &mockConfig{
+				errors: []error{tc.err},
+			})
+			t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+			ctx := context.Background()
+			exp := newGRPCExporter(t, ctx, mc.endpoint,
+				otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}))
+			t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+			err := exp.ExportSpans(ctx, roSpans)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, status.Code(tc.err), status.Code(err))
+			assert.Equal(t, tc.err.Error(), err.Error())
+		})
+	}
+}
+
+func TestPartialSuccess(t *testing.T) {
+	mc := runMockCollector(t)
+	mc.traceSvc.partialSuccess = &coltracepb.ExportTracePartialSuccess{
+		RejectedSpans: 2,
+		ErrorMessage:  "partially successful",
+	}
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	require.Len(t, errs, 1)
+	var psErr *otlptrace.PartialSuccessError
+	require.ErrorAs(t, errs[0], &psErr)
+	assert.Equal(t, int64(2), psErr.RejectedSpans)
+	assert.Equal(t, "partially successful", psErr.Message)
+	// The accepted spans are delivered once and not retried.
+	assert.Len(t, mc.getSpans(), len(roSpans))
+}
+
+func TestAttributeKeyPrefix(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithAttributeKeyPrefix("app."))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	attrs := []attribute.KeyValue{
+		attribute.String("key", "value"),
+		attribute.String("http.method", "GET"),
+	}
+	spans := tracetest.SpanStubs{{
+		Name:       "Span 0",
+		Attributes: attrs,
+		Events:     []sdktrace.Event{{Name: "event", Attributes: attrs}},
+		Links:      []sdktrace.Link{{Attributes: attrs}},
+	}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+
+	got := mc.getSpans()
+	require.Len(t, got, 1)
+	keys := func(kvs []*commonpb.KeyValue) []string {
+		var out []string
+		for _, kv := range kvs {
+			out = append(out, kv.Key)
+		}
+		return out
+	}
+	want := []string{"app.key", "http.method"}
+	assert.Equal(t, want, keys(got[0].Attributes))
+	require.Len(t, got[0].Events, 1)
+	assert.Equal(t, want, keys(got[0].Events[0].Attributes))
+	require.Len(t, got[0].Links, 1)
+	assert.Equal(t, want, keys(got[0].Links[0].Attributes))
+
+	// The exported spans are not modified.
+	assert.Equal(t, attrs, spans[0].Attributes())
 }
 
 func TestNewWithMultipleAttributeTypes(t *testing.T) {
@@ -385,3 +507,43 @@ func TestEmptyData(t *testing.T) {
 
 	assert.NoError(t, exp.ExportSpans(ctx, nil))
 }
+
+func TestExportSpansMaxMessageSize(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	<-time.After(5 * time.Millisecond)
+
+	const maxSize = 4096
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithMaxMessageSize(maxSize))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	const n = 500
+	stubs := make(tracetest.SpanStubs, n)
+	for i := range stubs {
+		stubs[i] = tracetest.SpanStub{
+			Name: fmt.Sprintf("span %d", i),
+			Attributes: []attribute.KeyValue{
+				attribute.Int("index", i),
+				attribute.String("payload", strings.Repeat("x", 32)),
+			},
+			Resource:               resource.NewSchemaless(attribute.String("service.name", "test")),
+			InstrumentationLibrary: instrumentation.Library{Name: "library"},
+		}
+	}
+	require.NoError(t, exp.ExportSpans(ctx, stubs.Snapshots()))
+
+	sizes := mc.getRequestSizes()
+	assert.Greater(t, len(sizes), 1, "spans not split across requests")
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, maxSize)
+	}
+	assert.Len(t, mc.getSpans(), n)
+	for _, rs := range mc.getResourceSpans() {
+		assert.NotNil(t, rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			assert.NotNil(t, ss.Scope)
+		}
+	}
+}
@@ -16,6 +16,8 @@ package otlptracegrpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +27,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 )
 
 func TestThrottleDuration(t *testing.T) {
@@ -123,6 +127,39 @@ func TestRetryable(t *testing.T) {
 	}
 }
 
+func TestClassify(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"OK", status.Error(codes.OK, ""), nil},
+		{"not gRPC", errors.New("other"), nil},
+		{"context deadline", context.DeadlineExceeded, otlptrace.ErrExportTimeout},
+		{"DeadlineExceeded", status.Error(codes.DeadlineExceeded, ""), otlptrace.ErrExportTimeout},
+		{"Unavailable", status.Error(codes.Unavailable, ""), otlptrace.ErrCollectorUnavailable},
+		{"wrapped Unavailable", fmt.Errorf("max retry time elapsed: %w", status.Error(codes.Unavailable, "")), otlptrace.ErrCollectorUnavailable},
+		{"InvalidArgument", status.Error(codes.InvalidArgument, ""), otlptrace.ErrCollectorRejected},
+		{"Unknown", status.Error(codes.Unknown, ""), otlptrace.ErrCollectorRejected},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, classify(tc.err))
+
+			err := wrapError(tc.err)
+			if tc.want == nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.err.Error(), err.Error())
+		})
+	}
+}
+
 func TestUnstartedStop(t *testing.T) {
 	client := NewClient()
 	assert.ErrorIs(t, client.Stop(context.Background()), errAlreadyStopped)
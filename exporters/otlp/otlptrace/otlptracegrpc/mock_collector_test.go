@@ -24,6 +24,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -43,12 +44,14 @@ func makeMockCollector(t *testing.T, mockConfig *mockConfig) *mockCollector {
 type mockTraceService struct {
 	collectortracepb.UnimplementedTraceServiceServer
 
-	errors      []error
-	requests    int
-	mu          sync.RWMutex
-	storage     otlptracetest.SpansStorage
-	headers     metadata.MD
-	exportBlock chan struct{}
+	errors         []error
+	partialSuccess *collectortracepb.ExportTracePartialSuccess
+	requests       int
+	sizes          []int
+	mu             sync.RWMutex
+	storage        otlptracetest.SpansStorage
+	headers        metadata.MD
+	exportBlock    chan struct{}
 }
 
 func (mts *mockTraceService) getHeaders() metadata.MD {
@@ -63,6 +66,12 @@ func (mts *mockTraceService) getSpans() []*tracepb.Span {
 	return mts.storage.GetSpans()
 }
 
+func (mts *mockTraceService) getRequestSizes() []int {
+	mts.mu.RLock()
+	defer mts.mu.RUnlock()
+	return mts.sizes
+}
+
 func (mts *mockTraceService) getResourceSpans() []*tracepb.ResourceSpans {
 	mts.mu.RLock()
 	defer mts.mu.RUnlock()
@@ -82,13 +91,17 @@ func (mts *mockTraceService) Export(ctx context.Context, exp *collectortracepb.E
 		<-mts.exportBlock
 	}
 
-	reply := &collectortracepb.ExportTraceServiceResponse{}
+	reply := &collectortracepb.ExportTraceServiceResponse{
+		PartialSuccess: mts.partialSuccess,
+	}
 	if mts.requests < len(mts.errors) {
 		idx := mts.requests
 		return reply, mts.errors[idx]
 	}
 
 	mts.headers, _ = metadata.FromIncomingContext(ctx)
+	// Size a clone so the cached sizes of exp do not affect comparisons.
+	mts.sizes = append(mts.sizes, proto.Size(proto.Clone(exp)))
 	mts.storage.AddSpans(exp)
 	return reply, nil
 }
@@ -146,6 +159,10 @@ func (mc *mockCollector) GetResourceSpans() []*tracepb.ResourceSpans {
 	return mc.getResourceSpans()
 }
 
+func (mc *mockCollector) getRequestSizes() []int {
+	return mc.traceSvc.getRequestSizes()
+}
+
 func (mc *mockCollector) getHeaders() metadata.MD {
 	return mc.traceSvc.getHeaders()
 }
@@ -161,6 +161,23 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// WithMaxMessageSize sets the maximum size, in bytes, of each export request
+// sent to the target endpoint. A batch of spans that would encode to a
+// larger request is split across multiple requests. Each request repeats the
+// Resource and InstrumentationScope of the spans it contains so it is valid
+// on its own. A single span that exceeds the size is still sent on its own.
+//
+// This is useful to stay below the maximum message size accepted by the
+// gRPC server of the target endpoint, which is 4MB by default.
+//
+// If unset or not positive, batches of spans are never split.
+func WithMaxMessageSize(bytes int) Option {
+	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
+		cfg.MaxMessageSize = bytes
+		return cfg
+	})}
+}
+
 // WithTimeout sets the max amount of time a client will attempt to export a
 // batch of spans. This takes precedence over any retry settings defined with
 // WithRetry, once this time limit has been reached the export is abandoned
@@ -187,3 +204,15 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(settings))}
 }
+
+// WithAttributeKeyPrefix sets the exporter to prepend prefix to the
+// attribute keys of exported spans, their events, and their links. If
+// onlyKeys are passed, only those keys are prefixed. Otherwise, all keys not
+// in a namespace defined by the OpenTelemetry semantic conventions are
+// prefixed. Keys already starting with prefix are not changed.
+//
+// Only the exported representation is changed, the attributes of the spans
+// themselves are left as is.
+func WithAttributeKeyPrefix(prefix string, onlyKeys ...string) Option {
+	return wrappedOption{otlpconfig.WithAttributeKeyPrefix(prefix, onlyKeys...)}
+}
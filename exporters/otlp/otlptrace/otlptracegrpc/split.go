@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// fieldOverhead is the upper bound of bytes used to encode the tag and
+// length prefix of an embedded message field. Tags of all the fields
+// involved fit in one byte and a length prefix never exceeds five.
+const fieldOverhead = 1 + 5
+
+// splitResourceSpans partitions rss into groups that each encode to an
+// ExportTraceServiceRequest of no more than limit bytes.
+//
+// The Resource and InstrumentationScope of every span are repeated in each
+// group the span is placed in so all groups are valid requests on their own.
+// A single span that on its own exceeds limit is placed in a group by
+// itself, there is no way to split it further.
+func splitResourceSpans(rss []*tracepb.ResourceSpans, limit int) [][]*tracepb.ResourceSpans {
+	var (
+		groups [][]*tracepb.ResourceSpans
+		group  []*tracepb.ResourceSpans
+		size   int
+
+		// The last ResourceSpans and ScopeSpans added to group, and the
+		// originals they were copied from.
+		curRS, srcRS *tracepb.ResourceSpans
+		curSS, srcSS *tracepb.ScopeSpans
+	)
+
+	for _, rs := range rss {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				newRS := curRS == nil || srcRS != rs
+				newSS := newRS || srcSS != ss
+				added := groupedSpanSize(rs, ss, span, newRS, newSS)
+
+				if len(group) > 0 && size+added > limit {
+					groups = append(groups, group)
+					group, size = nil, 0
+					newRS, newSS = true, true
+					added = groupedSpanSize(rs, ss, span, newRS, newSS)
+				}
+
+				if newRS {
+					curRS = &tracepb.ResourceSpans{
+						Resource:  rs.Resource,
+						SchemaUrl: rs.SchemaUrl,
+					}
+					srcRS = rs
+					group = append(group, curRS)
+				}
+				if newSS {
+					curSS = &tracepb.ScopeSpans{
+						Scope:     ss.Scope,
+						SchemaUrl: ss.SchemaUrl,
+					}
+					srcSS = ss
+					curRS.ScopeSpans = append(curRS.ScopeSpans, curSS)
+				}
+				curSS.Spans = append(curSS.Spans, span)
+				size += added
+			}
+		}
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// groupedSpanSize returns the upper bound of bytes span adds to a group it
+// is placed in. This includes its parent ResourceSpans and ScopeSpans if
+// they are new to the group.
+func groupedSpanSize(rs *tracepb.ResourceSpans, ss *tracepb.ScopeSpans, span *tracepb.Span, newRS, newSS bool) int {
+	n := 1 + protowire.SizeBytes(proto.Size(span))
+	if newRS {
+		n += emptyResourceSpansSize(rs)
+	}
+	if newSS {
+		n += emptyScopeSpansSize(ss)
+	}
+	return n
+}
+
+// emptyResourceSpansSize returns the upper bound of bytes used to encode rs,
+// without any of its ScopeSpans, as a field of an
+// ExportTraceServiceRequest.
+func emptyResourceSpansSize(rs *tracepb.ResourceSpans) int {
+	return fieldOverhead + proto.Size(&tracepb.ResourceSpans{
+		Resource:  rs.Resource,
+		SchemaUrl: rs.SchemaUrl,
+	})
+}
+
+// emptyScopeSpansSize returns the upper bound of bytes used to encode ss,
+// without any of its Spans, as a field of a ResourceSpans.
+func emptyScopeSpansSize(ss *tracepb.ScopeSpans) int {
+	return fieldOverhead + proto.Size(&tracepb.ScopeSpans{
+		Scope:     ss.Scope,
+		SchemaUrl: ss.SchemaUrl,
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracehttp // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// batcher coalesces the spans of concurrent exports into a single request.
+type batcher struct {
+	cfg  otlpconfig.BatchingConfig
+	send func(context.Context, []*tracepb.ResourceSpans) error
+
+	mu      sync.Mutex
+	pending *pendingRequest
+}
+
+// pendingRequest holds the spans of the exports waiting to be sent together.
+type pendingRequest struct {
+	// deadline is the latest deadline of the contexts of the exports
+	// added to the request. It is zero if one of them has no deadline.
+	deadline   time.Time
+	noDeadline bool
+
+	spans    []*tracepb.ResourceSpans
+	requests int
+	bytes    int
+	timer    *time.Timer
+
+	done chan struct{}
+	err  error
+}
+
+func newBatcher(cfg otlpconfig.BatchingConfig, send func(context.Context, []*tracepb.ResourceSpans) error) *batcher {
+	return &batcher{cfg: cfg, send: send}
+}
+
+// upload adds protoSpans to the pending request and waits for it to be sent.
+func (b *batcher) upload(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	size := 0
+	for _, rs := range protoSpans {
+		size += proto.Size(rs)
+	}
+
+	b.mu.Lock()
+	p := b.pending
+	if p == nil {
+		p = &pendingRequest{done: make(chan struct{})}
+		p.timer = time.AfterFunc(b.cfg.Delay, func() { b.flush(p) })
+		b.pending = p
+	}
+	if d, ok := ctx.Deadline(); !ok {
+		p.noDeadline = true
+		p.deadline = time.Time{}
+	} else if !p.noDeadline && d.After(p.deadline) {
+		p.deadline = d
+	}
+	p.spans = append(p.spans, protoSpans...)
+	p.requests++
+	p.bytes += size
+	full := (b.cfg.MaxRequests > 0 && p.requests >= b.cfg.MaxRequests) ||
+		(b.cfg.MaxBytes > 0 && p.bytes >= b.cfg.MaxBytes)
+	b.mu.Unlock()
+
+	if full {
+		go b.flush(p)
+	}
+
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush sends p if it is still pending. It is not sent with the context of
+// any of the exports waiting for it, so one of them giving up does not fail
+// the others, but it is bounded by their latest deadline.
+func (b *batcher) flush(p *pendingRequest) {
+	if !b.detach(p) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if !p.noDeadline {
+		ctx, cancel = context.WithDeadline(context.Background(), p.deadline)
+	}
+	defer cancel()
+	b.sendPending(ctx, p)
+}
+
+// detach returns true if p is still pending and makes it no longer pending,
+// no more exports are added to it.
+func (b *batcher) detach(p *pendingRequest) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending != p {
+		return false
+	}
+	b.pending = nil
+	return true
+}
+
+// sendPending sends the detached request p with ctx.
+func (b *batcher) sendPending(ctx context.Context, p *pendingRequest) {
+	p.timer.Stop()
+	p.err = b.send(ctx, p.spans)
+	close(p.done)
+}
+
+// shutdown sends the pending request with ctx, if any, and waits for it to
+// complete or for ctx to be done. It returns the error sending the request.
+func (b *batcher) shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	p := b.pending
+	b.mu.Unlock()
+	if p == nil {
+		return nil
+	}
+
+	go func() {
+		if b.detach(p) {
+			b.sendPending(ctx, p)
+		}
+	}()
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
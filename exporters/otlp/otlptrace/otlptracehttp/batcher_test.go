@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracehttp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+type batcherContextKey struct{}
+
+// sentRequest is the context a request is sent with and its error when the
+// request is sent.
+type sentRequest struct {
+	ctx context.Context
+	err error
+}
+
+// sentContexts returns a batcher and the channel it sends the context of
+// each request to.
+func sentContexts(cfg otlpconfig.BatchingConfig) (*batcher, chan sentRequest) {
+	sent := make(chan sentRequest, 1)
+	return newBatcher(cfg, func(ctx context.Context, _ []*tracepb.ResourceSpans) error {
+		sent <- sentRequest{ctx: ctx, err: ctx.Err()}
+		return nil
+	}), sent
+}
+
+// uploadPending uploads with ctx in the background, once the upload is
+// waiting for the pending request, and returns its result.
+func uploadPending(t *testing.T, b *batcher, ctx context.Context) chan error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- b.upload(ctx, nil) }()
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.pending != nil && b.pending.requests == 1
+	}, time.Second, time.Millisecond)
+	return errCh
+}
+
+func TestBatcherCanceledExportDoesNotCancelRequest(t *testing.T) {
+	b, sent := sentContexts(otlpconfig.BatchingConfig{Delay: time.Minute, MaxRequests: 2})
+
+	errCh := uploadPending(t, b, context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, b.upload(ctx, nil), context.Canceled)
+	require.NoError(t, <-errCh)
+	assert.NoError(t, (<-sent).err)
+}
+
+func TestBatcherSendsWithLatestDeadline(t *testing.T) {
+	b, sent := sentContexts(otlpconfig.BatchingConfig{Delay: time.Minute, MaxRequests: 2})
+
+	now := time.Now()
+	later, cancelLater := context.WithDeadline(context.Background(), now.Add(2*time.Hour))
+	defer cancelLater()
+	errCh := uploadPending(t, b, later)
+
+	sooner, cancelSooner := context.WithDeadline(context.Background(), now.Add(time.Hour))
+	defer cancelSooner()
+	require.NoError(t, b.upload(sooner, nil))
+	require.NoError(t, <-errCh)
+
+	req := <-sent
+	assert.NoError(t, req.err)
+	deadline, ok := req.ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(2*time.Hour), deadline)
+}
+
+func TestBatcherSendsWithoutDeadline(t *testing.T) {
+	b, sent := sentContexts(otlpconfig.BatchingConfig{Delay: time.Minute, MaxRequests: 2})
+
+	errCh := uploadPending(t, b, context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	require.NoError(t, b.upload(ctx, nil))
+	require.NoError(t, <-errCh)
+
+	_, ok := (<-sent).ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestBatcherSendsWithShutdownContext(t *testing.T) {
+	b, sent := sentContexts(otlpconfig.BatchingConfig{Delay: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, b.upload(ctx, nil), context.Canceled)
+
+	shutdownCtx := context.WithValue(context.Background(), batcherContextKey{}, "shutdown")
+	require.NoError(t, b.shutdown(shutdownCtx))
+	assert.Equal(t, "shutdown", (<-sent).ctx.Value(batcherContextKey{}))
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,9 +30,12 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/exporterror"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracetransform"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -68,6 +72,7 @@ type client struct {
 	generalCfg  otlpconfig.Config
 	requestFunc retry.RequestFunc
 	client      *http.Client
+	batcher     *batcher
 	stopCh      chan struct{}
 	stopOnce    sync.Once
 }
@@ -89,7 +94,7 @@ func NewClient(opts ...Option) otlptrace.Client {
 	}
 
 	stopCh := make(chan struct{})
-	return &client{
+	c := &client{
 		name:        "traces",
 		cfg:         cfg.Traces,
 		generalCfg:  cfg,
@@ -97,6 +102,10 @@ func NewClient(opts ...Option) otlptrace.Client {
 		stopCh:      stopCh,
 		client:      httpClient,
 	}
+	if cfg.Batching != nil {
+		c.batcher = newBatcher(*cfg.Batching, c.uploadTraces)
+	}
+	return c
 }
 
 // Start does nothing in a HTTP client.
@@ -110,11 +119,19 @@ func (d *client) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop shuts down the client and interrupt any in-flight request.
+// Stop shuts down the client and interrupt any in-flight request. If
+// batching is enabled, the pending request is sent first.
 func (d *client) Stop(ctx context.Context) error {
+	var err error
+	if d.batcher != nil {
+		err = d.batcher.shutdown(ctx)
+	}
 	d.stopOnce.Do(func() {
 		close(d.stopCh)
 	})
+	if err != nil {
+		return err
+	}
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -124,7 +141,21 @@ func (d *client) Stop(ctx context.Context) error {
 }
 
 // UploadTraces sends a batch of spans to the collector.
+//
+// If the collector accepts the batch but rejects some of the spans, an
+// *otlptrace.PartialSuccessError is sent to the global error handler, nil is
+// returned and nothing is retried.
 func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if d.batcher != nil {
+		return d.batcher.upload(ctx, protoSpans)
+	}
+	return d.uploadTraces(ctx, protoSpans)
+}
+
+// uploadTraces sends protoSpans to the collector in a single request.
+func (d *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	tracetransform.PrefixKeys(d.generalCfg.KeyPrefixer, protoSpans)
+
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
@@ -141,7 +172,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	err = d.requestFunc(ctx, func(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -157,7 +188,9 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		var rErr error
 		switch resp.StatusCode {
 		case http.StatusOK:
-			// Success, do not retry.
+			// Success, do not retry. The collector may have rejected part
+			// of the spans, but retrying would resend the accepted ones.
+			handlePartialSuccess(resp.Body)
 		case http.StatusTooManyRequests,
 			http.StatusServiceUnavailable:
 			// Retry-able failure.
@@ -169,7 +202,10 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 				return err
 			}
 		default:
-			rErr = fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+			rErr = exporterror.Error{
+				Kind: otlptrace.ErrCollectorRejected,
+				Err:  fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status),
+			}
 		}
 
 		if err := resp.Body.Close(); err != nil {
@@ -177,6 +213,33 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		}
 		return rErr
 	})
+	return wrapError(err)
+}
+
+// handlePartialSuccess reads the ExportTraceServiceResponse in body and
+// sends an *otlptrace.PartialSuccessError to the global error handler if it
+// reports any rejected spans or a message. The export succeeded regardless,
+// so a body that cannot be read or is not an ExportTraceServiceResponse, as
+// some collectors respond with, is ignored.
+func handlePartialSuccess(body io.Reader) {
+	raw, err := io.ReadAll(body)
+	if err != nil || len(raw) == 0 {
+		return
+	}
+
+	var resp coltracepb.ExportTraceServiceResponse
+	if err := proto.Unmarshal(raw, &resp); err != nil {
+		return
+	}
+
+	ps := resp.GetPartialSuccess()
+	if ps.GetRejectedSpans() == 0 && ps.GetErrorMessage() == "" {
+		return
+	}
+	otel.Handle(&otlptrace.PartialSuccessError{
+		RejectedSpans: ps.GetRejectedSpans(),
+		Message:       ps.GetErrorMessage(),
+	})
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
@@ -291,6 +354,23 @@ func evaluate(err error) (bool, time.Duration) {
 	return true, time.Duration(rErr.throttle)
 }
 
+// wrapError returns err wrapped so it matches the otlptrace export error it
+// is classified as. If err is already classified or cannot be classified, it
+// is returned as is.
+func wrapError(err error) error {
+	return exporterror.Wrap(classify(err), err)
+}
+
+// classify returns the otlptrace export error err is an instance of, or nil
+// if it is none of them.
+func classify(err error) error {
+	if errors.As(err, new(retryableError)) {
+		// The collector asked for the request to be retried later.
+		return otlptrace.ErrCollectorUnavailable
+	}
+	return exporterror.Classify(err)
+}
+
 func (d *client) getScheme() string {
 	if d.cfg.Insecure {
 		return "http"
@@ -18,16 +18,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 )
 
 const (
@@ -205,6 +215,7 @@ func TestTimeout(t *testing.T) {
 	}()
 	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
 	assert.Equalf(t, true, os.IsTimeout(err), "expected timeout error, got: %v", err)
+	assert.ErrorIs(t, err, otlptrace.ErrExportTimeout)
 }
 
 func TestNoRetry(t *testing.T) {
@@ -232,9 +243,276 @@ func TestNoRetry(t *testing.T) {
 	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Equal(t, fmt.Sprintf("failed to send traces to http://%s/v1/traces: 400 Bad Request", mc.endpoint), err.Error())
+	assert.ErrorIs(t, err, otlptrace.ErrCollectorRejected)
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestPartialSuccess(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		PartialSuccess: &coltracepb.ExportTracePartialSuccess{
+			RejectedSpans: 2,
+			ErrorMessage:  "partially successful",
+		},
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	errs := handleErrors(t)
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+
+	require.Len(t, *errs, 1)
+	var psErr *otlptrace.PartialSuccessError
+	require.ErrorAs(t, (*errs)[0], &psErr)
+	assert.Equal(t, int64(2), psErr.RejectedSpans)
+	assert.Equal(t, "partially successful", psErr.Message)
+	// The accepted spans are delivered once and not retried.
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestUnparseableSuccessBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(strings.TrimPrefix(srv.URL, "http://")),
+		otlptracehttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	errs := handleErrors(t)
+	assert.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Empty(t, *errs)
+}
+
+// handleErrors collects the errors sent to the global error handler until
+// the end of the test.
+func handleErrors(t *testing.T) *[]error {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+	return &errs
+}
+
+func TestAttributeKeyPrefix(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithAttributeKeyPrefix("app.", "key"),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("key", "value"),
+		attribute.String("other", "value"),
+	}
+	spans := tracetest.SpanStubs{{
+		Name:       "Span 0",
+		Attributes: attrs,
+		Events:     []sdktrace.Event{{Name: "event", Attributes: attrs}},
+		Links:      []sdktrace.Link{{Attributes: attrs}},
+	}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+
+	got := mc.GetSpans()
+	require.Len(t, got, 1)
+	keys := func(kvs []*commonpb.KeyValue) []string {
+		var out []string
+		for _, kv := range kvs {
+			out = append(out, kv.Key)
+		}
+		return out
+	}
+	want := []string{"app.key", "other"}
+	assert.Equal(t, want, keys(got[0].Attributes))
+	require.Len(t, got[0].Events, 1)
+	assert.Equal(t, want, keys(got[0].Events[0].Attributes))
+	require.Len(t, got[0].Links, 1)
+	assert.Equal(t, want, keys(got[0].Links[0].Attributes))
+
+	// The exported spans are not modified.
+	assert.Equal(t, attrs, spans[0].Attributes())
+}
+
+func TestBatching(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithBatching(otlptracehttp.BatchingConfig{
+			Delay:       time.Minute,
+			MaxRequests: 3,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			spans := tracetest.SpanStubs{{Name: fmt.Sprintf("Span %d", i)}}.Snapshots()
+			assert.NoError(t, exporter.ExportSpans(ctx, spans))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, mc.GetRequestCount())
+	assert.Len(t, mc.GetSpans(), 3)
+}
+
+func TestBatchingDelay(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithBatching(otlptracehttp.BatchingConfig{
+			Delay: time.Millisecond,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	spans := tracetest.SpanStubs{{Name: "Span 0"}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+	assert.Equal(t, 1, mc.GetRequestCount())
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestBatchingFlushOnShutdown(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithBatching(otlptracehttp.BatchingConfig{
+			Delay: time.Minute,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+
+	// The export gives up waiting, but its spans stay in the pending
+	// request.
+	exportCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	spans := tracetest.SpanStubs{{Name: "Span 0"}}.Snapshots()
+	assert.ErrorIs(t, exporter.ExportSpans(exportCtx, spans), context.DeadlineExceeded)
+	assert.Equal(t, 0, mc.GetRequestCount())
+
+	require.NoError(t, exporter.Shutdown(ctx))
+	assert.Equal(t, 1, mc.GetRequestCount())
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestBatchingFlushOnShutdownError(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusBadRequest},
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		otlptracehttp.WithBatching(otlptracehttp.BatchingConfig{
+			Delay: time.Minute,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+
+	exportCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	spans := tracetest.SpanStubs{{Name: "Span 0"}}.Snapshots()
+	assert.ErrorIs(t, exporter.ExportSpans(exportCtx, spans), context.DeadlineExceeded)
+
+	// The error sending the pending request is returned by Shutdown.
+	assert.ErrorIs(t, exporter.Shutdown(ctx), otlptrace.ErrCollectorRejected)
+	assert.Empty(t, mc.GetSpans())
+}
+
+func TestCollectorUnavailable(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorIs(t, err, otlptrace.ErrCollectorUnavailable)
+	assert.Empty(t, mc.GetSpans())
+}
+
+func TestCollectorUnreachable(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	endpoint := mc.Endpoint()
+	mc.MustStop(t)
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorIs(t, err, otlptrace.ErrCollectorUnavailable)
+}
+
 func TestEmptyData(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -306,6 +584,7 @@ func TestDeadlineContext(t *testing.T) {
 	defer cancel()
 	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, otlptrace.ErrExportTimeout)
 	assert.Empty(t, mc.GetSpans())
 }
 
@@ -42,10 +42,12 @@ type mockCollector struct {
 
 	spanLock     sync.Mutex
 	spansStorage otlptracetest.SpansStorage
+	requests     int
 
 	injectHTTPStatus     []int
 	injectResponseHeader []map[string]string
 	injectContentType    string
+	partialSuccess       *collectortracepb.ExportTracePartialSuccess
 	delay                <-chan struct{}
 
 	clientTLSConfig *tls.Config
@@ -72,6 +74,12 @@ func (c *mockCollector) GetResourceSpans() []*tracepb.ResourceSpans {
 	return c.spansStorage.GetResourceSpans()
 }
 
+func (c *mockCollector) GetRequestCount() int {
+	c.spanLock.Lock()
+	defer c.spanLock.Unlock()
+	return c.requests
+}
+
 func (c *mockCollector) Endpoint() string {
 	return c.endpoint
 }
@@ -93,7 +101,9 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	response := collectortracepb.ExportTraceServiceResponse{}
+	response := collectortracepb.ExportTraceServiceResponse{
+		PartialSuccess: c.partialSuccess,
+	}
 	rawResponse, err := proto.Marshal(&response)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -118,6 +128,7 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	writeReply(w, rawResponse, 0, c.injectContentType, h)
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
+	c.requests++
 	c.spansStorage.AddSpans(request)
 }
 
@@ -210,6 +221,7 @@ type mockCollectorConfig struct {
 	Delay                <-chan struct{}
 	WithTLS              bool
 	ExpectedHeaders      map[string]string
+	PartialSuccess       *collectortracepb.ExportTracePartialSuccess
 }
 
 func (c *mockCollectorConfig) fillInDefaults() {
@@ -230,6 +242,7 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		injectHTTPStatus:     cfg.InjectHTTPStatus,
 		injectResponseHeader: cfg.InjectResponseHeader,
 		injectContentType:    cfg.InjectContentType,
+		partialSuccess:       cfg.PartialSuccess,
 		delay:                cfg.Delay,
 		expectedHeaders:      cfg.ExpectedHeaders,
 	}
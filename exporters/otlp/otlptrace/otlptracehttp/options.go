@@ -52,6 +52,24 @@ func asHTTPOptions(opts []Option) []otlpconfig.HTTPOption {
 // failure using an exponential backoff.
 type RetryConfig retry.Config
 
+// BatchingConfig defines how concurrent exports are coalesced into a single
+// request to the collector.
+type BatchingConfig struct {
+	// Delay is the longest time an export waits for others to join its
+	// request. If zero or negative, DefaultBatchingDelay is used.
+	Delay time.Duration
+	// MaxRequests is the number of exports after which the request is sent
+	// without waiting for Delay. If zero or negative, there is no limit.
+	MaxRequests int
+	// MaxBytes is the size of the encoded spans after which the request is
+	// sent without waiting for Delay. If zero or negative, there is no
+	// limit.
+	MaxBytes int
+}
+
+// DefaultBatchingDelay is the Delay used by WithBatching if none is set.
+const DefaultBatchingDelay = 100 * time.Millisecond
+
 type wrappedOption struct {
 	otlpconfig.HTTPOption
 }
@@ -114,3 +132,31 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(rc))}
 }
+
+// WithBatching tells the driver to coalesce the spans of concurrent exports
+// into a single request. Each export blocks until the request containing its
+// spans has been sent, or until its context is done. Batching only helps when
+// several exports are in flight at once, for example when the client is
+// shared by multiple span processors or MaxConcurrentExports is set,
+// otherwise it only delays each export by bc.Delay. The request is not
+// canceled when an export waiting for it gives up, its deadline is the latest
+// deadline of those exports. It is sent with the context of Shutdown if it is
+// still pending then.
+func WithBatching(bc BatchingConfig) Option {
+	if bc.Delay <= 0 {
+		bc.Delay = DefaultBatchingDelay
+	}
+	return wrappedOption{otlpconfig.WithBatching(otlpconfig.BatchingConfig(bc))}
+}
+
+// WithAttributeKeyPrefix sets the exporter to prepend prefix to the
+// attribute keys of exported spans, their events, and their links. If
+// onlyKeys are passed, only those keys are prefixed. Otherwise, all keys not
+// in a namespace defined by the OpenTelemetry semantic conventions are
+// prefixed. Keys already starting with prefix are not changed.
+//
+// Only the exported representation is changed, the attributes of the spans
+// themselves are left as is.
+func WithAttributeKeyPrefix(prefix string, onlyKeys ...string) Option {
+	return wrappedOption{otlpconfig.WithAttributeKeyPrefix(prefix, onlyKeys...)}
+}
@@ -301,7 +301,17 @@ func (c *collector) toDesc(record export.Record, attrKeys []string) *prometheus.
 // duplicate keys.  This outputs one or both of the keys and the values as a
 // slice, and either argument may be nil to avoid allocating an unnecessary
 // slice.
+//
+// The Resource associated with the record, if any, is merged into res, so
+// records of different Resources are exported as different series. Prometheus
+// requires the series of a metric to have the same label names, the Resources
+// associated with the records of an instrument need to have the same keys.
 func mergeAttrs(record export.Record, res *resource.Resource, keys, values *[]string) {
+	if r := record.Resource(); r != nil {
+		// The schema URL is not exported, the attributes are merged without
+		// checking it.
+		res = resource.NewSchemaless(append(res.Attributes(), r.Attributes()...)...)
+	}
 	if keys != nil {
 		*keys = make([]string, 0, record.Attributes().Len()+res.Len())
 	}
@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric/instrument"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
@@ -179,6 +180,36 @@ func TestPrometheusExporter(t *testing.T) {
 	compareExport(t, exporter, expected)
 }
 
+func TestPrometheusRecordResource(t *testing.T) {
+	exporter, err := newPipeline(
+		prometheus.Config{},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.NewSchemaless(attribute.String("R", "V"))),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	gaugeObserver, err := meter.AsyncInt64().Gauge("tenantgauge")
+	require.NoError(t, err)
+
+	err = meter.RegisterCallback([]instrument.Asynchronous{gaugeObserver}, func(ctx context.Context) {
+		for i, tenant := range []string{"a", "b"} {
+			tenantCtx := sdkmetric.ContextWithResource(ctx, resource.NewSchemaless(attribute.String("tenant", tenant)))
+			gaugeObserver.Observe(tenantCtx, int64(i+1))
+		}
+	})
+	require.NoError(t, err)
+
+	compareExport(t, exporter, []expectedMetric{{
+		kind: "gauge",
+		name: "tenantgauge",
+		values: []string{
+			`tenantgauge{R="V",tenant="a"} 1`,
+			`tenantgauge{R="V",tenant="b"} 2`,
+		},
+	}})
+}
+
 func compareExport(t *testing.T, exporter *prometheus.Exporter, expected []expectedMetric) {
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/metrics", nil)
@@ -70,7 +70,14 @@ func (e *metricExporter) Export(_ context.Context, res *resource.Resource, reade
 			desc := record.Descriptor()
 			agg := record.Aggregation()
 			kind := desc.NumberKind()
-			encodedResource := res.Encoded(e.config.Encoder)
+			recordRes := res
+			if r := record.Resource(); r != nil {
+				var err error
+				if recordRes, err = resource.Merge(res, r); err != nil {
+					return err
+				}
+			}
+			encodedResource := recordRes.Encoded(e.config.Encoder)
 
 			var expose line
 
@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
@@ -258,3 +260,19 @@ func TestStdoutResource(t *testing.T) {
 		})
 	}
 }
+
+func TestStdoutObserverResource(t *testing.T) {
+	fix := newFixture(t)
+
+	counter, err := fix.meter.AsyncInt64().Counter("name.sum")
+	require.NoError(t, err)
+	err = fix.meter.RegisterCallback([]instrument.Asynchronous{counter}, func(ctx context.Context) {
+		res := resource.NewSchemaless(attribute.String("R", "W"), attribute.String("T", "a"))
+		counter.Observe(sdkmetric.ContextWithResource(ctx, res), 1)
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, fix.cont.Stop(fix.ctx))
+
+	require.Equal(t, `[{"Name":"name.sum{R=W,T=a,instrumentation.name=test}","Sum":1}]`, fix.Output())
+}
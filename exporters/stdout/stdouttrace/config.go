@@ -17,6 +17,8 @@ package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdoutt
 import (
 	"io"
 	"os"
+
+	iattribute "go.opentelemetry.io/otel/internal/attribute"
 )
 
 var (
@@ -37,6 +39,10 @@ type config struct {
 	// Timestamps specifies if timestamps should be printed. Default is
 	// true.
 	Timestamps bool
+
+	// KeyPrefixer rewrites the attribute keys of exported spans. If not
+	// set, keys are exported as is.
+	KeyPrefixer *iattribute.KeyPrefixer
 }
 
 // newConfig creates a validated Config configured with options.
@@ -94,3 +100,24 @@ func (o timestampsOption) apply(cfg config) config {
 	cfg.Timestamps = bool(o)
 	return cfg
 }
+
+// WithAttributeKeyPrefix sets the exporter to prepend prefix to the
+// attribute keys of exported spans, their events, and their links. If
+// onlyKeys are passed, only those keys are prefixed. Otherwise, all keys not
+// in a namespace defined by the OpenTelemetry semantic conventions are
+// prefixed. Keys already starting with prefix are not changed.
+//
+// Only the exported representation is changed, the attributes of the spans
+// themselves are left as is.
+func WithAttributeKeyPrefix(prefix string, onlyKeys ...string) Option {
+	return keyPrefixOption{iattribute.NewKeyPrefixer(prefix, onlyKeys...)}
+}
+
+type keyPrefixOption struct {
+	P *iattribute.KeyPrefixer
+}
+
+func (o keyPrefixOption) apply(cfg config) config {
+	cfg.KeyPrefixer = o.P
+	return cfg
+}
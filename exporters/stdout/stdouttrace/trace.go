@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	iattribute "go.opentelemetry.io/otel/internal/attribute"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
@@ -41,16 +42,18 @@ func New(options ...Option) (*Exporter, error) {
 	}
 
 	return &Exporter{
-		encoder:    enc,
-		timestamps: cfg.Timestamps,
+		encoder:     enc,
+		timestamps:  cfg.Timestamps,
+		keyPrefixer: cfg.KeyPrefixer,
 	}, nil
 }
 
 // Exporter is an implementation of trace.SpanSyncer that writes spans to stdout.
 type Exporter struct {
-	encoder    *json.Encoder
-	encoderMu  sync.Mutex
-	timestamps bool
+	encoder     *json.Encoder
+	encoderMu   sync.Mutex
+	timestamps  bool
+	keyPrefixer *iattribute.KeyPrefixer
 
 	stoppedMu sync.RWMutex
 	stopped   bool
@@ -75,6 +78,12 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 	defer e.encoderMu.Unlock()
 	for i := range stubs {
 		stub := &stubs[i]
+		// The events and links of a stub are shared with the span, copy
+		// them before they are modified.
+		if !e.timestamps || e.keyPrefixer != nil {
+			stub.Events = append([]trace.Event(nil), stub.Events...)
+			stub.Links = append([]trace.Link(nil), stub.Links...)
+		}
 		// Remove timestamps
 		if !e.timestamps {
 			stub.StartTime = zeroTime
@@ -84,6 +93,18 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 				ev.Time = zeroTime
 			}
 		}
+		// Rewrite attribute keys
+		if e.keyPrefixer != nil {
+			stub.Attributes = e.keyPrefixer.Attributes(stub.Attributes)
+			for j := range stub.Events {
+				ev := &stub.Events[j]
+				ev.Attributes = e.keyPrefixer.Attributes(ev.Attributes)
+			}
+			for j := range stub.Links {
+				l := &stub.Links[j]
+				l.Attributes = e.keyPrefixer.Attributes(l.Attributes)
+			}
+		}
 
 		// Encode span stubs, one by one
 		if err := e.encoder.Encode(stub); err != nil {
@@ -233,3 +233,67 @@ func TestExporterShutdownNoError(t *testing.T) {
 		t.Errorf("shutdown errored: expected nil, got %v", err)
 	}
 }
+
+func TestExporterAttributeKeyPrefix(t *testing.T) {
+	ss := tracetest.SpanStub{
+		Name: "/foo",
+		Attributes: []attribute.KeyValue{
+			attribute.String("key", "value"),
+			attribute.String("http.method", "GET"),
+		},
+		Events: []tracesdk.Event{
+			{Name: "foo", Attributes: []attribute.KeyValue{attribute.String("key", "value")}},
+		},
+		Links: []tracesdk.Link{
+			{Attributes: []attribute.KeyValue{attribute.String("key", "value")}},
+		},
+	}
+	spans := tracetest.SpanStubs{ss}.Snapshots()
+
+	var b bytes.Buffer
+	ex, err := stdouttrace.New(stdouttrace.WithWriter(&b), stdouttrace.WithAttributeKeyPrefix("app."))
+	require.NoError(t, err)
+	require.NoError(t, ex.ExportSpans(context.Background(), spans))
+
+	var got struct {
+		Attributes []struct{ Key string }
+		Events     []struct{ Attributes []struct{ Key string } }
+		Links      []struct{ Attributes []struct{ Key string } }
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &got))
+	require.Len(t, got.Attributes, 2)
+	assert.Equal(t, "app.key", got.Attributes[0].Key)
+	assert.Equal(t, "http.method", got.Attributes[1].Key)
+	require.Len(t, got.Events, 1)
+	require.Len(t, got.Events[0].Attributes, 1)
+	assert.Equal(t, "app.key", got.Events[0].Attributes[0].Key)
+	require.Len(t, got.Links, 1)
+	require.Len(t, got.Links[0].Attributes, 1)
+	assert.Equal(t, "app.key", got.Links[0].Attributes[0].Key)
+
+	// The exported spans themselves are not modified.
+	assert.Equal(t, ss.Attributes, spans[0].Attributes())
+	assert.Equal(t, ss.Events[0].Attributes, spans[0].Events()[0].Attributes)
+	assert.Equal(t, ss.Links[0].Attributes, spans[0].Links()[0].Attributes)
+}
+
+func TestExporterAttributeKeyPrefixOnlyKeys(t *testing.T) {
+	ss := tracetest.SpanStub{
+		Name: "/foo",
+		Attributes: []attribute.KeyValue{
+			attribute.String("key", "value"),
+			attribute.String("other", "value"),
+		},
+	}
+
+	var b bytes.Buffer
+	ex, err := stdouttrace.New(stdouttrace.WithWriter(&b), stdouttrace.WithAttributeKeyPrefix("app.", "other"))
+	require.NoError(t, err)
+	require.NoError(t, ex.ExportSpans(context.Background(), tracetest.SpanStubs{ss}.Snapshots()))
+
+	var got struct{ Attributes []struct{ Key string } }
+	require.NoError(t, json.Unmarshal(b.Bytes(), &got))
+	require.Len(t, got.Attributes, 2)
+	assert.Equal(t, "key", got.Attributes[0].Key)
+	assert.Equal(t, "app.other", got.Attributes[1].Key)
+}
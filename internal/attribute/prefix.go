@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package attribute provides functionality shared by exporters to rewrite the
+attributes they export. This package exists so the stdout and OTLP exporters
+rewrite attribute keys the same way without exposing it as part of the
+`go.opentelemetry.io/otel/attribute` API.
+*/
+package attribute // import "go.opentelemetry.io/otel/internal/attribute"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// semconvNamespaces are the namespaces of the attribute keys defined by the
+// OpenTelemetry semantic conventions.
+var semconvNamespaces = map[string]struct{}{
+	"aws":         {},
+	"browser":     {},
+	"cloud":       {},
+	"cloudevents": {},
+	"code":        {},
+	"container":   {},
+	"db":          {},
+	"deployment":  {},
+	"device":      {},
+	"enduser":     {},
+	"exception":   {},
+	"faas":        {},
+	"gcp":         {},
+	"host":        {},
+	"http":        {},
+	"k8s":         {},
+	"message":     {},
+	"messaging":   {},
+	"net":         {},
+	"opentracing": {},
+	"os":          {},
+	"otel":        {},
+	"peer":        {},
+	"process":     {},
+	"rpc":         {},
+	"service":     {},
+	"telemetry":   {},
+	"thread":      {},
+	"webengine":   {},
+}
+
+// KeyPrefixer prepends a prefix to attribute keys.
+//
+// The zero value, or a nil *KeyPrefixer, does not change any key.
+type KeyPrefixer struct {
+	prefix string
+	only   map[string]struct{}
+}
+
+// NewKeyPrefixer returns a KeyPrefixer that prepends prefix to onlyKeys. If
+// no onlyKeys are passed, prefix is prepended to all keys that are not in a
+// namespace defined by the OpenTelemetry semantic conventions. Keys that
+// already start with prefix are never changed.
+func NewKeyPrefixer(prefix string, onlyKeys ...string) *KeyPrefixer {
+	p := &KeyPrefixer{prefix: prefix}
+	if len(onlyKeys) > 0 {
+		p.only = make(map[string]struct{}, len(onlyKeys))
+		for _, k := range onlyKeys {
+			p.only[k] = struct{}{}
+		}
+	}
+	return p
+}
+
+// Key returns key with the prefix of p prepended if it applies to key.
+func (p *KeyPrefixer) Key(key string) string {
+	if p == nil || p.prefix == "" || strings.HasPrefix(key, p.prefix) {
+		return key
+	}
+	if p.only != nil {
+		if _, ok := p.only[key]; !ok {
+			return key
+		}
+	} else if isSemconv(key) {
+		return key
+	}
+	return p.prefix + key
+}
+
+// Attributes returns attrs with the keys rewritten by p. The passed attrs are
+// never modified, a new slice is returned if any key is changed.
+func (p *KeyPrefixer) Attributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	var out []attribute.KeyValue
+	for i, kv := range attrs {
+		k := attribute.Key(p.Key(string(kv.Key)))
+		if k == kv.Key {
+			if out != nil {
+				out[i] = kv
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]attribute.KeyValue, len(attrs))
+			copy(out, attrs[:i])
+		}
+		out[i] = attribute.KeyValue{Key: k, Value: kv.Value}
+	}
+	if out == nil {
+		return attrs
+	}
+	return out
+}
+
+// isSemconv returns if key is in a namespace defined by the OpenTelemetry
+// semantic conventions.
+func isSemconv(key string) bool {
+	i := strings.IndexByte(key, '.')
+	if i < 0 {
+		return false
+	}
+	_, ok := semconvNamespaces[key[:i]]
+	return ok
+}
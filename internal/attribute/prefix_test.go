@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestKeyPrefixerKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefixer *KeyPrefixer
+		key      string
+		want     string
+	}{
+		{"nil", nil, "key", "key"},
+		{"empty prefix", NewKeyPrefixer(""), "key", "key"},
+		{"custom key", NewKeyPrefixer("custom."), "key", "custom.key"},
+		{"custom namespace", NewKeyPrefixer("custom."), "app.key", "custom.app.key"},
+		{"semconv key", NewKeyPrefixer("custom."), "http.method", "http.method"},
+		{"semconv namespace only", NewKeyPrefixer("custom."), "http", "custom.http"},
+		{"already prefixed", NewKeyPrefixer("custom."), "custom.key", "custom.key"},
+		{"only keys match", NewKeyPrefixer("custom.", "key", "http.method"), "http.method", "custom.http.method"},
+		{"only keys no match", NewKeyPrefixer("custom.", "other"), "key", "key"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.prefixer.Key(tc.key))
+		})
+	}
+}
+
+func TestKeyPrefixerAttributes(t *testing.T) {
+	p := NewKeyPrefixer("custom.")
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.Int("key", 1),
+		attribute.Bool("other", true),
+	}
+	orig := make([]attribute.KeyValue, len(attrs))
+	copy(orig, attrs)
+
+	got := p.Attributes(attrs)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.Int("custom.key", 1),
+		attribute.Bool("custom.other", true),
+	}, got)
+	assert.Equal(t, orig, attrs, "passed attributes modified")
+
+	semconv := []attribute.KeyValue{attribute.String("http.method", "GET")}
+	assert.Equal(t, semconv, p.Attributes(semconv))
+	assert.Nil(t, p.Attributes(nil))
+}
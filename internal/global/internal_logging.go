@@ -18,6 +18,7 @@ import (
 	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
@@ -30,8 +31,40 @@ import (
 var globalLogger logr.Logger = stdr.New(log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile))
 var globalLoggerLock = &sync.RWMutex{}
 
+// Level is the minimum severity of the messages logged.
+type Level int32
+
+const (
+	// ErrorLevel only logs errors.
+	ErrorLevel Level = iota
+	// WarnLevel logs warnings and errors.
+	WarnLevel
+	// InfoLevel logs informational messages, warnings, and errors.
+	InfoLevel
+	// DebugLevel logs all messages.
+	DebugLevel
+)
+
+// logLevel is the minimum severity of the messages logged, it is accessed
+// atomically so it can be changed while messages are logged.
+var logLevel = int32(DebugLevel)
+
+// SetLogLevel sets the minimum severity of the messages logged to l.
+//
+// Messages at or above l are still only logged if the logger is enabled at
+// their verbosity, messages below l are never passed to the logger. The
+// default level is DebugLevel.
+func SetLogLevel(l Level) {
+	atomic.StoreInt32(&logLevel, int32(l))
+}
+
+func enabled(l Level) bool {
+	return Level(atomic.LoadInt32(&logLevel)) >= l
+}
+
 // SetLogger overrides the globalLogger with l.
 //
+// Warn messages are logged at the default verbosity.
 // To see Info messages use a logger with `l.V(1).Enabled() == true`
 // To see Debug messages use a logger with `l.V(5).Enabled() == true`.
 func SetLogger(l logr.Logger) {
@@ -43,11 +76,25 @@ func SetLogger(l logr.Logger) {
 // Info prints messages about the general state of the API or SDK.
 // This should usually be less then 5 messages a minute.
 func Info(msg string, keysAndValues ...interface{}) {
+	if !enabled(InfoLevel) {
+		return
+	}
 	globalLoggerLock.RLock()
 	defer globalLoggerLock.RUnlock()
 	globalLogger.V(1).Info(msg, keysAndValues...)
 }
 
+// Warn prints messages about non-fatal issues of the API or SDK, like
+// dropped telemetry.
+func Warn(msg string, keysAndValues ...interface{}) {
+	if !enabled(WarnLevel) {
+		return
+	}
+	globalLoggerLock.RLock()
+	defer globalLoggerLock.RUnlock()
+	globalLogger.Info(msg, keysAndValues...)
+}
+
 // Error prints messages about exceptional states of the API or SDK.
 func Error(err error, msg string, keysAndValues ...interface{}) {
 	globalLoggerLock.RLock()
@@ -57,6 +104,9 @@ func Error(err error, msg string, keysAndValues ...interface{}) {
 
 // Debug prints messages about all internal changes in the API or SDK.
 func Debug(msg string, keysAndValues ...interface{}) {
+	if !enabled(DebugLevel) {
+		return
+	}
 	globalLoggerLock.RLock()
 	defer globalLoggerLock.RUnlock()
 	globalLogger.V(5).Info(msg, keysAndValues...)
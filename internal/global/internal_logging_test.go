@@ -15,14 +15,59 @@
 package global
 
 import (
+	"errors"
 	"log"
 	"os"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/go-logr/stdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRace(t *testing.T) {
 	go SetLogger(stdr.New(log.New(os.Stderr, "", 0)))
 	go Info("")
 }
+
+func TestSetLogLevel(t *testing.T) {
+	defer func(l logr.Logger) { SetLogger(l) }(globalLogger)
+	defer SetLogLevel(DebugLevel)
+
+	var got []string
+	SetLogger(funcr.New(func(prefix, args string) {
+		got = append(got, args)
+	}, funcr.Options{Verbosity: 10}))
+
+	log := func() {
+		Debug("debug")
+		Info("info")
+		Warn("warn")
+		Error(errors.New("err"), "error")
+	}
+
+	tests := []struct {
+		level Level
+		want  int
+	}{
+		{DebugLevel, 4},
+		{InfoLevel, 3},
+		{WarnLevel, 2},
+		{ErrorLevel, 1},
+	}
+	for _, test := range tests {
+		got = nil
+		SetLogLevel(test.level)
+		log()
+		assert.Len(t, got, test.want, "level %d", test.level)
+	}
+
+	SetLogLevel(WarnLevel)
+	got = nil
+	log()
+	require.Len(t, got, 2)
+	assert.Contains(t, got[0], `"msg"="warn"`)
+	assert.Contains(t, got[1], `"msg"="error"`)
+}
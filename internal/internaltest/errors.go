@@ -14,6 +14,8 @@
 
 package internaltest // import "go.opentelemetry.io/otel/internal/internaltest"
 
+import "log"
+
 type TestError string
 
 var _ error = TestError("")
@@ -25,3 +27,13 @@ func NewTestError(s string) error {
 func (e TestError) Error() string {
 	return string(e)
 }
+
+// ErrorLogger is an otel.ErrorHandler that logs errors like the default global
+// one. otel.GetErrorHandler returns the global handler itself, not the one it
+// delegates to, so tests that replace it set an ErrorLogger back instead.
+type ErrorLogger struct{}
+
+// Handle logs err.
+func (ErrorLogger) Handle(err error) {
+	log.Print(err)
+}
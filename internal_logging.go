@@ -24,3 +24,28 @@ import (
 func SetLogger(logger logr.Logger) {
 	global.SetLogger(logger)
 }
+
+// LogLevel is the minimum severity of the messages logged internally to
+// opentelemetry.
+type LogLevel = global.Level
+
+const (
+	// LogLevelError only logs errors.
+	LogLevelError = global.ErrorLevel
+	// LogLevelWarn logs warnings, like dropped telemetry, and errors.
+	LogLevelWarn = global.WarnLevel
+	// LogLevelInfo logs informational messages, warnings, and errors.
+	LogLevelInfo = global.InfoLevel
+	// LogLevelDebug logs all messages.
+	LogLevelDebug = global.DebugLevel
+)
+
+// SetLogLevel sets the minimum severity of the messages logged internally to
+// opentelemetry. It can be called at any time to change the verbosity.
+//
+// Messages at or above level are only logged if the logger set with
+// SetLogger is enabled at their verbosity. Errors are always logged. The
+// default level is LogLevelDebug, leaving filtering to the logger.
+func SetLogLevel(level LogLevel) {
+	global.SetLogLevel(level)
+}
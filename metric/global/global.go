@@ -15,6 +15,8 @@
 package global // import "go.opentelemetry.io/otel/metric/global"
 
 import (
+	"context"
+
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/internal/global"
 )
@@ -40,3 +42,16 @@ func MeterProvider() metric.MeterProvider {
 func SetMeterProvider(mp metric.MeterProvider) {
 	global.SetMeterProvider(mp)
 }
+
+// ForceFlushMetrics flushes the registered global meter provider if it has a
+// ForceFlush(context.Context) error method, like the basic controller of the
+// SDK, and returns the error it returns. It does nothing and returns nil if
+// the global meter provider cannot be flushed.
+func ForceFlushMetrics(ctx context.Context) error {
+	if f, ok := MeterProvider().(interface {
+		ForceFlush(context.Context) error
+	}); ok {
+		return f.ForceFlush(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+)
+
+type flushableMeterProvider struct {
+	metric.MeterProvider
+
+	flushed int
+	err     error
+}
+
+func (mp *flushableMeterProvider) ForceFlush(context.Context) error {
+	mp.flushed++
+	return mp.err
+}
+
+func TestForceFlushMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	prev := global.MeterProvider()
+	mp := &flushableMeterProvider{MeterProvider: metric.NewNoopMeterProvider()}
+	global.SetMeterProvider(mp)
+	t.Cleanup(func() { global.SetMeterProvider(prev) })
+	assert.NoError(t, global.ForceFlushMetrics(ctx))
+	assert.Equal(t, 1, mp.flushed)
+
+	mp.err = errors.New("flush failed")
+	assert.ErrorIs(t, global.ForceFlushMetrics(ctx), mp.err)
+	assert.Equal(t, 2, mp.flushed)
+
+	global.SetMeterProvider(metric.NewNoopMeterProvider())
+	assert.NoError(t, global.ForceFlushMetrics(ctx))
+	assert.Equal(t, 2, mp.flushed)
+}
+
+func TestForceFlushMetricsUnflushable(t *testing.T) {
+	ctx := context.Background()
+
+	// The default global meter provider cannot be flushed.
+	assert.NoError(t, global.ForceFlushMetrics(ctx))
+
+	prev := global.MeterProvider()
+	global.SetMeterProvider(metric.NewNoopMeterProvider())
+	t.Cleanup(func() { global.SetMeterProvider(prev) })
+	assert.NoError(t, global.ForceFlushMetrics(ctx))
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument // import "go.opentelemetry.io/otel/metric/instrument"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// AttributesFromBaggage returns the members of the baggage in ctx with one of
+// keys as string attributes, in the order of keys. Keys without a member in
+// the baggage are skipped.
+func AttributesFromBaggage(ctx context.Context, keys ...attribute.Key) []attribute.KeyValue {
+	bag := baggage.FromContext(ctx)
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		if m := bag.Member(string(k)); m.Key() != "" {
+			attrs = append(attrs, k.String(m.Value()))
+		}
+	}
+	return attrs
+}
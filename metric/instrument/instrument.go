@@ -14,6 +14,8 @@
 
 package instrument // import "go.opentelemetry.io/otel/metric/instrument"
 
+import "errors"
+
 // Asynchronous instruments are instruments that are updated within a Callback.
 // If an instrument is observed outside of it's callback it should be an error.
 //
@@ -28,3 +30,7 @@ type Asynchronous interface {
 type Synchronous interface {
 	synchronous()
 }
+
+// ErrBucketsUnsupported is returned when recording pre-aggregated buckets to
+// a Histogram that does not support it.
+var ErrBucketsUnsupported = errors.New("histogram does not support recording buckets")
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncfloat64 // import "go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+// BucketRecorder is implemented by the Histograms that record pre-aggregated
+// buckets.
+type BucketRecorder interface {
+	// RecordHistogram adds the distribution of values counted in counts,
+	// the counts of the buckets delimited by bounds, whose sum is sum. An
+	// error is returned if bounds are not the bucket boundaries of the
+	// histogram, or if there is not one more count than bounds.
+	RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum float64, attrs ...attribute.KeyValue) error
+}
+
+// RecordHistogram adds a distribution of values that is already aggregated
+// to h, e.g. by a system the values are bridged from. The values are
+// counted in counts, the counts of the buckets delimited by bounds, and
+// their sum is sum. There is one more count than bounds, the last bucket
+// counts the values greater than or equal to the last bound.
+//
+// The bounds must be the bucket boundaries of the histogram. If h is not a
+// BucketRecorder, instrument.ErrBucketsUnsupported is returned.
+func RecordHistogram(ctx context.Context, h Histogram, counts []uint64, bounds []float64, sum float64, attrs ...attribute.KeyValue) error {
+	if r, ok := h.(BucketRecorder); ok {
+		return r.RecordHistogram(ctx, counts, bounds, sum, attrs...)
+	}
+	return instrument.ErrBucketsUnsupported
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncint64 // import "go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+// BoundCounter is a Counter bound to a set of attributes. It records all
+// changes with these attributes. Their attribute.Set is built once when
+// binding. Changes recorded without additional attributes pass it to a Counter
+// that is a SetAdder, which avoids building the set on every call.
+//
+// A BoundCounter holds no resources of its Counter, it does not need to be
+// released when it is no longer used. It is safe for concurrent use if its
+// Counter is.
+type BoundCounter struct {
+	counter Counter
+	attrs   []attribute.KeyValue
+	set     *attribute.Set
+}
+
+// BindFromBaggage returns c bound to the members of the baggage in ctx with
+// one of keys. Each member is bound as a string attribute with its key. Keys
+// without a member in the baggage are skipped.
+//
+// This is meant to bind a Counter once per request, e.g. in an HTTP handler,
+// and record all the changes of the request with it.
+func BindFromBaggage(ctx context.Context, c Counter, keys ...attribute.Key) BoundCounter {
+	attrs := instrument.AttributesFromBaggage(ctx, keys...)
+	set := attribute.NewSet(attrs...)
+	return BoundCounter{
+		counter: c,
+		attrs:   attrs,
+		set:     &set,
+	}
+}
+
+// Add records a change to the counter with the bound attributes and attrs.
+func (b BoundCounter) Add(ctx context.Context, incr int64, attrs ...attribute.KeyValue) {
+	if len(attrs) == 0 {
+		AddSet(ctx, b.counter, incr, b.set)
+		return
+	}
+	all := make([]attribute.KeyValue, 0, len(b.attrs)+len(attrs))
+	all = append(all, b.attrs...)
+	all = append(all, attrs...)
+	b.counter.Add(ctx, incr, all...)
+}
+
+// Attributes returns the attributes b is bound to.
+func (b BoundCounter) Attributes() []attribute.KeyValue {
+	return b.attrs
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncint64_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type recordingCounter struct {
+	instrument.Synchronous
+
+	incrs []int64
+	attrs [][]attribute.KeyValue
+}
+
+func (c *recordingCounter) Add(_ context.Context, incr int64, attrs ...attribute.KeyValue) {
+	c.incrs = append(c.incrs, incr)
+	c.attrs = append(c.attrs, attrs)
+}
+
+func TestBindFromBaggage(t *testing.T) {
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	user, err := baggage.NewMember("user", "alice")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant, user)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	c := &recordingCounter{}
+	bound := syncint64.BindFromBaggage(ctx, c, "tenant", "route")
+	want := []attribute.KeyValue{attribute.String("tenant", "acme")}
+	assert.Equal(t, want, bound.Attributes())
+
+	bound.Add(context.Background(), 1)
+	bound.Add(context.Background(), 2, attribute.Int("code", 200))
+
+	assert.Equal(t, []int64{1, 2}, c.incrs)
+	assert.Equal(t, [][]attribute.KeyValue{
+		want,
+		{attribute.String("tenant", "acme"), attribute.Int("code", 200)},
+	}, c.attrs)
+}
+
+func TestBoundCounterAddSet(t *testing.T) {
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	c := &setCounter{}
+	bound := syncint64.BindFromBaggage(ctx, c, "tenant")
+	bound.Add(context.Background(), 1)
+	bound.Add(context.Background(), 1)
+
+	// The bound set is built once and reused for every change.
+	require.Len(t, c.sets, 2)
+	assert.Same(t, c.sets[0], c.sets[1])
+	assert.Equal(t, []attribute.KeyValue{attribute.String("tenant", "acme")}, c.sets[0].ToSlice())
+	assert.Empty(t, c.attrs)
+}
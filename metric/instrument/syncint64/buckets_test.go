@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncint64_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type bucketHistogram struct {
+	recordingHistogram
+
+	counts [][]uint64
+	sums   []int64
+}
+
+func (h *bucketHistogram) RecordHistogram(_ context.Context, counts []uint64, _ []float64, sum int64, _ ...attribute.KeyValue) error {
+	h.counts = append(h.counts, counts)
+	h.sums = append(h.sums, sum)
+	return nil
+}
+
+func TestRecordHistogram(t *testing.T) {
+	ctx := context.Background()
+	counts := []uint64{1, 2, 3}
+	bounds := []float64{5, 10}
+
+	h := &recordingHistogram{}
+	err := syncint64.RecordHistogram(ctx, h, counts, bounds, 42)
+	assert.ErrorIs(t, err, instrument.ErrBucketsUnsupported)
+	assert.Empty(t, h.incrs)
+
+	bh := &bucketHistogram{}
+	assert.NoError(t, syncint64.RecordHistogram(ctx, bh, counts, bounds, 42))
+	assert.Equal(t, [][]uint64{counts}, bh.counts)
+	assert.Equal(t, []int64{42}, bh.sums)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncint64 // import "go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SetAdder is implemented by the Counters and UpDownCounters that record
+// changes with a pre-built attribute.Set.
+type SetAdder interface {
+	// AddSet records a change to the counter with the attributes of set.
+	AddSet(ctx context.Context, incr int64, set *attribute.Set)
+}
+
+// SetRecorder is implemented by the Histograms that record values with a
+// pre-built attribute.Set.
+type SetRecorder interface {
+	// RecordSet adds an additional value to the distribution with the
+	// attributes of set.
+	RecordSet(ctx context.Context, incr int64, set *attribute.Set)
+}
+
+// AddSet records a change to c, a Counter or an UpDownCounter, with the
+// attributes of set. If c is a SetAdder, set is passed to it as is, which
+// avoids building the set from the attributes on every call. Otherwise the
+// attributes of set are passed to c.Add.
+//
+// This is meant for hot paths that record with the same attributes many
+// times, the set is built once and reused for every call.
+func AddSet(ctx context.Context, c Counter, incr int64, set *attribute.Set) {
+	if a, ok := c.(SetAdder); ok {
+		a.AddSet(ctx, incr, set)
+		return
+	}
+	c.Add(ctx, incr, set.ToSlice()...)
+}
+
+// RecordSet records a value to h with the attributes of set. If h is a
+// SetRecorder, set is passed to it as is, which avoids building the set from
+// the attributes on every call. Otherwise the attributes of set are passed
+// to h.Record.
+func RecordSet(ctx context.Context, h Histogram, incr int64, set *attribute.Set) {
+	if r, ok := h.(SetRecorder); ok {
+		r.RecordSet(ctx, incr, set)
+		return
+	}
+	h.Record(ctx, incr, set.ToSlice()...)
+}
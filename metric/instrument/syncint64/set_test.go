@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncint64_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type setCounter struct {
+	recordingCounter
+
+	sets []*attribute.Set
+}
+
+func (c *setCounter) AddSet(_ context.Context, incr int64, set *attribute.Set) {
+	c.incrs = append(c.incrs, incr)
+	c.sets = append(c.sets, set)
+}
+
+type recordingHistogram struct {
+	recordingCounter
+}
+
+func (h *recordingHistogram) Record(ctx context.Context, incr int64, attrs ...attribute.KeyValue) {
+	h.Add(ctx, incr, attrs...)
+}
+
+func TestAddSet(t *testing.T) {
+	ctx := context.Background()
+	set := attribute.NewSet(attribute.String("b", "2"), attribute.String("a", "1"))
+
+	c := &recordingCounter{}
+	syncint64.AddSet(ctx, c, 1, &set)
+	assert.Equal(t, []int64{1}, c.incrs)
+	assert.Equal(t, [][]attribute.KeyValue{set.ToSlice()}, c.attrs)
+
+	sc := &setCounter{}
+	syncint64.AddSet(ctx, sc, 2, &set)
+	assert.Equal(t, []int64{2}, sc.incrs)
+	assert.Equal(t, []*attribute.Set{&set}, sc.sets)
+	assert.Empty(t, sc.attrs)
+}
+
+func TestRecordSet(t *testing.T) {
+	set := attribute.NewSet(attribute.String("a", "1"))
+	h := &recordingHistogram{}
+	syncint64.RecordSet(context.Background(), h, 3, &set)
+	assert.Equal(t, []int64{3}, h.incrs)
+	assert.Equal(t, [][]attribute.KeyValue{set.ToSlice()}, h.attrs)
+}
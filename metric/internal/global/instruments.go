@@ -239,6 +239,12 @@ func (i *sfCounter) Add(ctx context.Context, incr float64, attrs ...attribute.Ke
 	}
 }
 
+func (i *sfCounter) AddSet(ctx context.Context, x float64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncfloat64.AddSet(ctx, ctr.(syncfloat64.Counter), x, set)
+	}
+}
+
 type sfUpDownCounter struct {
 	name string
 	opts []instrument.Option
@@ -263,6 +269,12 @@ func (i *sfUpDownCounter) Add(ctx context.Context, incr float64, attrs ...attrib
 	}
 }
 
+func (i *sfUpDownCounter) AddSet(ctx context.Context, x float64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncfloat64.AddSet(ctx, ctr.(syncfloat64.UpDownCounter), x, set)
+	}
+}
+
 type sfHistogram struct {
 	name string
 	opts []instrument.Option
@@ -287,6 +299,19 @@ func (i *sfHistogram) Record(ctx context.Context, x float64, attrs ...attribute.
 	}
 }
 
+func (i *sfHistogram) RecordSet(ctx context.Context, x float64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncfloat64.RecordSet(ctx, ctr.(syncfloat64.Histogram), x, set)
+	}
+}
+
+func (i *sfHistogram) RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum float64, attrs ...attribute.KeyValue) error {
+	if ctr := i.delegate.Load(); ctr != nil {
+		return syncfloat64.RecordHistogram(ctx, ctr.(syncfloat64.Histogram), counts, bounds, sum, attrs...)
+	}
+	return nil
+}
+
 type siCounter struct {
 	name string
 	opts []instrument.Option
@@ -311,6 +336,12 @@ func (i *siCounter) Add(ctx context.Context, x int64, attrs ...attribute.KeyValu
 	}
 }
 
+func (i *siCounter) AddSet(ctx context.Context, x int64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncint64.AddSet(ctx, ctr.(syncint64.Counter), x, set)
+	}
+}
+
 type siUpDownCounter struct {
 	name string
 	opts []instrument.Option
@@ -335,6 +366,12 @@ func (i *siUpDownCounter) Add(ctx context.Context, x int64, attrs ...attribute.K
 	}
 }
 
+func (i *siUpDownCounter) AddSet(ctx context.Context, x int64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncint64.AddSet(ctx, ctr.(syncint64.UpDownCounter), x, set)
+	}
+}
+
 type siHistogram struct {
 	name string
 	opts []instrument.Option
@@ -358,3 +395,16 @@ func (i *siHistogram) Record(ctx context.Context, x int64, attrs ...attribute.Ke
 		ctr.(syncint64.Histogram).Record(ctx, x, attrs...)
 	}
 }
+
+func (i *siHistogram) RecordSet(ctx context.Context, x int64, set *attribute.Set) {
+	if ctr := i.delegate.Load(); ctr != nil {
+		syncint64.RecordSet(ctx, ctr.(syncint64.Histogram), x, set)
+	}
+}
+
+func (i *siHistogram) RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum int64, attrs ...attribute.KeyValue) error {
+	if ctr := i.delegate.Load(); ctr != nil {
+		return syncint64.RecordHistogram(ctx, ctr.(syncint64.Histogram), counts, bounds, sum, attrs...)
+	}
+	return nil
+}
@@ -18,9 +18,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 )
 
 func testFloat64Race(interact func(context.Context, float64, ...attribute.KeyValue), setDelegate func(metric.Meter)) {
@@ -135,6 +139,43 @@ func TestSyncInstrumentSetDelegateRace(t *testing.T) {
 	})
 }
 
+func TestSyncInstrumentForwardsSet(t *testing.T) {
+	ctx := context.Background()
+	set := attribute.NewSet(attribute.String("key", "value"))
+
+	t.Run("Float64", func(t *testing.T) {
+		ctr, udc, hist := &sfCounter{}, &sfUpDownCounter{}, &sfHistogram{}
+		delegates := []*testSetFloatInstrument{{}, {}, {}}
+		ctr.delegate.Store(delegates[0])
+		udc.delegate.Store(delegates[1])
+		hist.delegate.Store(delegates[2])
+
+		syncfloat64.AddSet(ctx, ctr, 1, &set)
+		syncfloat64.AddSet(ctx, udc, 1, &set)
+		syncfloat64.RecordSet(ctx, hist, 1, &set)
+
+		for _, d := range delegates {
+			assert.Same(t, &set, d.set, "the set was not forwarded to the delegate")
+		}
+	})
+
+	t.Run("Int64", func(t *testing.T) {
+		ctr, udc, hist := &siCounter{}, &siUpDownCounter{}, &siHistogram{}
+		delegates := []*testSetIntInstrument{{}, {}, {}}
+		ctr.delegate.Store(delegates[0])
+		udc.delegate.Store(delegates[1])
+		hist.delegate.Store(delegates[2])
+
+		syncint64.AddSet(ctx, ctr, 1, &set)
+		syncint64.AddSet(ctx, udc, 1, &set)
+		syncint64.RecordSet(ctx, hist, 1, &set)
+
+		for _, d := range delegates {
+			assert.Same(t, &set, d.set, "the set was not forwarded to the delegate")
+		}
+	})
+}
+
 type testCountingFloatInstrument struct {
 	count int
 
@@ -168,3 +209,29 @@ func (i *testCountingIntInstrument) Add(context.Context, int64, ...attribute.Key
 func (i *testCountingIntInstrument) Record(context.Context, int64, ...attribute.KeyValue) {
 	i.count++
 }
+
+type testSetFloatInstrument struct {
+	testCountingFloatInstrument
+
+	set *attribute.Set
+}
+
+func (i *testSetFloatInstrument) AddSet(_ context.Context, _ float64, set *attribute.Set) {
+	i.set = set
+}
+func (i *testSetFloatInstrument) RecordSet(_ context.Context, _ float64, set *attribute.Set) {
+	i.set = set
+}
+
+type testSetIntInstrument struct {
+	testCountingIntInstrument
+
+	set *attribute.Set
+}
+
+func (i *testSetIntInstrument) AddSet(_ context.Context, _ int64, set *attribute.Set) {
+	i.set = set
+}
+func (i *testSetIntInstrument) RecordSet(_ context.Context, _ int64, set *attribute.Set) {
+	i.set = set
+}
@@ -16,6 +16,7 @@ package global // import "go.opentelemetry.io/otel/metric/internal/global"
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 
@@ -118,6 +119,30 @@ type delegatedInstrument interface {
 	setDelegate(metric.Meter)
 }
 
+// maxPendingInstruments is the number of instruments a meter can hold before
+// a delegate is set. Creating more is reported to the error handler once, as
+// it likely means SetMeterProvider is never called and the instruments are
+// leaked.
+var maxPendingInstruments = 1000
+
+// appendInstrument adds inst to the instruments delegated when a delegate is
+// set.
+func (m *meter) appendInstrument(inst delegatedInstrument) {
+	m.mtx.Lock()
+	m.instruments = append(m.instruments, inst)
+	n := len(m.instruments)
+	m.mtx.Unlock()
+
+	// The error handler is called without holding m.mtx so it can use the
+	// global Meters itself.
+	if n == maxPendingInstruments+1 {
+		otel.Handle(fmt.Errorf(
+			"meter %q holds more than %d instruments waiting for a MeterProvider, SetMeterProvider may be missing",
+			m.name, maxPendingInstruments,
+		))
+	}
+}
+
 // setDelegate configures m to delegate all Meter functionality to Meters
 // created by provider.
 //
@@ -129,18 +154,20 @@ func (m *meter) setDelegate(provider metric.MeterProvider) {
 	m.delegate.Store(meter)
 
 	m.mtx.Lock()
-	defer m.mtx.Unlock()
+	instruments, callbacks := m.instruments, m.callbacks
+	m.instruments = nil
+	m.callbacks = nil
+	m.mtx.Unlock()
 
-	for _, inst := range m.instruments {
+	// Delegating can send errors to the error handler, which must not be
+	// called while holding m.mtx.
+	for _, inst := range instruments {
 		inst.setDelegate(meter)
 	}
 
-	for _, callback := range m.callbacks {
+	for _, callback := range callbacks {
 		callback.setDelegate(meter)
 	}
-
-	m.instruments = nil
-	m.callbacks = nil
 }
 
 // AsyncInt64 is the namespace for the Asynchronous Integer instruments.
@@ -234,28 +261,22 @@ type afInstProvider meter
 
 // Counter creates an instrument for recording increasing values.
 func (ip *afInstProvider) Counter(name string, opts ...instrument.Option) (asyncfloat64.Counter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &afCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // UpDownCounter creates an instrument for recording changes of a value.
 func (ip *afInstProvider) UpDownCounter(name string, opts ...instrument.Option) (asyncfloat64.UpDownCounter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &afUpDownCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // Gauge creates an instrument for recording the current value.
 func (ip *afInstProvider) Gauge(name string, opts ...instrument.Option) (asyncfloat64.Gauge, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &afGauge{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
@@ -263,28 +284,22 @@ type aiInstProvider meter
 
 // Counter creates an instrument for recording increasing values.
 func (ip *aiInstProvider) Counter(name string, opts ...instrument.Option) (asyncint64.Counter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &aiCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // UpDownCounter creates an instrument for recording changes of a value.
 func (ip *aiInstProvider) UpDownCounter(name string, opts ...instrument.Option) (asyncint64.UpDownCounter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &aiUpDownCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // Gauge creates an instrument for recording the current value.
 func (ip *aiInstProvider) Gauge(name string, opts ...instrument.Option) (asyncint64.Gauge, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &aiGauge{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
@@ -292,28 +307,22 @@ type sfInstProvider meter
 
 // Counter creates an instrument for recording increasing values.
 func (ip *sfInstProvider) Counter(name string, opts ...instrument.Option) (syncfloat64.Counter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &sfCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // UpDownCounter creates an instrument for recording changes of a value.
 func (ip *sfInstProvider) UpDownCounter(name string, opts ...instrument.Option) (syncfloat64.UpDownCounter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &sfUpDownCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // Histogram creates an instrument for recording a distribution of values.
 func (ip *sfInstProvider) Histogram(name string, opts ...instrument.Option) (syncfloat64.Histogram, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &sfHistogram{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
@@ -321,27 +330,21 @@ type siInstProvider meter
 
 // Counter creates an instrument for recording increasing values.
 func (ip *siInstProvider) Counter(name string, opts ...instrument.Option) (syncint64.Counter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &siCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // UpDownCounter creates an instrument for recording changes of a value.
 func (ip *siInstProvider) UpDownCounter(name string, opts ...instrument.Option) (syncint64.UpDownCounter, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &siUpDownCounter{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
 
 // Histogram creates an instrument for recording a distribution of values.
 func (ip *siInstProvider) Histogram(name string, opts ...instrument.Option) (syncint64.Histogram, error) {
-	ip.mtx.Lock()
-	defer ip.mtx.Unlock()
 	ctr := &siHistogram{name: name, opts: opts}
-	ip.instruments = append(ip.instruments, ctr)
+	(*meter)(ip).appendInstrument(ctr)
 	return ctr, nil
 }
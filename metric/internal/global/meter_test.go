@@ -23,10 +23,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
 	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 )
 
 func TestMeterProviderRace(t *testing.T) {
@@ -257,3 +260,35 @@ func TestMeterDefersDelegations(t *testing.T) {
 	assert.IsType(t, &afCounter{}, actr)
 	assert.Equal(t, 1, mp.count)
 }
+
+func TestMeterPendingInstrumentsWarning(t *testing.T) {
+	defer func(n int) { maxPendingInstruments = n }(maxPendingInstruments)
+	maxPendingInstruments = 3
+
+	mp := &meterProvider{}
+	meter := mp.Meter("test")
+
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+		// The error handler can use the Meter that reported the error.
+		_, hErr := meter.SyncInt64().Counter("handler")
+		assert.NoError(t, hErr)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	var ctrs []syncint64.Counter
+	for i := 0; i < 10; i++ {
+		ctr, err := meter.SyncInt64().Counter(fmt.Sprintf("counter%d", i))
+		require.NoError(t, err)
+		ctrs = append(ctrs, ctr)
+	}
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "SetMeterProvider")
+
+	// All instruments are still delegated.
+	mp.setDelegate(&testMeterProvider{})
+	for _, ctr := range ctrs {
+		assert.NotNil(t, ctr.(*siCounter).delegate.Load())
+	}
+}
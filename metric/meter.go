@@ -51,6 +51,10 @@ type Meter interface {
 	//
 	// It is only valid to call Observe within the scope of the passed function,
 	// and only on the instruments that were registered with this call.
+	//
+	// Registering multiple instruments with a single callback allows related
+	// measurements, such as those read from the same system call, to be
+	// observed together and stay consistent with one another.
 	RegisterCallback(insts []instrument.Asynchronous, function func(context.Context)) error
 
 	// SyncInt64 is the namespace for the Synchronous Integer instruments
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package propagationtest provides testing helpers for the propagation
+// package. Users can use the TextMapPropagator and TextMapCarrier of this
+// package to verify how their instrumentation injects and extracts
+// cross-cutting concerns without having to use real headers.
+package propagationtest // import "go.opentelemetry.io/otel/propagation/propagationtest"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Field is a key-value pair set on a TextMapCarrier.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// TextMapCarrier is a propagation.TextMapCarrier that records how it is
+// used. The methods of a TextMapCarrier are safe to call concurrently.
+type TextMapCarrier struct {
+	mu sync.Mutex
+
+	gets []string
+	sets []Field
+	data map[string]string
+}
+
+var _ propagation.TextMapCarrier = (*TextMapCarrier)(nil)
+
+// NewTextMapCarrier returns a new *TextMapCarrier populated with data.
+func NewTextMapCarrier(data map[string]string) *TextMapCarrier {
+	return &TextMapCarrier{data: copyData(data)}
+}
+
+// Get returns the value associated with the passed key.
+func (c *TextMapCarrier) Get(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gets = append(c.gets, key)
+	return c.data[key]
+}
+
+// Set stores the key-value pair.
+func (c *TextMapCarrier) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sets = append(c.sets, Field{Key: key, Value: value})
+	c.data[key] = value
+}
+
+// Keys returns the keys for which this carrier has a value.
+func (c *TextMapCarrier) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.data))
+	for k := range c.data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Gets returns the keys passed to Get, in the order Get was called.
+func (c *TextMapCarrier) Gets() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.gets...)
+}
+
+// Sets returns the key-value pairs passed to Set, in the order Set was
+// called.
+func (c *TextMapCarrier) Sets() []Field {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Field(nil), c.sets...)
+}
+
+// Data returns a copy of the key-value pairs c currently carries.
+func (c *TextMapCarrier) Data() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return copyData(c.data)
+}
+
+// Reset clears the recorded calls and sets the carried values to data.
+func (c *TextMapCarrier) Reset(data map[string]string) {
+	copied := copyData(data)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gets = nil
+	c.sets = nil
+	c.data = copied
+}
+
+func copyData(data map[string]string) map[string]string {
+	copied := make(map[string]string, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+	return copied
+}
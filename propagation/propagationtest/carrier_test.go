@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagationtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextMapCarrier(t *testing.T) {
+	data := map[string]string{"key": "value"}
+	c := NewTextMapCarrier(data)
+	// The passed data is copied.
+	data["other"] = "value"
+
+	assert.Equal(t, "value", c.Get("key"))
+	assert.Equal(t, "", c.Get("other"))
+	assert.Equal(t, []string{"key", "other"}, c.Gets())
+
+	c.Set("other", "one")
+	c.Set("other", "two")
+	assert.Equal(t, []Field{{"other", "one"}, {"other", "two"}}, c.Sets())
+	assert.Equal(t, map[string]string{"key": "value", "other": "two"}, c.Data())
+	assert.ElementsMatch(t, []string{"key", "other"}, c.Keys())
+
+	c.Reset(nil)
+	assert.Empty(t, c.Gets())
+	assert.Empty(t, c.Sets())
+	assert.Empty(t, c.Data())
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagationtest_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/propagation/propagationtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// handle is instrumentation under test. It continues the trace of an
+// incoming request and propagates it to an outgoing one.
+func handle(p propagation.TextMapPropagator, in, out propagation.TextMapCarrier) {
+	ctx := p.Extract(context.Background(), in)
+	p.Inject(ctx, out)
+}
+
+func ExampleTextMapPropagator() {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	p := propagationtest.NewTextMapPropagator("x-request")
+	p.SetExtractSpanContext(sc)
+	p.SetInjectData(map[string]string{"x-request": "outgoing"})
+
+	in := propagationtest.NewTextMapCarrier(map[string]string{"x-request": "incoming"})
+	out := propagationtest.NewTextMapCarrier(nil)
+	handle(p, in, out)
+
+	fmt.Println(p.Extractions()[0].Values["x-request"])
+	fmt.Println(p.Injections()[0].SpanContext.TraceID())
+	fmt.Println(out.Data()["x-request"])
+	// Output:
+	// incoming
+	// 01000000000000000000000000000000
+	// outgoing
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagationtest // import "go.opentelemetry.io/otel/propagation/propagationtest"
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Injection is a call of the Inject method of a TextMapPropagator.
+type Injection struct {
+	// SpanContext is the SpanContext contained in the injected context.
+	SpanContext trace.SpanContext
+	// Baggage is the Baggage contained in the injected context.
+	Baggage baggage.Baggage
+}
+
+// Extraction is a call of the Extract method of a TextMapPropagator.
+type Extraction struct {
+	// Values are the values the carrier held for the fields of the
+	// TextMapPropagator.
+	Values map[string]string
+}
+
+// TextMapPropagator is a programmable propagation.TextMapPropagator that
+// records the calls made to it. The methods of a TextMapPropagator are safe
+// to call concurrently.
+type TextMapPropagator struct {
+	mu sync.Mutex
+
+	fields      []string
+	injectData  map[string]string
+	extractSC   trace.SpanContext
+	injections  []Injection
+	extractions []Extraction
+}
+
+var _ propagation.TextMapPropagator = (*TextMapPropagator)(nil)
+
+// NewTextMapPropagator returns a new TextMapPropagator that uses fields as
+// the keys it reads from a carrier when Extract is called.
+func NewTextMapPropagator(fields ...string) *TextMapPropagator {
+	return &TextMapPropagator{fields: append([]string(nil), fields...)}
+}
+
+// SetInjectData sets the key-value pairs p sets on a carrier when Inject is
+// called.
+func (p *TextMapPropagator) SetInjectData(data map[string]string) {
+	copied := copyData(data)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.injectData = copied
+}
+
+// SetExtractSpanContext sets the SpanContext p returns as the remote
+// SpanContext of the context returned from Extract. If sc is not valid, the
+// context passed to Extract is returned unchanged.
+func (p *TextMapPropagator) SetExtractSpanContext(sc trace.SpanContext) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.extractSC = sc
+}
+
+// Inject records the SpanContext and Baggage of ctx and sets the data of p
+// on carrier.
+func (p *TextMapPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.mu.Lock()
+	p.injections = append(p.injections, Injection{
+		SpanContext: trace.SpanContextFromContext(ctx),
+		Baggage:     baggage.FromContext(ctx),
+	})
+	keys := make([]string, 0, len(p.injectData))
+	for k := range p.injectData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	data := make([]Field, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, Field{Key: k, Value: p.injectData[k]})
+	}
+	p.mu.Unlock()
+
+	// Do not hold the lock while calling into carrier.
+	for _, f := range data {
+		carrier.Set(f.Key, f.Value)
+	}
+}
+
+// Extract records the values carrier holds for the fields of p and returns
+// ctx with the SpanContext set with SetExtractSpanContext, if any.
+func (p *TextMapPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	p.mu.Lock()
+	fields, sc := p.fields, p.extractSC
+	p.mu.Unlock()
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f] = carrier.Get(f)
+	}
+
+	p.mu.Lock()
+	p.extractions = append(p.extractions, Extraction{Values: values})
+	p.mu.Unlock()
+
+	if !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the fields p was created with.
+func (p *TextMapPropagator) Fields() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.fields...)
+}
+
+// Injections returns the calls made to Inject, in the order they were made.
+func (p *TextMapPropagator) Injections() []Injection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Injection(nil), p.injections...)
+}
+
+// Extractions returns the calls made to Extract, in the order they were
+// made.
+func (p *TextMapPropagator) Extractions() []Extraction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Extraction(nil), p.extractions...)
+}
+
+// Reset clears the calls recorded by p. The data set with SetInjectData and
+// the SpanContext set with SetExtractSpanContext are kept.
+func (p *TextMapPropagator) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.injections = nil
+	p.extractions = nil
+}
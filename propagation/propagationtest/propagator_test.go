@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagationtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var sc = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{1},
+	SpanID:     trace.SpanID{2},
+	TraceFlags: trace.FlagsSampled,
+})
+
+func TestTextMapPropagatorInject(t *testing.T) {
+	p := NewTextMapPropagator("key")
+	p.SetInjectData(map[string]string{"b": "2", "a": "1"})
+
+	m, err := baggage.NewMember("k", "v")
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+
+	c := NewTextMapCarrier(nil)
+	p.Inject(ctx, c)
+	p.Inject(context.Background(), c)
+
+	assert.Equal(t, []Injection{
+		{SpanContext: sc, Baggage: bag},
+		{},
+	}, p.Injections())
+	// Data is set in key order for each injection.
+	assert.Equal(t, []Field{{"a", "1"}, {"b", "2"}, {"a", "1"}, {"b", "2"}}, c.Sets())
+}
+
+func TestTextMapPropagatorExtract(t *testing.T) {
+	p := NewTextMapPropagator("a", "b")
+	c := NewTextMapCarrier(map[string]string{"a": "1", "c": "3"})
+
+	ctx := p.Extract(context.Background(), c)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+
+	p.SetExtractSpanContext(sc)
+	ctx = p.Extract(context.Background(), c)
+	assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(ctx))
+
+	assert.Equal(t, []Extraction{
+		{Values: map[string]string{"a": "1", "b": ""}},
+		{Values: map[string]string{"a": "1", "b": ""}},
+	}, p.Extractions())
+	assert.Equal(t, []string{"a", "b", "a", "b"}, c.Gets())
+	assert.Equal(t, []string{"a", "b"}, p.Fields())
+
+	p.Reset()
+	assert.Empty(t, p.Injections())
+	assert.Empty(t, p.Extractions())
+}
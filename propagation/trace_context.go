@@ -28,6 +28,10 @@ const (
 	maxVersion        = 254
 	traceparentHeader = "traceparent"
 	tracestateHeader  = "tracestate"
+
+	// supportedFlags are the trace-flags defined by the trace-context
+	// specification, all other flags are reserved and need to be zero.
+	supportedFlags = trace.FlagsSampled | trace.FlagsRandom
 )
 
 // TraceContext is a propagator that supports the W3C Trace Context format
@@ -41,7 +45,7 @@ const (
 type TraceContext struct{}
 
 var _ TextMapPropagator = TraceContext{}
-var traceCtxRegExp = regexp.MustCompile("^(?P<version>[0-9a-f]{2})-(?P<traceID>[a-f0-9]{32})-(?P<spanID>[a-f0-9]{16})-(?P<traceFlags>[a-f0-9]{2})(?:-.*)?$")
+var traceCtxRegExp = regexp.MustCompile("^(?P<version>[0-9a-f]{2})-(?P<traceID>[a-f0-9]{32})-(?P<spanID>[a-f0-9]{16})-(?P<traceFlags>[a-f0-9]{2})(?P<extra>-.*)?$")
 
 // Inject set tracecontext from the Context into the carrier.
 func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
@@ -54,8 +58,9 @@ func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
 		carrier.Set(tracestateHeader, ts)
 	}
 
-	// Clear all flags other than the trace-context supported sampling bit.
-	flags := sc.TraceFlags() & trace.FlagsSampled
+	// Clear all flags other than the trace-context supported sampled and
+	// random bits.
+	flags := sc.TraceFlags() & supportedFlags
 
 	h := fmt.Sprintf("%.2x-%s-%s-%s",
 		supportedVersion,
@@ -70,6 +75,12 @@ func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
 // The returned Context will be a copy of ctx and contain the extracted
 // tracecontext as the remote SpanContext. If the extracted tracecontext is
 // invalid, the passed ctx will be returned directly instead.
+//
+// A traceparent with a version greater than 00 is parsed as a version 00
+// traceparent: the trace-id, parent-id, and trace-flags are read from the
+// first 55 characters and any data following them, which has to be
+// separated by a dash, is ignored. Version ff is invalid, as are version 00
+// traceparents with additional data.
 func (tc TraceContext) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
 	sc := tc.extract(carrier)
 	if !sc.IsValid() {
@@ -90,7 +101,7 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 
-	if len(matches) < 5 { // four subgroups plus the overall match
+	if len(matches) < 6 { // five subgroups plus the overall match
 		return trace.SpanContext{}
 	}
 
@@ -106,7 +117,9 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 
-	if version == 0 && len(matches) != 5 { // four subgroups plus the overall match
+	// Version 00 does not allow additional data. A lone trailing dash is
+	// tolerated as it is produced by some B3 implementations.
+	if version == 0 && len(matches[5]) > 1 {
 		return trace.SpanContext{}
 	}
 
@@ -133,11 +146,12 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 	opts, err := hex.DecodeString(matches[4])
-	if err != nil || len(opts) < 1 || (version == 0 && opts[0] > 2) {
+	if err != nil || len(opts) < 1 || (version == 0 && trace.TraceFlags(opts[0])&^supportedFlags != 0) {
 		return trace.SpanContext{}
 	}
-	// Clear all flags other than the trace-context supported sampling bit.
-	scc.TraceFlags = trace.TraceFlags(opts[0]) & trace.FlagsSampled
+	// Clear all flags other than the trace-context supported sampled and
+	// random bits.
+	scc.TraceFlags = trace.TraceFlags(opts[0]) & supportedFlags
 
 	// Ignore the error returned here. Failure to parse tracestate MUST NOT
 	// affect the parsing of traceparent according to the W3C tracecontext
@@ -68,6 +68,42 @@ func TestExtractValidTraceContext(t *testing.T) {
 				Remote:     true,
 			}),
 		},
+		{
+			name: "random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-02"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "sampled and random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "future version sampled and random with unused bits set",
+			header: http.Header{
+				traceparent: []string{"02-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0b"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
 		{
 			name: "valid tracestate",
 			header: http.Header{
@@ -150,6 +186,18 @@ func TestExtractValidTraceContext(t *testing.T) {
 				Remote:  true,
 			}),
 		},
+		{
+			name: "future version additional fields",
+			header: http.Header{
+				traceparent: []string{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-00f067aa0ba902b7-ffff"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
 		{
 			name: "B3 format ending in dash",
 			header: http.Header{
@@ -252,6 +300,26 @@ func TestExtractInvalidTraceContextFromHTTPReq(t *testing.T) {
 			name:   "empty options",
 			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-",
 		},
+		{
+			name:   "invalid version",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "version 00 additional data",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-XYZxsf09",
+		},
+		{
+			name:   "future version additional data without dash",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01XYZxsf09",
+		},
+		{
+			name:   "future version short trace ID",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01-XYZxsf09",
+		},
+		{
+			name:   "future version short length",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0",
+		},
 	}
 
 	empty := trace.SpanContext{}
@@ -298,10 +366,34 @@ func TestInjectValidTraceContext(t *testing.T) {
 				Remote:     true,
 			}),
 		},
+		{
+			name: "random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-02"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "sampled and random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
 		{
 			name: "unsupported trace flag bits dropped",
 			header: http.Header{
-				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03"},
 			},
 			sc: trace.NewSpanContext(trace.SpanContextConfig{
 				TraceID:    traceID,
@@ -0,0 +1,479 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential/mapping"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential/mapping/exponent"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential/mapping/logarithm"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of buckets per
+	// sign (positive, negative).
+	DefaultMaxSize int32 = 160
+
+	// MinSize is the smallest reasonable maximum number of buckets
+	// per sign.  At the minimum scale, all normal float64 values
+	// map into two buckets.
+	MinSize int32 = 2
+
+	// DefaultMaxScale is the default scale used before any
+	// measurement is recorded.  The scale is lowered as needed to
+	// keep the number of buckets within the maximum size.
+	DefaultMaxScale int32 = logarithm.MaxScale
+)
+
+type (
+	// Aggregator observes events and counts them in exponentially
+	// sized buckets.  It also calculates the sum and count of all
+	// events.
+	Aggregator struct {
+		lock     sync.Mutex
+		kind     number.Kind
+		maxSize  int32
+		maxScale int32
+		state    *state
+	}
+
+	// config describes how the exponential histogram is aggregated.
+	config struct {
+		maxSize  int32
+		maxScale int32
+	}
+
+	// Option configures an exponential histogram config.
+	Option interface {
+		// apply sets one or more config fields.
+		apply(*config)
+	}
+
+	// state represents the state of an exponential histogram.
+	state struct {
+		sum       number.Number
+		count     uint64
+		zeroCount uint64
+
+		scale   int32
+		mapping mapping.Mapping
+
+		positive buckets
+		negative buckets
+	}
+
+	// buckets is a contiguous range of bucket counts starting at
+	// index offset.
+	buckets struct {
+		offset int32
+		counts []uint64
+	}
+)
+
+// WithMaxSize sets the maximum number of buckets used for each of the
+// positive and negative ranges.  Values smaller than MinSize are
+// raised to MinSize.
+func WithMaxSize(size int32) Option {
+	return maxSizeOption{size}
+}
+
+type maxSizeOption struct {
+	size int32
+}
+
+func (o maxSizeOption) apply(config *config) {
+	config.maxSize = o.size
+}
+
+// WithMaxScale sets the initial, and highest, scale of the histogram.
+// Values are clamped to the range supported by the mapping functions,
+// exponent.MinScale through logarithm.MaxScale.
+func WithMaxScale(scale int32) Option {
+	return maxScaleOption{scale}
+}
+
+type maxScaleOption struct {
+	scale int32
+}
+
+func (o maxScaleOption) apply(config *config) {
+	config.maxScale = o.scale
+}
+
+var _ aggregator.Aggregator = &Aggregator{}
+var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Count = &Aggregator{}
+var _ aggregation.ExponentialHistogram = &Aggregator{}
+var _ aggregation.ExponentialBuckets = &buckets{}
+
+// New returns a new aggregator for computing base-2 exponential
+// histograms.
+//
+// The histogram starts at the maximum scale and automatically lowers
+// the scale, merging adjacent buckets, when recording a value would
+// exceed the maximum number of buckets.
+func New(cnt int, desc *sdkapi.Descriptor, opts ...Option) []Aggregator {
+	cfg := config{
+		maxSize:  DefaultMaxSize,
+		maxScale: DefaultMaxScale,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	if cfg.maxSize < MinSize {
+		cfg.maxSize = MinSize
+	}
+	if cfg.maxScale < exponent.MinScale {
+		cfg.maxScale = exponent.MinScale
+	} else if cfg.maxScale > logarithm.MaxScale {
+		cfg.maxScale = logarithm.MaxScale
+	}
+
+	aggs := make([]Aggregator, cnt)
+	for i := range aggs {
+		aggs[i] = Aggregator{
+			kind:     desc.NumberKind(),
+			maxSize:  cfg.maxSize,
+			maxScale: cfg.maxScale,
+		}
+		aggs[i].state = aggs[i].newState()
+	}
+	return aggs
+}
+
+// Aggregation returns an interface for reading the state of this aggregator.
+func (c *Aggregator) Aggregation() aggregation.Aggregation {
+	return c
+}
+
+// Kind returns aggregation.ExponentialHistogramKind.
+func (c *Aggregator) Kind() aggregation.Kind {
+	return aggregation.ExponentialHistogramKind
+}
+
+// Sum returns the sum of all values in the checkpoint.
+func (c *Aggregator) Sum() (number.Number, error) {
+	return c.state.sum, nil
+}
+
+// Count returns the number of values in the checkpoint, including
+// zeros.
+func (c *Aggregator) Count() (uint64, error) {
+	return c.state.count, nil
+}
+
+// Scale returns the scale of the buckets in the checkpoint.
+func (c *Aggregator) Scale() (int32, error) {
+	return c.state.scale, nil
+}
+
+// ZeroCount returns the number of zero values in the checkpoint.
+func (c *Aggregator) ZeroCount() (uint64, error) {
+	return c.state.zeroCount, nil
+}
+
+// Positive returns the buckets of positive values in the checkpoint.
+func (c *Aggregator) Positive() (aggregation.ExponentialBuckets, error) {
+	return &c.state.positive, nil
+}
+
+// Negative returns the buckets of negative values in the checkpoint.
+func (c *Aggregator) Negative() (aggregation.ExponentialBuckets, error) {
+	return &c.state.negative, nil
+}
+
+// SynchronizedMove saves the current state into oa and resets the current state to
+// the empty set.
+func (c *Aggregator) SynchronizedMove(oa aggregator.Aggregator, desc *sdkapi.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+
+	if oa != nil && o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	if o != nil {
+		// Swap case: reset the target state before swapping
+		// it under the lock below.
+		o.clearState()
+	}
+
+	c.lock.Lock()
+	if o != nil {
+		c.state, o.state = o.state, c.state
+	} else {
+		// No swap case: asynchronous instruments use a single
+		// Aggregator.
+		c.clearState()
+	}
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *Aggregator) newState() *state {
+	return &state{
+		scale:   c.maxScale,
+		mapping: newMapping(c.maxScale),
+	}
+}
+
+func (c *Aggregator) clearState() {
+	c.state.sum = 0
+	c.state.count = 0
+	c.state.zeroCount = 0
+	c.state.scale = c.maxScale
+	c.state.mapping = newMapping(c.maxScale)
+	c.state.positive.clear()
+	c.state.negative.clear()
+}
+
+// Update adds the recorded measurement to the current data set.
+func (c *Aggregator) Update(_ context.Context, n number.Number, desc *sdkapi.Descriptor) error {
+	kind := desc.NumberKind()
+	value := n.CoerceToFloat64(kind)
+
+	if math.IsNaN(value) {
+		return aggregation.ErrNaNInput
+	}
+	if math.IsInf(value, 0) {
+		return aggregation.ErrInfInput
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.count++
+	c.state.sum.AddNumber(kind, n)
+
+	if value == 0 {
+		c.state.zeroCount++
+		return nil
+	}
+
+	b := &c.state.positive
+	if value < 0 {
+		b = &c.state.negative
+		value = -value
+	}
+
+	index := c.state.mapping.MapToIndex(value)
+	if !b.empty() {
+		low, high := int64(b.offset), int64(b.high())
+		if int64(index) < low {
+			low = int64(index)
+		} else if int64(index) > high {
+			high = int64(index)
+		}
+		if change := c.scaleChange(low, high); change > 0 {
+			c.state.downscale(change)
+			index >>= change
+		}
+	}
+	b.increment(index, 1)
+	return nil
+}
+
+// Merge combines two exponential histograms into a single one.  The
+// result uses the lower of the two scales, lowered further when the
+// combined range of buckets does not fit the maximum size.
+func (c *Aggregator) Merge(oa aggregator.Aggregator, desc *sdkapi.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+	if o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	c.state.sum.AddNumber(desc.NumberKind(), o.state.sum)
+	c.state.count += o.state.count
+	c.state.zeroCount += o.state.zeroCount
+
+	scale := c.state.scale
+	if o.state.scale < scale {
+		scale = o.state.scale
+	}
+
+	// Find the extra scale change needed for the combined range
+	// of each sign to fit.
+	var change int32
+	for _, pair := range [2][2]*buckets{
+		{&c.state.positive, &o.state.positive},
+		{&c.state.negative, &o.state.negative},
+	} {
+		low, high, ok := pair[0].span(c.state.scale - scale)
+		if olow, ohigh, ook := pair[1].span(o.state.scale - scale); ook {
+			if !ok || olow < low {
+				low = olow
+			}
+			if !ok || ohigh > high {
+				high = ohigh
+			}
+			ok = true
+		}
+		if !ok {
+			continue
+		}
+		if ch := scaleChangeFrom(scale, low, high, c.maxSize); ch > change {
+			change = ch
+		}
+	}
+	scale -= change
+
+	if shift := c.state.scale - scale; shift > 0 {
+		c.state.downscale(shift)
+	}
+
+	shift := o.state.scale - scale
+	c.state.positive.mergeFrom(&o.state.positive, shift)
+	c.state.negative.mergeFrom(&o.state.negative, shift)
+	return nil
+}
+
+// scaleChange returns how much to lower the current scale so that
+// indexes from low through high fit in the maximum size.
+func (c *Aggregator) scaleChange(low, high int64) int32 {
+	return scaleChangeFrom(c.state.scale, low, high, c.maxSize)
+}
+
+// scaleChangeFrom returns how much to lower scale so that indexes
+// from low through high fit in size buckets, without going below
+// exponent.MinScale.
+func scaleChangeFrom(scale int32, low, high int64, size int32) int32 {
+	var change int32
+	for high-low >= int64(size) && scale-change > exponent.MinScale {
+		low >>= 1
+		high >>= 1
+		change++
+	}
+	return change
+}
+
+// downscale lowers the scale of s by change, merging the buckets of
+// both signs accordingly.
+func (s *state) downscale(change int32) {
+	s.scale -= change
+	s.mapping = newMapping(s.scale)
+	s.positive.downscale(change)
+	s.negative.downscale(change)
+}
+
+// newMapping returns the mapping function for scale.  The scale is
+// always kept within the range supported by the mapping functions,
+// so no error is expected.
+func newMapping(scale int32) mapping.Mapping {
+	var (
+		m   mapping.Mapping
+		err error
+	)
+	if scale > exponent.MaxScale {
+		m, err = logarithm.NewMapping(scale)
+	} else {
+		m, err = exponent.NewMapping(scale)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Offset implements aggregation.ExponentialBuckets.
+func (b *buckets) Offset() int32 {
+	return b.offset
+}
+
+// Len implements aggregation.ExponentialBuckets.
+func (b *buckets) Len() uint32 {
+	return uint32(len(b.counts))
+}
+
+// At implements aggregation.ExponentialBuckets.
+func (b *buckets) At(i uint32) uint64 {
+	return b.counts[i]
+}
+
+func (b *buckets) empty() bool {
+	return len(b.counts) == 0
+}
+
+// high returns the index of the last bucket.
+func (b *buckets) high() int32 {
+	return b.offset + int32(len(b.counts)) - 1
+}
+
+// span returns the lowest and highest index of b after lowering its
+// scale by shift, and false if b is empty.
+func (b *buckets) span(shift int32) (low, high int64, ok bool) {
+	if b.empty() {
+		return 0, 0, false
+	}
+	return int64(b.offset) >> shift, int64(b.high()) >> shift, true
+}
+
+func (b *buckets) clear() {
+	b.offset = 0
+	b.counts = b.counts[:0]
+}
+
+// increment adds incr to the bucket at index, growing the range of
+// buckets as needed.
+func (b *buckets) increment(index int32, incr uint64) {
+	switch {
+	case b.empty():
+		b.offset = index
+		b.counts = append(b.counts, 0)
+	case index < b.offset:
+		grown := make([]uint64, int(b.high()-index)+1)
+		copy(grown[b.offset-index:], b.counts)
+		b.counts = grown
+		b.offset = index
+	case index > b.high():
+		for i := b.high(); i < index; i++ {
+			b.counts = append(b.counts, 0)
+		}
+	}
+	b.counts[index-b.offset] += incr
+}
+
+// downscale merges the buckets of b into the buckets of a scale lower
+// by change.
+func (b *buckets) downscale(change int32) {
+	if b.empty() || change == 0 {
+		return
+	}
+	offset := b.offset >> change
+	counts := make([]uint64, int((b.high()>>change)-offset)+1)
+	for i, cnt := range b.counts {
+		idx := (b.offset + int32(i)) >> change
+		counts[idx-offset] += cnt
+	}
+	b.offset = offset
+	b.counts = counts
+}
+
+// mergeFrom adds the counts of o, lowered in scale by shift, to b.
+func (b *buckets) mergeFrom(o *buckets, shift int32) {
+	for i, cnt := range o.counts {
+		if cnt == 0 {
+			continue
+		}
+		b.increment((o.offset+int32(i))>>shift, cnt)
+	}
+}
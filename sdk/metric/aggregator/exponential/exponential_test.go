@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+)
+
+const count = 1000
+
+func new2(desc *sdkapi.Descriptor, options ...exponential.Option) (_, _ *exponential.Aggregator) {
+	alloc := exponential.New(2, desc, options...)
+	return &alloc[0], &alloc[1]
+}
+
+func new3(desc *sdkapi.Descriptor, options ...exponential.Option) (_, _, _ *exponential.Aggregator) {
+	alloc := exponential.New(3, desc, options...)
+	return &alloc[0], &alloc[1], &alloc[2]
+}
+
+func randomSign() int {
+	if rand.Uint32() > math.MaxUint32/2 {
+		return -1
+	}
+	return 1
+}
+
+func positive(t *testing.T, agg *exponential.Aggregator) aggregation.ExponentialBuckets {
+	b, err := agg.Positive()
+	require.NoError(t, err)
+	return b
+}
+
+func negative(t *testing.T, agg *exponential.Aggregator) aggregation.ExponentialBuckets {
+	b, err := agg.Negative()
+	require.NoError(t, err)
+	return b
+}
+
+// counts returns the bucket counts of b.
+func counts(b aggregation.ExponentialBuckets) []uint64 {
+	out := make([]uint64, b.Len())
+	for i := range out {
+		out[i] = b.At(uint32(i))
+	}
+	return out
+}
+
+func bucketsTotal(b aggregation.ExponentialBuckets) uint64 {
+	var total uint64
+	for _, c := range counts(b) {
+		total += c
+	}
+	return total
+}
+
+func checkZero(t *testing.T, agg *exponential.Aggregator) {
+	sum, err := agg.Sum()
+	require.NoError(t, err)
+	require.Equal(t, number.Number(0), sum)
+
+	cnt, err := agg.Count()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), cnt)
+
+	zero, err := agg.ZeroCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), zero)
+
+	scale, err := agg.Scale()
+	require.NoError(t, err)
+	require.Equal(t, exponential.DefaultMaxScale, scale)
+
+	require.Empty(t, counts(positive(t, agg)))
+	require.Empty(t, counts(negative(t, agg)))
+}
+
+// requireEqualState checks a and b hold the same distribution.
+func requireEqualState(t *testing.T, kind number.Kind, a, b *exponential.Aggregator) {
+	aCount, _ := a.Count()
+	bCount, _ := b.Count()
+	require.Equal(t, aCount, bCount, "count")
+
+	aZero, _ := a.ZeroCount()
+	bZero, _ := b.ZeroCount()
+	require.Equal(t, aZero, bZero, "zero count")
+
+	aScale, _ := a.Scale()
+	bScale, _ := b.Scale()
+	require.Equal(t, aScale, bScale, "scale")
+
+	aPos, _ := a.Positive()
+	bPos, _ := b.Positive()
+	require.Equal(t, aPos.Offset(), bPos.Offset(), "positive offset")
+	require.Equal(t, counts(aPos), counts(bPos), "positive counts")
+
+	aNeg, _ := a.Negative()
+	bNeg, _ := b.Negative()
+	require.Equal(t, aNeg.Offset(), bNeg.Offset(), "negative offset")
+	require.Equal(t, counts(aNeg), counts(bNeg), "negative counts")
+
+	aSum, _ := a.Sum()
+	bSum, _ := b.Sum()
+	require.InEpsilon(t, aSum.CoerceToFloat64(kind), bSum.CoerceToFloat64(kind), 1e-9, "sum")
+}
+
+func TestExponentialHistogram(t *testing.T) {
+	aggregatortest.RunProfiles(t, func(t *testing.T, profile aggregatortest.Profile) {
+		descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, profile.NumberKind)
+		agg, ckpt := new2(descriptor, exponential.WithMaxSize(20))
+
+		// Repeat to make sure reused state is properly reset.
+		for rep := 0; rep < 3; rep++ {
+			all := aggregatortest.NewNumbers(profile.NumberKind)
+			var zeros uint64
+			for i := 0; i < count; i++ {
+				x := profile.Random(randomSign())
+				if x.CoerceToFloat64(profile.NumberKind) == 0 {
+					zeros++
+				}
+				all.Append(x)
+				aggregatortest.CheckedUpdate(t, agg, x, descriptor)
+			}
+
+			require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+			checkZero(t, agg)
+
+			cnt, err := ckpt.Count()
+			require.NoError(t, err)
+			require.Equal(t, all.Count(), cnt)
+
+			zero, err := ckpt.ZeroCount()
+			require.NoError(t, err)
+			require.Equal(t, zeros, zero)
+
+			sum, err := ckpt.Sum()
+			require.NoError(t, err)
+			allSum := all.Sum()
+			require.InEpsilon(t,
+				allSum.CoerceToFloat64(profile.NumberKind),
+				sum.CoerceToFloat64(profile.NumberKind),
+				1e-9,
+			)
+
+			pos := counts(positive(t, ckpt))
+			neg := counts(negative(t, ckpt))
+			require.LessOrEqual(t, len(pos), 20)
+			require.LessOrEqual(t, len(neg), 20)
+			require.Equal(t, cnt-zeros, bucketsTotal(positive(t, ckpt))+bucketsTotal(negative(t, ckpt)))
+		}
+	})
+}
+
+func TestExponentialHistogramRescale(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	agg, ckpt := new2(descriptor, exponential.WithMaxSize(4), exponential.WithMaxScale(0))
+
+	// At scale 0 powers of two map to their exponent.
+	for _, v := range []float64{1, 2, 4, 8} {
+		aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(v), descriptor)
+	}
+	scale, err := agg.Scale()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), scale)
+	pos, err := agg.Positive()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), pos.Offset())
+	assert.Equal(t, []uint64{1, 1, 1, 1}, counts(pos))
+
+	// A fifth bucket does not fit, the scale is lowered by one and
+	// pairs of buckets are merged.
+	aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(16), descriptor)
+	aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(-1), descriptor)
+	scale, err = agg.Scale()
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), scale)
+	pos, err = agg.Positive()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), pos.Offset())
+	assert.Equal(t, []uint64{2, 2, 1}, counts(pos))
+	neg, err := agg.Negative()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), neg.Offset())
+	assert.Equal(t, []uint64{1}, counts(neg))
+
+	// Reset restores the maximum scale.
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+	scale, err = agg.Scale()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), scale)
+}
+
+func TestExponentialHistogramMerge(t *testing.T) {
+	aggregatortest.RunProfiles(t, func(t *testing.T, profile aggregatortest.Profile) {
+		descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, profile.NumberKind)
+		opt := exponential.WithMaxSize(20)
+		agg1, agg2, want := new3(descriptor, opt)
+		ckpt1, ckpt2 := new2(descriptor, opt)
+
+		// The two halves use very different magnitudes so they
+		// are checkpointed at different scales.
+		for i := 0; i < count; i++ {
+			x := profile.Random(randomSign())
+			aggregatortest.CheckedUpdate(t, agg1, x, descriptor)
+			aggregatortest.CheckedUpdate(t, want, x, descriptor)
+
+			var y number.Number
+			if profile.NumberKind == number.Int64Kind {
+				y = number.NewInt64Number(x.AsInt64() * aggregatortest.Magnitude)
+			} else {
+				y = number.NewFloat64Number(x.AsFloat64() / aggregatortest.Magnitude)
+			}
+			aggregatortest.CheckedUpdate(t, agg2, y, descriptor)
+			aggregatortest.CheckedUpdate(t, want, y, descriptor)
+		}
+
+		require.NoError(t, agg1.SynchronizedMove(ckpt1, descriptor))
+		require.NoError(t, agg2.SynchronizedMove(ckpt2, descriptor))
+
+		aggregatortest.CheckedMerge(t, ckpt1, ckpt2, descriptor)
+		requireEqualState(t, profile.NumberKind, want, ckpt1)
+	})
+}
+
+func TestExponentialHistogramMergeEmpty(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	agg, empty, want := new3(descriptor)
+
+	for _, v := range []float64{0, 0.5, -3, 1e6} {
+		aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(v), descriptor)
+		aggregatortest.CheckedUpdate(t, want, number.NewFloat64Number(v), descriptor)
+	}
+	aggregatortest.CheckedMerge(t, agg, empty, descriptor)
+	requireEqualState(t, number.Float64Kind, want, agg)
+
+	aggregatortest.CheckedMerge(t, empty, agg, descriptor)
+	requireEqualState(t, number.Float64Kind, want, empty)
+}
+
+func TestExponentialHistogramInvalidInput(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	agg, _ := new2(descriptor)
+	ctx := context.Background()
+
+	err := agg.Update(ctx, number.NewFloat64Number(math.Inf(+1)), descriptor)
+	assert.True(t, errors.Is(err, aggregation.ErrInfInput))
+	err = agg.Update(ctx, number.NewFloat64Number(math.Inf(-1)), descriptor)
+	assert.True(t, errors.Is(err, aggregation.ErrInfInput))
+	err = agg.Update(ctx, number.NewFloat64Number(math.NaN()), descriptor)
+	assert.True(t, errors.Is(err, aggregation.ErrNaNInput))
+
+	checkZero(t, agg)
+}
+
+func TestExponentialHistogramExtremes(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	agg, _ := new2(descriptor, exponential.WithMaxSize(exponential.MinSize))
+
+	for _, v := range []float64{math.MaxFloat64, math.SmallestNonzeroFloat64, 1, 0x1p-1022} {
+		aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(v), descriptor)
+	}
+	pos, err := agg.Positive()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, pos.Len(), uint32(exponential.MinSize))
+	assert.Equal(t, uint64(4), bucketsTotal(pos))
+}
+
+func TestSynchronizedMoveReset(t *testing.T) {
+	aggregatortest.SynchronizedMoveResetTest(
+		t,
+		sdkapi.HistogramInstrumentKind,
+		func(desc *sdkapi.Descriptor) aggregator.Aggregator {
+			return &exponential.New(1, desc)[0]
+		},
+	)
+}
+
+func TestExponentialHistogramConcurrent(t *testing.T) {
+	const (
+		writers = 8
+		perWrit = 5000
+	)
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	agg, ckpt, total := new3(descriptor, exponential.WithMaxSize(40))
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < perWrit; i++ {
+				x := rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(20)-10))
+				aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(x), descriptor)
+			}
+		}(int64(w))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	collect := func() {
+		require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+		aggregatortest.CheckedMerge(t, total, ckpt, descriptor)
+	}
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		collect()
+	}
+
+	cnt, err := total.Count()
+	require.NoError(t, err)
+	require.Equal(t, uint64(writers*perWrit), cnt)
+
+	zero, err := total.ZeroCount()
+	require.NoError(t, err)
+	require.Equal(t, cnt-zero, bucketsTotal(positive(t, total))+bucketsTotal(negative(t, total)))
+	require.LessOrEqual(t, len(counts(positive(t, total))), 40)
+	require.LessOrEqual(t, len(counts(negative(t, total))), 40)
+}
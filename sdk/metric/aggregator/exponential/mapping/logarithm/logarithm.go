@@ -137,6 +137,16 @@ func (l *logarithmMapping) MapToIndex(value float64) int32 {
 	if value <= MinValue {
 		return l.minIndex
 	}
+
+	// Exact powers of two are computed from the exponent so they
+	// map to the same bucket as in the ../exponent mapping, which
+	// the floating point logarithm below may miss by one.  This
+	// keeps indexes consistent when a histogram lowers its scale.
+	if bits := math.Float64bits(value); bits&exponent.SignificandMask == 0 {
+		exp := int64(bits&exponent.ExponentMask)>>exponent.SignificandWidth - exponent.ExponentBias
+		return int32(exp << l.scale)
+	}
+
 	// Use Floor() to round toward 0.
 	index := int32(math.Floor(math.Log(value) * l.scaleFactor))
 
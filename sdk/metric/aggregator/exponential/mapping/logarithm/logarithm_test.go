@@ -47,12 +47,16 @@ func TestLogarithmMapping(t *testing.T) {
 	require.Equal(t, int32(+1), m.Scale())
 
 	// Note: Do not test exact boundaries, with the exception of
-	// 1, because we expect errors in that case (e.g.,
-	// MapToIndex(8) returns 5, an off-by-one.  See the following
-	// test.
+	// powers of two, because we expect errors in that case.  See
+	// the following test.
 	for _, pair := range []expectMapping{
 		{15, 7},
 		{9, 6},
+		{8, 6},
+		{4, 4},
+		{2, 2},
+		{0.5, -2},
+		{0.25, -4},
 		{7, 5},
 		{5, 4},
 		{3, 3},
@@ -248,3 +252,17 @@ func TestExponentIndexMin(t *testing.T) {
 		require.Equal(t, err, mapping.ErrUnderflow)
 	}
 }
+
+// TestLogarithmPowerOfTwo ensures that exact powers of two map to the
+// same index as the exponent mapping at every scale.
+func TestLogarithmPowerOfTwo(t *testing.T) {
+	for scale := MinScale; scale <= MaxScale; scale++ {
+		m, err := NewMapping(scale)
+		require.NoError(t, err)
+
+		for exp := exponent.MinNormalExponent; exp <= exponent.MaxNormalExponent; exp++ {
+			value := math.Ldexp(1, int(exp))
+			require.Equal(t, exp<<scale, m.MapToIndex(value), "scale %d value 2**%d", scale, exp)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package histogram // import "go.opentelemetry.io/otel/sdk/metric/aggregator/hist
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 
@@ -252,6 +253,39 @@ func (c *Aggregator) Update(_ context.Context, n number.Number, desc *sdkapi.Des
 	return nil
 }
 
+// MergeBuckets adds pre-aggregated bucket counts, whose values sum to sum, to
+// the current data set. The bounds must equal the boundaries of c, and there
+// must be one more count than bounds, otherwise an error wrapping
+// aggregation.ErrInconsistentBuckets is returned.
+func (c *Aggregator) MergeBuckets(counts []uint64, bounds []float64, sum number.Number, desc *sdkapi.Descriptor) error {
+	if len(bounds) != len(c.boundaries) {
+		return fmt.Errorf("%w: %d bounds, want %d", aggregation.ErrInconsistentBuckets, len(bounds), len(c.boundaries))
+	}
+	for i, b := range bounds {
+		if b != c.boundaries[i] {
+			return fmt.Errorf("%w: bound %d is %v, want %v", aggregation.ErrInconsistentBuckets, i, b, c.boundaries[i])
+		}
+	}
+	if len(counts) != len(bounds)+1 {
+		return fmt.Errorf("%w: %d counts for %d bounds", aggregation.ErrInconsistentBuckets, len(counts), len(bounds))
+	}
+
+	var count uint64
+	for _, n := range counts {
+		count += n
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.count += count
+	c.state.sum.AddNumber(desc.NumberKind(), sum)
+	for i, n := range counts {
+		c.state.bucketCounts[i] += n
+	}
+	return nil
+}
+
 // Merge combines two histograms that have the same buckets into a single one.
 func (c *Aggregator) Merge(oa aggregator.Aggregator, desc *sdkapi.Descriptor) error {
 	o, _ := oa.(*Aggregator)
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/number"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
 )
@@ -293,3 +294,40 @@ func TestHistogramDefaultBoundaries(t *testing.T) {
 		require.EqualValues(t, expect, bucks.Counts)
 	})
 }
+
+func TestHistogramMergeBuckets(t *testing.T) {
+	aggregatortest.RunProfiles(t, func(t *testing.T, profile aggregatortest.Profile) {
+		descriptor := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, profile.NumberKind)
+
+		agg, ckpt := new2(descriptor, histogram.WithExplicitBoundaries(testBoundaries))
+
+		bounds := []float64{250, 500, 750}
+		sum := profile.Random(+1)
+		require.NoError(t, agg.MergeBuckets([]uint64{1, 2, 3, 4}, bounds, sum, descriptor))
+		require.NoError(t, agg.MergeBuckets([]uint64{4, 3, 2, 1}, bounds, sum, descriptor))
+
+		err := agg.MergeBuckets([]uint64{1, 2, 3}, bounds, sum, descriptor)
+		require.ErrorIs(t, err, aggregation.ErrInconsistentBuckets)
+		err = agg.MergeBuckets([]uint64{1, 2, 3, 4}, []float64{250, 500, 1000}, sum, descriptor)
+		require.ErrorIs(t, err, aggregation.ErrInconsistentBuckets)
+		err = agg.MergeBuckets([]uint64{1, 2, 3}, []float64{250, 500}, sum, descriptor)
+		require.ErrorIs(t, err, aggregation.ErrInconsistentBuckets)
+
+		require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+		buckets, err := ckpt.Histogram()
+		require.NoError(t, err)
+		require.Equal(t, []uint64{5, 5, 5, 5}, buckets.Counts)
+
+		count, err := ckpt.Count()
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), count)
+
+		var want number.Number
+		want.AddNumber(profile.NumberKind, sum)
+		want.AddNumber(profile.NumberKind, sum)
+		asum, err := ckpt.Sum()
+		require.NoError(t, err)
+		require.Equal(t, 0, want.CompareNumber(profile.NumberKind, asum))
+	})
+}
@@ -32,6 +32,21 @@ type (
 	Aggregator struct {
 		// value is an atomic pointer to *lastValueData.  It is never nil.
 		value unsafe.Pointer
+
+		// now returns the timestamp of updates.
+		now func() time.Time
+	}
+
+	// config describes how the lastValue is aggregated.
+	config struct {
+		// now is the source of the timestamps recorded with values.
+		now func() time.Time
+	}
+
+	// Option configures a lastValue config.
+	Option interface {
+		// apply sets one or more config fields.
+		apply(*config)
 	}
 
 	// lastValueData stores the current value of a lastValue along with
@@ -55,13 +70,36 @@ var _ aggregation.LastValue = &Aggregator{}
 // An unset lastValue has zero timestamp and zero value.
 var unsetLastValue = &lastValueData{}
 
+// WithClock sets the function used to timestamp the values recorded by the
+// aggregator. If unset, time.Now is used.
+//
+// This is useful to replay data or to produce deterministic timestamps in
+// tests.
+func WithClock(now func() time.Time) Option {
+	return clockOption{now}
+}
+
+type clockOption struct {
+	now func() time.Time
+}
+
+func (o clockOption) apply(config *config) {
+	config.now = o.now
+}
+
 // New returns a new lastValue aggregator.  This aggregator retains the
 // last value and timestamp that were recorded.
-func New(cnt int) []Aggregator {
+func New(cnt int, opts ...Option) []Aggregator {
+	cfg := config{now: time.Now}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
 	aggs := make([]Aggregator, cnt)
 	for i := range aggs {
 		aggs[i] = Aggregator{
 			value: unsafe.Pointer(unsetLastValue),
+			now:   cfg.now,
 		}
 	}
 	return aggs
@@ -107,7 +145,7 @@ func (g *Aggregator) SynchronizedMove(oa aggregator.Aggregator, _ *sdkapi.Descri
 func (g *Aggregator) Update(_ context.Context, n number.Number, desc *sdkapi.Descriptor) error {
 	ngd := &lastValueData{
 		value:     n,
-		timestamp: time.Now(),
+		timestamp: g.now(),
 	}
 	atomic.StorePointer(&g.value, unsafe.Pointer(ngd))
 	return nil
@@ -56,11 +56,21 @@ func new2() (_, _ *Aggregator) {
 	return &alloc[0], &alloc[1]
 }
 
-func new4() (_, _, _, _ *Aggregator) {
-	alloc := New(4)
+func new4(opts ...Option) (_, _, _, _ *Aggregator) {
+	alloc := New(4, opts...)
 	return &alloc[0], &alloc[1], &alloc[2], &alloc[3]
 }
 
+// tickingClock returns a clock that advances one second each time it is
+// read, starting from start.
+func tickingClock(start time.Time) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
 func checkZero(t *testing.T, agg *Aggregator) {
 	lv, ts, err := agg.LastValue()
 	require.True(t, errors.Is(err, aggregation.ErrNoData))
@@ -92,7 +102,8 @@ func TestLastValueUpdate(t *testing.T) {
 
 func TestLastValueMerge(t *testing.T) {
 	aggregatortest.RunProfiles(t, func(t *testing.T, profile aggregatortest.Profile) {
-		agg1, agg2, ckpt1, ckpt2 := new4()
+		start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+		agg1, agg2, ckpt1, ckpt2 := new4(WithClock(tickingClock(start)))
 
 		descriptor := aggregatortest.NewAggregatorTest(sdkapi.GaugeObserverInstrumentKind, profile.NumberKind)
 
@@ -101,8 +112,6 @@ func TestLastValueMerge(t *testing.T) {
 		first1.AddNumber(profile.NumberKind, first2)
 
 		aggregatortest.CheckedUpdate(t, agg1, first1, descriptor)
-		// Ensure these should not have the same timestamp.
-		time.Sleep(time.Nanosecond)
 		aggregatortest.CheckedUpdate(t, agg2, first2, descriptor)
 
 		require.NoError(t, agg1.SynchronizedMove(ckpt1, descriptor))
@@ -115,7 +124,8 @@ func TestLastValueMerge(t *testing.T) {
 		require.Nil(t, err)
 		_, t2, err := ckpt2.LastValue()
 		require.Nil(t, err)
-		require.True(t, t1.Before(t2))
+		require.Equal(t, start.Add(time.Second), t1)
+		require.Equal(t, start.Add(2*time.Second), t2)
 
 		aggregatortest.CheckedMerge(t, ckpt1, ckpt2, descriptor)
 
@@ -126,6 +136,21 @@ func TestLastValueMerge(t *testing.T) {
 	})
 }
 
+func TestLastValueDefaultClock(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(sdkapi.GaugeObserverInstrumentKind, number.Int64Kind)
+
+	agg, ckpt := new2()
+	before := time.Now()
+	aggregatortest.CheckedUpdate(t, agg, number.NewInt64Number(1), descriptor)
+	after := time.Now()
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	_, ts, err := ckpt.LastValue()
+	require.NoError(t, err)
+	require.False(t, ts.Before(before))
+	require.False(t, ts.After(after))
+}
+
 func TestLastValueNotSet(t *testing.T) {
 	descriptor := aggregatortest.NewAggregatorTest(sdkapi.GaugeObserverInstrumentKind, number.Int64Kind)
 
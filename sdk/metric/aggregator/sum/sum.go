@@ -16,7 +16,11 @@ package sum // import "go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/number"
@@ -28,6 +32,86 @@ type Aggregator struct {
 	// current holds current increments to this counter record
 	// current needs to be aligned for 64-bit atomic operations.
 	value number.Number
+
+	// overflow is how a sum exceeding its number kind is handled.
+	overflow overflowPolicy
+
+	// rejectNonFinite is whether NaN and infinite float64 updates are
+	// rejected for all instruments, not only monotonic ones.
+	rejectNonFinite bool
+
+	// overflowReported is set to 1, atomically, once the overflow of an
+	// update is reported. It is reset by SynchronizedMove so an overflowing
+	// sum is reported at most once per collection.
+	overflowReported uint32
+}
+
+// overflowPolicy determines how overflow of a sum is handled.
+type overflowPolicy uint8
+
+const (
+	// overflowIgnore wraps int64 sums and saturates float64 sums at
+	// infinity without reporting it.
+	overflowIgnore overflowPolicy = iota
+	// overflowReport reports overflow and otherwise handles it the same
+	// as overflowIgnore.
+	overflowReport
+	// overflowClamp reports overflow and clamps the sum at the limit of
+	// its number kind.
+	overflowClamp
+)
+
+// config describes how sums are aggregated.
+type config struct {
+	overflow        overflowPolicy
+	rejectNonFinite bool
+}
+
+// Option configures a sum config.
+type Option interface {
+	// apply sets one or more config fields.
+	apply(*config)
+}
+
+type overflowOption overflowPolicy
+
+func (o overflowOption) apply(config *config) {
+	config.overflow = overflowPolicy(o)
+}
+
+// WithOverflowDetection configures the aggregator to report a sum exceeding
+// the range of its number kind to the global ErrorHandler. An int64 sum still
+// wraps around and a float64 sum still becomes infinite. The overflow of
+// updates is reported at most once per collection.
+//
+// Detecting overflow makes updates more expensive, it is not done unless
+// this option or WithOverflowClamp is used.
+func WithOverflowDetection() Option {
+	return overflowOption(overflowReport)
+}
+
+// WithOverflowClamp configures the aggregator to report a sum exceeding the
+// range of its number kind to the global ErrorHandler and to clamp the sum
+// at the maximum (or minimum) value of its number kind instead. A clamped sum
+// overflows again with every update, it is reported at most once per
+// collection.
+func WithOverflowClamp() Option {
+	return overflowOption(overflowClamp)
+}
+
+type nonFiniteOption bool
+
+func (o nonFiniteOption) apply(config *config) {
+	config.rejectNonFinite = bool(o)
+}
+
+// WithNonFiniteRejection configures the aggregator to reject NaN and
+// infinite float64 updates of non-monotonic instruments. These updates are
+// always rejected for monotonic instruments, this option extends that to
+// UpDownCounters and their asynchronous counterparts. A rejected update is
+// not added to the sum and an error is returned to the caller.
+func WithNonFiniteRejection() Option {
+	return nonFiniteOption(true)
 }
 
 var _ aggregator.Aggregator = &Aggregator{}
@@ -36,8 +120,20 @@ var _ aggregation.Sum = &Aggregator{}
 // New returns a new counter aggregator implemented by atomic
 // operations.  This aggregator implements the aggregation.Sum
 // export interface.
-func New(cnt int) []Aggregator {
-	return make([]Aggregator, cnt)
+func New(cnt int, opts ...Option) []Aggregator {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	aggs := make([]Aggregator, cnt)
+	if cfg != (config{}) {
+		for i := range aggs {
+			aggs[i].overflow = cfg.overflow
+			aggs[i].rejectNonFinite = cfg.rejectNonFinite
+		}
+	}
+	return aggs
 }
 
 // Aggregation returns an interface for reading the state of this aggregator.
@@ -61,6 +157,7 @@ func (c *Aggregator) Sum() (number.Number, error) {
 func (c *Aggregator) SynchronizedMove(oa aggregator.Aggregator, _ *sdkapi.Descriptor) error {
 	if oa == nil {
 		c.value.SetRawAtomic(0)
+		atomic.StoreUint32(&c.overflowReported, 0)
 		return nil
 	}
 	o, _ := oa.(*Aggregator)
@@ -68,13 +165,40 @@ func (c *Aggregator) SynchronizedMove(oa aggregator.Aggregator, _ *sdkapi.Descri
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	o.value = c.value.SwapNumberAtomic(number.Number(0))
+	atomic.StoreUint32(&c.overflowReported, 0)
 	return nil
 }
 
 // Update atomically adds to the current value.
+//
+// If the aggregator detects overflow, it is reported to the global
+// ErrorHandler, once until the next SynchronizedMove. The update is still
+// applied, an error is not returned.
+//
+// A NaN or infinite float64 update of a monotonic instrument, or of any
+// instrument if the aggregator is configured WithNonFiniteRejection, is not
+// applied and aggregation.ErrNaNInput or aggregation.ErrInfInput is returned
+// instead. Once added, such a value would otherwise poison the sum until it
+// is reset.
 func (c *Aggregator) Update(_ context.Context, num number.Number, desc *sdkapi.Descriptor) error {
-	c.value.AddNumberAtomic(desc.NumberKind(), num)
-	return nil
+	if err := c.checkFinite(num, desc); err != nil {
+		return err
+	}
+	if c.overflow == overflowIgnore {
+		c.value.AddNumberAtomic(desc.NumberKind(), num)
+		return nil
+	}
+
+	for {
+		old := c.value.AsNumberAtomic()
+		sum, overflow := c.add(desc.NumberKind(), old, num)
+		if c.value.CompareAndSwapNumber(old, sum) {
+			if overflow && atomic.CompareAndSwapUint32(&c.overflowReported, 0, 1) {
+				otel.Handle(overflowError(desc))
+			}
+			return nil
+		}
+	}
 }
 
 // Merge combines two counters by adding their sums.
@@ -83,6 +207,69 @@ func (c *Aggregator) Merge(oa aggregator.Aggregator, desc *sdkapi.Descriptor) er
 	if o == nil {
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
-	c.value.AddNumber(desc.NumberKind(), o.value)
+	if c.overflow == overflowIgnore {
+		c.value.AddNumber(desc.NumberKind(), o.value)
+		return nil
+	}
+
+	sum, overflow := c.add(desc.NumberKind(), c.value, o.value)
+	c.value = sum
+	if overflow {
+		otel.Handle(overflowError(desc))
+	}
 	return nil
 }
+
+// add returns the sum of a and b and if it overflows their number kind.
+// The sum is clamped if c is configured to do so.
+func (c *Aggregator) add(kind number.Kind, a, b number.Number) (number.Number, bool) {
+	switch kind {
+	case number.Int64Kind:
+		x, y := a.AsInt64(), b.AsInt64()
+		sum := x + y
+		if (y > 0 && sum < x) || (y < 0 && sum > x) {
+			if c.overflow == overflowClamp {
+				if y > 0 {
+					return number.NewInt64Number(math.MaxInt64), true
+				}
+				return number.NewInt64Number(math.MinInt64), true
+			}
+			return number.NewInt64Number(sum), true
+		}
+		return number.NewInt64Number(sum), false
+	case number.Float64Kind:
+		x, y := a.AsFloat64(), b.AsFloat64()
+		sum := x + y
+		if math.IsInf(sum, 0) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+			if c.overflow == overflowClamp {
+				return number.NewFloat64Number(math.Copysign(math.MaxFloat64, sum)), true
+			}
+			return number.NewFloat64Number(sum), true
+		}
+		return number.NewFloat64Number(sum), false
+	}
+	a.AddNumber(kind, b)
+	return a, false
+}
+
+// checkFinite returns an error if num is a float64 that is NaN or infinite
+// and c rejects these values for desc.
+func (c *Aggregator) checkFinite(num number.Number, desc *sdkapi.Descriptor) error {
+	if desc.NumberKind() != number.Float64Kind {
+		return nil
+	}
+	if !c.rejectNonFinite && !desc.InstrumentKind().Monotonic() {
+		return nil
+	}
+	switch f := num.AsFloat64(); {
+	case math.IsNaN(f):
+		return aggregation.ErrNaNInput
+	case math.IsInf(f, 0):
+		return aggregation.ErrInfInput
+	}
+	return nil
+}
+
+func overflowError(desc *sdkapi.Descriptor) error {
+	return fmt.Errorf("%w: %s", aggregation.ErrOverflow, desc.Name())
+}
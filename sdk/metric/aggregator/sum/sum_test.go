@@ -15,15 +15,19 @@
 package sum
 
 import (
+	"context"
+	"math"
 	"os"
 	"testing"
 	"unsafe"
 
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/number"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
 )
@@ -152,3 +156,209 @@ func TestSynchronizedMoveReset(t *testing.T) {
 		},
 	)
 }
+
+func TestOverflow(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	ctx := context.Background()
+	desc := aggregatortest.NewAggregatorTest(sdkapi.CounterInstrumentKind, number.Int64Kind)
+	fdesc := aggregatortest.NewAggregatorTest(sdkapi.CounterInstrumentKind, number.Float64Kind)
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		desc    *sdkapi.Descriptor
+		start   number.Number
+		add     number.Number
+		want    number.Number
+		wantErr bool
+	}{
+		{
+			name:  "int64 ignored",
+			desc:  desc,
+			start: number.NewInt64Number(math.MaxInt64 - 1),
+			add:   number.NewInt64Number(2),
+			want:  number.NewInt64Number(math.MinInt64),
+		},
+		{
+			name:    "int64 detected",
+			opts:    []Option{WithOverflowDetection()},
+			desc:    desc,
+			start:   number.NewInt64Number(math.MaxInt64 - 1),
+			add:     number.NewInt64Number(2),
+			want:    number.NewInt64Number(math.MinInt64),
+			wantErr: true,
+		},
+		{
+			name:    "int64 clamped",
+			opts:    []Option{WithOverflowClamp()},
+			desc:    desc,
+			start:   number.NewInt64Number(math.MaxInt64 - 1),
+			add:     number.NewInt64Number(2),
+			want:    number.NewInt64Number(math.MaxInt64),
+			wantErr: true,
+		},
+		{
+			name:  "int64 at max",
+			opts:  []Option{WithOverflowClamp()},
+			desc:  desc,
+			start: number.NewInt64Number(math.MaxInt64 - 1),
+			add:   number.NewInt64Number(1),
+			want:  number.NewInt64Number(math.MaxInt64),
+		},
+		{
+			name:    "int64 clamped negative",
+			opts:    []Option{WithOverflowClamp()},
+			desc:    desc,
+			start:   number.NewInt64Number(math.MinInt64 + 1),
+			add:     number.NewInt64Number(-2),
+			want:    number.NewInt64Number(math.MinInt64),
+			wantErr: true,
+		},
+		{
+			name:    "float64 detected",
+			opts:    []Option{WithOverflowDetection()},
+			desc:    fdesc,
+			start:   number.NewFloat64Number(math.MaxFloat64),
+			add:     number.NewFloat64Number(math.MaxFloat64),
+			want:    number.NewFloat64Number(math.Inf(1)),
+			wantErr: true,
+		},
+		{
+			name:    "float64 clamped",
+			opts:    []Option{WithOverflowClamp()},
+			desc:    fdesc,
+			start:   number.NewFloat64Number(math.MaxFloat64),
+			add:     number.NewFloat64Number(math.MaxFloat64),
+			want:    number.NewFloat64Number(math.MaxFloat64),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs = nil
+			aggs := New(2, tc.opts...)
+			agg, other := &aggs[0], &aggs[1]
+
+			// Update.
+			require.NoError(t, agg.Update(ctx, tc.start, tc.desc))
+			require.NoError(t, agg.Update(ctx, tc.add, tc.desc))
+			sum, err := agg.Sum()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, sum)
+
+			// Merge.
+			agg.value = tc.start
+			require.NoError(t, other.Update(ctx, tc.add, tc.desc))
+			require.NoError(t, agg.Merge(other, tc.desc))
+			sum, err = agg.Sum()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, sum)
+
+			if !tc.wantErr {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 2)
+			for _, err := range errs {
+				require.ErrorIs(t, err, aggregation.ErrOverflow)
+			}
+		})
+	}
+}
+
+func TestOverflowReportedOnce(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(ottest.ErrorLogger{}) })
+
+	ctx := context.Background()
+	desc := aggregatortest.NewAggregatorTest(sdkapi.CounterInstrumentKind, number.Int64Kind)
+	aggs := New(2, WithOverflowClamp())
+	agg, ckpt := &aggs[0], &aggs[1]
+
+	require.NoError(t, agg.Update(ctx, number.NewInt64Number(math.MaxInt64), desc))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, agg.Update(ctx, number.NewInt64Number(1), desc))
+	}
+	require.Len(t, errs, 1, "saturated sum reported more than once")
+
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	require.NoError(t, agg.Update(ctx, number.NewInt64Number(math.MaxInt64), desc))
+	require.NoError(t, agg.Update(ctx, number.NewInt64Number(1), desc))
+	require.Len(t, errs, 2, "overflow not reported after collection")
+	for _, err := range errs {
+		require.ErrorIs(t, err, aggregation.ErrOverflow)
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	ctx := context.Background()
+	counter := aggregatortest.NewAggregatorTest(sdkapi.CounterInstrumentKind, number.Float64Kind)
+	upDown := aggregatortest.NewAggregatorTest(sdkapi.UpDownCounterInstrumentKind, number.Float64Kind)
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		desc    *sdkapi.Descriptor
+		add     float64
+		want    number.Number
+		wantErr error
+	}{
+		{
+			name:    "monotonic NaN",
+			desc:    counter,
+			add:     math.NaN(),
+			want:    number.NewFloat64Number(1),
+			wantErr: aggregation.ErrNaNInput,
+		},
+		{
+			name:    "monotonic Inf",
+			desc:    counter,
+			add:     math.Inf(1),
+			want:    number.NewFloat64Number(1),
+			wantErr: aggregation.ErrInfInput,
+		},
+		{
+			name: "non-monotonic Inf accepted",
+			desc: upDown,
+			add:  math.Inf(-1),
+			want: number.NewFloat64Number(math.Inf(-1)),
+		},
+		{
+			name:    "non-monotonic NaN rejected",
+			opts:    []Option{WithNonFiniteRejection()},
+			desc:    upDown,
+			add:     math.NaN(),
+			want:    number.NewFloat64Number(1),
+			wantErr: aggregation.ErrNaNInput,
+		},
+		{
+			name:    "non-monotonic Inf rejected",
+			opts:    []Option{WithNonFiniteRejection()},
+			desc:    upDown,
+			add:     math.Inf(-1),
+			want:    number.NewFloat64Number(1),
+			wantErr: aggregation.ErrInfInput,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			agg := &New(1, tc.opts...)[0]
+			require.NoError(t, agg.Update(ctx, number.NewFloat64Number(1), tc.desc))
+
+			err := agg.Update(ctx, number.NewFloat64Number(tc.add), tc.desc)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+
+			sum, err := agg.Sum()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, sum)
+		})
+	}
+}
@@ -27,6 +27,7 @@ func TestMain(m *testing.M) {
 	offsets := map[string]uintptr{
 		"record.refMapped.value": unsafe.Offsetof(record{}.refMapped.value),
 		"record.updateCount":     unsafe.Offsetof(record{}.updateCount),
+		"baseInstrument.dropped": unsafe.Offsetof(baseInstrument{}.dropped),
 	}
 	var r []ottest.FieldOffset
 	for name, offset := range offsets {
@@ -157,6 +157,39 @@ func BenchmarkInt64CounterAddWithAttrs_16(b *testing.B) {
 	benchmarkAttrs(b, 16)
 }
 
+func benchmarkAttrsSet(b *testing.B, n int) {
+	ctx := context.Background()
+	fix := newFixture(b)
+	set := attribute.NewSet(makeAttrs(n)...)
+	cnt := fix.iCounter("int64.sum")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		syncint64.AddSet(ctx, cnt, 1, &set)
+	}
+}
+
+func BenchmarkInt64CounterAddWithSet_1(b *testing.B) {
+	benchmarkAttrsSet(b, 1)
+}
+
+func BenchmarkInt64CounterAddWithSet_2(b *testing.B) {
+	benchmarkAttrsSet(b, 2)
+}
+
+func BenchmarkInt64CounterAddWithSet_4(b *testing.B) {
+	benchmarkAttrsSet(b, 4)
+}
+
+func BenchmarkInt64CounterAddWithSet_8(b *testing.B) {
+	benchmarkAttrsSet(b, 8)
+}
+
+func BenchmarkInt64CounterAddWithSet_16(b *testing.B) {
+	benchmarkAttrsSet(b, 16)
+}
+
 // Note: performance does not depend on attribute set size for the benchmarks
 // below--all are benchmarked for a single attribute.
 
@@ -15,9 +15,12 @@
 package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 
 import (
+	"context"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
@@ -57,6 +60,23 @@ type config struct {
 	//
 	// Default value is 10s.  If zero, no Export timeout is applied.
 	PushTimeout time.Duration
+
+	// MaxExportRecords is the maximum number of records passed to the
+	// exporter in one export.
+	//
+	// Default value is 0.  If zero, the number of records is not limited.
+	MaxExportRecords int
+
+	// ContextAttributes returns the attributes added to every
+	// synchronous measurement from its context.
+	//
+	// Default value is nil.  If nil, no attributes are added.
+	ContextAttributes func(context.Context) []attribute.KeyValue
+
+	// InternalMeter is the Meter the Controller records its exports to.
+	//
+	// Default value is nil.  If nil, the exports are not recorded.
+	InternalMeter metric.Meter
 }
 
 // Option is the interface that applies the value to a configuration option.
@@ -129,3 +149,59 @@ func (o pushTimeoutOption) apply(cfg config) config {
 	cfg.PushTimeout = time.Duration(o)
 	return cfg
 }
+
+// WithMaxExportRecords sets the MaxExportRecords configuration option of a
+// Config.
+//
+// When a collection produces more than n records, only the first n records
+// ordered by instrumentation library, instrument name, and attributes are
+// exported. The number of records dropped is reported to the global error
+// handler as an ErrMaxExportRecords error.
+func WithMaxExportRecords(n int) Option {
+	return maxExportRecordsOption(n)
+}
+
+type maxExportRecordsOption int
+
+func (o maxExportRecordsOption) apply(cfg config) config {
+	cfg.MaxExportRecords = int(o)
+	return cfg
+}
+
+// WithContextAttributes sets the ContextAttributes configuration option of a
+// Config.
+//
+// The attributes returned by f for the context of every synchronous
+// measurement made with the Meters of the Controller are added to the
+// attributes of the measurement, e.g. to add a tenant ID stored in the
+// context to all measurements. When an attribute returned by f has the same
+// key as an attribute passed with the measurement, the passed attribute
+// takes precedence.
+func WithContextAttributes(f func(context.Context) []attribute.KeyValue) Option {
+	return contextAttributesOption(f)
+}
+
+type contextAttributesOption func(context.Context) []attribute.KeyValue
+
+func (o contextAttributesOption) apply(cfg config) config {
+	cfg.ContextAttributes = o
+	return cfg
+}
+
+// WithInternalMetrics sets the InternalMeter configuration option of a
+// Config.
+//
+// The number of records read by every export is added to the
+// RecordsExportedName counter of meter if the export succeeds, and to the
+// RecordsFailedName counter otherwise. This allows the telemetry pipeline to
+// be monitored with the same tooling as the application.
+func WithInternalMetrics(meter metric.Meter) Option {
+	return internalMetricsOption{meter}
+}
+
+type internalMetricsOption struct{ metric.Meter }
+
+func (o internalMetricsOption) apply(cfg config) config {
+	cfg.InternalMeter = o.Meter
+	return cfg
+}
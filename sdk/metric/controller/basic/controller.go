@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -42,6 +44,10 @@ const DefaultPeriod = 10 * time.Second
 // than once.
 var ErrControllerStarted = fmt.Errorf("controller already started")
 
+// ErrMaxExportRecords indicates that records were dropped from an export
+// because the collection produced more records than the configured maximum.
+var ErrMaxExportRecords = fmt.Errorf("maximum export records exceeded")
+
 // Controller organizes and synchronizes collection of metric data in
 // both "pull" and "push" configurations.  This supports two distinct
 // modes:
@@ -57,8 +63,16 @@ var ErrControllerStarted = fmt.Errorf("controller already started")
 // using the export.Reader RWLock interface.  Collection will
 // be blocked by a pull request in the basic controller.
 type Controller struct {
+	// skippedTicks is the number of ticks skipped because a
+	// collection was still running. Accessed atomically, it is
+	// first to be 64-bit aligned.
+	skippedTicks int64
+
 	// lock synchronizes Start() and Stop().
-	lock                sync.Mutex
+	lock sync.Mutex
+	// collectLock serializes the collections and exports of the
+	// background goroutine, Stop() and ForceFlush().
+	collectLock         sync.Mutex
 	scopes              sync.Map
 	checkpointerFactory export.CheckpointerFactory
 
@@ -69,9 +83,13 @@ type Controller struct {
 	clock    controllerTime.Clock
 	ticker   controllerTime.Ticker
 
-	collectPeriod  time.Duration
-	collectTimeout time.Duration
-	pushTimeout    time.Duration
+	collectPeriod    time.Duration
+	collectTimeout   time.Duration
+	pushTimeout      time.Duration
+	maxExportRecords int
+
+	contextAttributes func(context.Context) []attribute.KeyValue
+	internalMetrics   *internalMetrics
 
 	// collectedTime is used only in configurations with no
 	// exporter, when ticker != nil.
@@ -97,7 +115,7 @@ func (c *Controller) Meter(instrumentationName string, opts ...metric.MeterOptio
 		m, _ = c.scopes.LoadOrStore(
 			scope,
 			registry.NewUniqueInstrumentMeterImpl(&accumulatorCheckpointer{
-				Accumulator:  sdk.NewAccumulator(checkpointer),
+				Accumulator:  c.newAccumulator(checkpointer),
 				checkpointer: checkpointer,
 				scope:        scope,
 			}))
@@ -105,6 +123,16 @@ func (c *Controller) Meter(instrumentationName string, opts ...metric.MeterOptio
 	return sdkapi.WrapMeterImpl(m.(*registry.UniqueInstrumentMeterImpl))
 }
 
+// newAccumulator returns a new Accumulator for the checkpointer configured
+// with the options of c.
+func (c *Controller) newAccumulator(checkpointer export.Checkpointer) *sdk.Accumulator {
+	var opts []sdk.AccumulatorOption
+	if c.contextAttributes != nil {
+		opts = append(opts, sdk.WithContextAttributes(c.contextAttributes))
+	}
+	return sdk.NewAccumulator(checkpointer, opts...)
+}
+
 type accumulatorCheckpointer struct {
 	*sdk.Accumulator
 	checkpointer export.Checkpointer
@@ -141,9 +169,13 @@ func New(checkpointerFactory export.CheckpointerFactory, opts ...Option) *Contro
 		stopCh:              nil,
 		clock:               controllerTime.RealClock{},
 
-		collectPeriod:  c.CollectPeriod,
-		collectTimeout: c.CollectTimeout,
-		pushTimeout:    c.PushTimeout,
+		collectPeriod:    c.CollectPeriod,
+		collectTimeout:   c.CollectTimeout,
+		pushTimeout:      c.PushTimeout,
+		maxExportRecords: c.MaxExportRecords,
+
+		contextAttributes: c.ContextAttributes,
+		internalMetrics:   newInternalMetrics(c.InternalMeter),
 	}
 }
 
@@ -223,16 +255,53 @@ func (c *Controller) runTicker(ctx context.Context, stopCh chan struct{}) {
 		case <-stopCh:
 			return
 		case <-c.ticker.C():
+			start := c.clock.Now()
 			if err := c.collect(ctx); err != nil {
 				otel.Handle(err)
 			}
+			c.skipTicks(c.clock.Now().Sub(start))
 		}
 	}
 }
 
+// skipTicks coalesces the ticks that occurred during a collection that took
+// elapsed, so the next collection waits for the next tick instead of
+// starting immediately.
+func (c *Controller) skipTicks(elapsed time.Duration) {
+	skipped := int64(elapsed / c.collectPeriod)
+	if skipped <= 0 {
+		return
+	}
+	select {
+	case <-c.ticker.C():
+	default:
+	}
+	atomic.AddInt64(&c.skippedTicks, skipped)
+}
+
+// SkippedTicks returns the number of collection periods skipped by the
+// controller because a collection and export was still running when they
+// started. A growing value means exporting takes longer than the collection
+// period.
+func (c *Controller) SkippedTicks() int {
+	return int(atomic.LoadInt64(&c.skippedTicks))
+}
+
+// ForceFlush collects and exports metrics immediately, regardless of the
+// collection period and of whether the controller was started. The passed
+// context is passed to Collect() and subsequently to asynchronous
+// instrument callbacks. When no exporter is configured, the collected
+// metrics remain available through ForEach().
+func (c *Controller) ForceFlush(ctx context.Context) error {
+	return c.collect(ctx)
+}
+
 // collect computes a checkpoint and optionally exports it.
 func (c *Controller) collect(ctx context.Context) error {
-	if err := c.checkpoint(ctx); err != nil {
+	c.collectLock.Lock()
+	defer c.collectLock.Unlock()
+
+	if err := c.checkpoint(ctx, false); err != nil {
 		return err
 	}
 	if c.exporter == nil {
@@ -261,10 +330,12 @@ func (c *Controller) accumulatorList() []*accumulatorCheckpointer {
 // checkpoint calls the Accumulator and Checkpointer interfaces to
 // compute the Reader.  This applies the configured collection
 // timeout.  Note that this does not try to cancel a Collect or Export
-// when Stop() is called.
-func (c *Controller) checkpoint(ctx context.Context) error {
+// when Stop() is called. If partial is true, the records of synchronous
+// instruments not collected when ctx is done are left for the next
+// collection.
+func (c *Controller) checkpoint(ctx context.Context, partial bool) error {
 	for _, impl := range c.accumulatorList() {
-		if err := c.checkpointSingleAccumulator(ctx, impl); err != nil {
+		if err := c.checkpointSingleAccumulator(ctx, impl, partial); err != nil {
 			return err
 		}
 	}
@@ -275,7 +346,7 @@ func (c *Controller) checkpoint(ctx context.Context) error {
 // scope's accumulator, which involves calling
 // checkpointer.StartCollection, accumulator.Collect, and
 // checkpointer.FinishCollection in sequence.
-func (c *Controller) checkpointSingleAccumulator(ctx context.Context, ac *accumulatorCheckpointer) error {
+func (c *Controller) checkpointSingleAccumulator(ctx context.Context, ac *accumulatorCheckpointer, partial bool) error {
 	ckpt := ac.checkpointer.Reader()
 	ckpt.Lock()
 	defer ckpt.Unlock()
@@ -288,7 +359,11 @@ func (c *Controller) checkpointSingleAccumulator(ctx context.Context, ac *accumu
 		defer cancel()
 	}
 
-	_ = ac.Accumulator.Collect(ctx)
+	if partial {
+		_ = ac.Accumulator.CollectPartial(ctx)
+	} else {
+		_ = ac.Accumulator.Collect(ctx)
+	}
 
 	var err error
 	select {
@@ -311,7 +386,7 @@ func (c *Controller) checkpointSingleAccumulator(ctx context.Context, ac *accumu
 }
 
 // export calls the exporter with a read lock on the Reader,
-// applying the configured export timeout.
+// applying the configured export timeout and maximum number of records.
 func (c *Controller) export(ctx context.Context) error { // nolint:revive  // method name shadows import.
 	if c.pushTimeout > 0 {
 		var cancel context.CancelFunc
@@ -319,7 +394,24 @@ func (c *Controller) export(ctx context.Context) error { // nolint:revive  // me
 		defer cancel()
 	}
 
-	return c.exporter.Export(ctx, c.resource, c)
+	var reader export.InstrumentationLibraryReader = c
+	if c.maxExportRecords > 0 {
+		limited, dropped, err := newLimitedReader(c, c.exporter, c.maxExportRecords)
+		if err != nil {
+			return err
+		}
+		if dropped > 0 {
+			otel.Handle(fmt.Errorf("%w: %d records dropped", ErrMaxExportRecords, dropped))
+		}
+		reader = limited
+	}
+
+	if c.internalMetrics != nil {
+		return c.internalMetrics.export(ctx, reader, func(r export.InstrumentationLibraryReader) error {
+			return c.exporter.Export(ctx, c.resource, r)
+		})
+	}
+	return c.exporter.Export(ctx, c.resource, reader)
 }
 
 // ForEach implements export.InstrumentationLibraryReader.
@@ -351,6 +443,23 @@ func (c *Controller) IsRunning() bool {
 // the last collection is aged less than the configured collection
 // period.
 func (c *Controller) Collect(ctx context.Context) error {
+	return c.pull(ctx, false)
+}
+
+// CollectWithTimeout is like Collect, except the collection is bounded
+// to the duration d. If d elapses before the collection completes, what
+// was collected so far remains available through ForEach and an error
+// wrapping context.DeadlineExceeded is returned. Records not collected
+// in time are included in the next collection.
+func (c *Controller) CollectWithTimeout(ctx context.Context, d time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	return c.pull(ctx, true)
+}
+
+// pull computes a checkpoint unless the controller is running or the last
+// collection is aged less than the collection period.
+func (c *Controller) pull(ctx context.Context, partial bool) error {
 	if c.IsRunning() {
 		// When there's a non-nil ticker, there's a goroutine
 		// computing checkpoints with the collection period.
@@ -360,7 +469,7 @@ func (c *Controller) Collect(ctx context.Context) error {
 		return nil
 	}
 
-	return c.checkpoint(ctx)
+	return c.checkpoint(ctx, partial)
 }
 
 // shouldCollect returns true if the collector should collect now,
@@ -25,7 +25,9 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
@@ -491,3 +493,152 @@ func TestRegistryFunction(t *testing.T) {
 		"counter.sum//": 20,
 	}, exp.Values())
 }
+
+func TestForceFlush(t *testing.T) {
+	exp := processortest.New(
+		aggregation.CumulativeTemporalitySelector(),
+		attribute.DefaultEncoder(),
+	)
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			exp,
+		),
+		controller.WithCollectPeriod(time.Second),
+		controller.WithExporter(exp),
+		controller.WithResource(resource.Empty()),
+	)
+	mock := controllertest.NewMockClock()
+	cont.SetClock(mock)
+
+	ctx := context.Background()
+	counter, err := cont.Meter("test").SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+
+	// Flushing does not need the controller to be started.
+	counter.Add(ctx, 1)
+	require.NoError(t, cont.ForceFlush(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//": 1,
+	}, exp.Values())
+
+	// Nor does it wait for the collection period, which the mock clock
+	// never reaches.
+	exp.Reset()
+	require.NoError(t, cont.Start(ctx))
+	counter.Add(ctx, 2)
+	require.NoError(t, cont.ForceFlush(ctx))
+	require.Equal(t, 1, exp.ExportCount())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//": 3,
+	}, exp.Values())
+	require.NoError(t, cont.Stop(ctx))
+}
+
+func TestForceFlushMetrics(t *testing.T) {
+	exp := processortest.New(
+		aggregation.CumulativeTemporalitySelector(),
+		attribute.DefaultEncoder(),
+	)
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			exp,
+		),
+		controller.WithExporter(exp),
+		controller.WithResource(resource.Empty()),
+	)
+
+	prev := global.MeterProvider()
+	global.SetMeterProvider(cont)
+	t.Cleanup(func() { global.SetMeterProvider(prev) })
+
+	ctx := context.Background()
+	counter, err := global.Meter("test").SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+
+	require.NoError(t, global.ForceFlushMetrics(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//": 1,
+	}, exp.Values())
+}
+
+type tenantContextKey struct{}
+
+func TestContextAttributes(t *testing.T) {
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithContextAttributes(func(ctx context.Context) []attribute.KeyValue {
+			if tenant, ok := ctx.Value(tenantContextKey{}).(string); ok {
+				return []attribute.KeyValue{attribute.String("tenant", tenant)}
+			}
+			return nil
+		}),
+	)
+	meter := cont.Meter("go.opentelemetry.io/otel/sdk/metric/controller/basic_test#ContextAttributes")
+
+	counter, err := meter.SyncInt64().Counter("requests.sum")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	counter.Add(context.WithValue(ctx, tenantContextKey{}, "a"), 1, attribute.String("method", "GET"))
+	counter.Add(context.WithValue(ctx, tenantContextKey{}, "a"), 2, attribute.String("method", "GET"))
+	counter.Add(context.WithValue(ctx, tenantContextKey{}, "b"), 4, attribute.String("method", "GET"))
+	// Explicitly passed attributes take precedence.
+	counter.Add(context.WithValue(ctx, tenantContextKey{}, "b"), 8, attribute.String("tenant", "c"))
+	// No attributes are added without a tenant in the context.
+	counter.Add(ctx, 16)
+
+	require.NoError(t, cont.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"requests.sum/method=GET,tenant=a/": 3,
+		"requests.sum/method=GET,tenant=b/": 4,
+		"requests.sum/tenant=c/":            8,
+		"requests.sum//":                    16,
+	}, getMap(t, cont))
+}
+
+func TestAddSetContextAttributes(t *testing.T) {
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithContextAttributes(func(ctx context.Context) []attribute.KeyValue {
+			if tenant, ok := ctx.Value(tenantContextKey{}).(string); ok {
+				return []attribute.KeyValue{attribute.String("tenant", tenant)}
+			}
+			return nil
+		}),
+	)
+	meter := cont.Meter("go.opentelemetry.io/otel/sdk/metric/controller/basic_test#AddSetContextAttributes")
+
+	counter, err := meter.SyncInt64().Counter("requests.sum")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	get := attribute.NewSet(attribute.String("method", "GET"))
+	// Attributes extracted from the context are added to the set.
+	syncint64.AddSet(context.WithValue(ctx, tenantContextKey{}, "a"), counter, 1, &get)
+	counter.Add(context.WithValue(ctx, tenantContextKey{}, "a"), 2, attribute.String("method", "GET"))
+	// Attributes of the set take precedence.
+	explicit := attribute.NewSet(attribute.String("tenant", "c"))
+	syncint64.AddSet(context.WithValue(ctx, tenantContextKey{}, "b"), counter, 4, &explicit)
+	// The set is used as is without a tenant in the context.
+	syncint64.AddSet(ctx, counter, 8, &get)
+
+	require.NoError(t, cont.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"requests.sum/method=GET,tenant=a/": 3,
+		"requests.sum/tenant=c/":            4,
+		"requests.sum/method=GET/":          8,
+	}, getMap(t, cont))
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+)
+
+// Names of the counters the Controller records its exports to when the
+// WithInternalMetrics option is used.
+const (
+	// RecordsExportedName is the name of the counter of records
+	// successfully exported.
+	RecordsExportedName = "otel.sdk.metric.records.exported"
+	// RecordsFailedName is the name of the counter of records read by
+	// exports that returned an error.
+	RecordsFailedName = "otel.sdk.metric.records.failed"
+)
+
+// internalMetrics are the counters a Controller records its exports to.
+type internalMetrics struct {
+	exported syncint64.Counter
+	failed   syncint64.Counter
+}
+
+// newInternalMetrics returns the internalMetrics created with meter, or nil
+// if meter is nil or the counters cannot be created.
+func newInternalMetrics(meter metric.Meter) *internalMetrics {
+	if meter == nil {
+		return nil
+	}
+	exported, err := meter.SyncInt64().Counter(RecordsExportedName)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+	failed, err := meter.SyncInt64().Counter(RecordsFailedName)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+	return &internalMetrics{exported: exported, failed: failed}
+}
+
+// export calls fn with a reader counting the records read from reader
+// and records them as exported or failed depending on the returned error.
+func (m *internalMetrics) export(ctx context.Context, reader export.InstrumentationLibraryReader, fn func(export.InstrumentationLibraryReader) error) error {
+	counting := &countingReader{InstrumentationLibraryReader: reader}
+	err := fn(counting)
+	n := atomic.LoadInt64(&counting.count)
+	if err != nil {
+		m.failed.Add(ctx, n)
+	} else {
+		m.exported.Add(ctx, n)
+	}
+	return err
+}
+
+// countingReader is an export.InstrumentationLibraryReader counting the
+// records read from the wrapped reader.
+type countingReader struct {
+	export.InstrumentationLibraryReader
+	count int64
+}
+
+var _ export.InstrumentationLibraryReader = (*countingReader)(nil)
+
+// ForEach implements export.InstrumentationLibraryReader.
+func (r *countingReader) ForEach(readerFunc func(instrumentation.Library, export.Reader) error) error {
+	return r.InstrumentationLibraryReader.ForEach(func(l instrumentation.Library, reader export.Reader) error {
+		return readerFunc(l, countingRecordReader{Reader: reader, count: &r.count})
+	})
+}
+
+// countingRecordReader is an export.Reader adding the number of records read
+// from the wrapped reader to count.
+type countingRecordReader struct {
+	export.Reader
+	count *int64
+}
+
+// ForEach implements export.Reader.
+func (r countingRecordReader) ForEach(sel aggregation.TemporalitySelector, recordFunc func(export.Record) error) error {
+	return r.Reader.ForEach(sel, func(rec export.Record) error {
+		atomic.AddInt64(r.count, 1)
+		return recordFunc(rec)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+)
+
+// recordKey identifies a Record of an instrumentation library.
+type recordKey struct {
+	scope    instrumentation.Library
+	name     string
+	attrs    attribute.Distinct
+	resource attribute.Distinct
+}
+
+func newRecordKey(l instrumentation.Library, rec export.Record) recordKey {
+	return recordKey{
+		scope:    l,
+		name:     rec.Descriptor().Name(),
+		attrs:    rec.Attributes().Equivalent(),
+		resource: rec.Resource().Equivalent(),
+	}
+}
+
+// limitedReader is an export.InstrumentationLibraryReader only reading the
+// records of the wrapped reader that are kept.
+type limitedReader struct {
+	export.InstrumentationLibraryReader
+	keep map[recordKey]struct{}
+}
+
+var _ export.InstrumentationLibraryReader = (*limitedReader)(nil)
+
+// newLimitedReader returns a reader of at most limit records of reader, and
+// the number of records that were dropped. The records kept are the first
+// ones ordered by instrumentation library, instrument name, attributes, and
+// Resource so the same records are kept across exports.
+//
+// The temporality selector determines the records of reader, it needs to be
+// the same one the returned reader is read with.
+func newLimitedReader(reader export.InstrumentationLibraryReader, sel aggregation.TemporalitySelector, limit int) (*limitedReader, int, error) {
+	type sortable struct {
+		key      recordKey
+		attrs    string
+		resource string
+	}
+	var all []sortable
+	enc := attribute.DefaultEncoder()
+	err := reader.ForEach(func(l instrumentation.Library, r export.Reader) error {
+		return r.ForEach(sel, func(rec export.Record) error {
+			all = append(all, sortable{
+				key:      newRecordKey(l, rec),
+				attrs:    rec.Attributes().Encoded(enc),
+				resource: rec.Resource().Encoded(enc),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		switch {
+		case a.key.scope.Name != b.key.scope.Name:
+			return a.key.scope.Name < b.key.scope.Name
+		case a.key.scope.Version != b.key.scope.Version:
+			return a.key.scope.Version < b.key.scope.Version
+		case a.key.scope.SchemaURL != b.key.scope.SchemaURL:
+			return a.key.scope.SchemaURL < b.key.scope.SchemaURL
+		case a.key.name != b.key.name:
+			return a.key.name < b.key.name
+		case a.attrs != b.attrs:
+			return a.attrs < b.attrs
+		}
+		return a.resource < b.resource
+	})
+
+	dropped := 0
+	if len(all) > limit {
+		dropped = len(all) - limit
+		all = all[:limit]
+	}
+	keep := make(map[recordKey]struct{}, len(all))
+	for _, s := range all {
+		keep[s.key] = struct{}{}
+	}
+	return &limitedReader{InstrumentationLibraryReader: reader, keep: keep}, dropped, nil
+}
+
+// ForEach implements export.InstrumentationLibraryReader.
+func (r *limitedReader) ForEach(readerFunc func(l instrumentation.Library, r export.Reader) error) error {
+	return r.InstrumentationLibraryReader.ForEach(func(l instrumentation.Library, reader export.Reader) error {
+		return readerFunc(l, limitedRecords{Reader: reader, scope: l, keep: r.keep})
+	})
+}
+
+// limitedRecords is an export.Reader only reading the kept records of the
+// wrapped reader of one instrumentation library.
+type limitedRecords struct {
+	export.Reader
+	scope instrumentation.Library
+	keep  map[recordKey]struct{}
+}
+
+// ForEach implements export.Reader.
+func (r limitedRecords) ForEach(sel aggregation.TemporalitySelector, recordFunc func(export.Record) error) error {
+	return r.Reader.ForEach(sel, func(rec export.Record) error {
+		if _, ok := r.keep[newRecordKey(r.scope, rec)]; !ok {
+			return nil
+		}
+		return recordFunc(rec)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
@@ -119,3 +120,82 @@ func TestPullWithCollect(t *testing.T) {
 		"counter.sum/A=B/": 20,
 	}, records.Map())
 }
+
+func TestPullCollectWithTimeout(t *testing.T) {
+	puller := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+
+	ctx := context.Background()
+	meter := puller.Meter("timeout")
+	counter, err := meter.SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	gauge, err := meter.AsyncInt64().Gauge("observer.lastvalue")
+	require.NoError(t, err)
+
+	// The callback blocks the collection until release is closed or the
+	// collection times out.
+	release := make(chan struct{})
+	err = meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		gauge.Observe(ctx, 1)
+	})
+	require.NoError(t, err)
+
+	counter.Add(ctx, 1, attribute.String("A", "1"))
+	counter.Add(ctx, 2, attribute.String("A", "2"))
+
+	// The records of the counter are left for the next collection.
+	err = puller.CollectWithTimeout(ctx, 10*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	records := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, controllertest.ReadAll(puller, aggregation.CumulativeTemporalitySelector(), records.AddInstrumentationLibraryRecord))
+	require.EqualValues(t, map[string]float64{
+		"observer.lastvalue//": 1,
+	}, records.Map())
+
+	close(release)
+	require.NoError(t, puller.CollectWithTimeout(ctx, time.Minute))
+	records = processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, controllertest.ReadAll(puller, aggregation.CumulativeTemporalitySelector(), records.AddInstrumentationLibraryRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=1/":     1,
+		"counter.sum/A=2/":     2,
+		"observer.lastvalue//": 1,
+	}, records.Map())
+}
+
+func TestPullCollectDoneContext(t *testing.T) {
+	puller := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	counter, err := puller.Meter("done").SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+	counter.Add(ctx, 1, attribute.String("A", "1"))
+	cancel()
+
+	// Unlike CollectWithTimeout, Collect does not skip any record.
+	err = puller.Collect(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	records := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, controllertest.ReadAll(puller, aggregation.CumulativeTemporalitySelector(), records.AddInstrumentationLibraryRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=1/": 1,
+	}, records.Map())
+}
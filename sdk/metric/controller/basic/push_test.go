@@ -27,12 +27,16 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -228,3 +232,257 @@ func TestPushExportError(t *testing.T) {
 		})
 	}
 }
+
+func TestPushMaxExportRecords(t *testing.T) {
+	exporter := newExporter()
+	checkpointer := newCheckpointerFactory()
+	p := controller.New(
+		checkpointer,
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Second),
+		controller.WithResource(testResource),
+		controller.WithMaxExportRecords(3),
+	)
+	meter := p.Meter("name")
+
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	ctx := context.Background()
+
+	counter, err := meter.SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(ctx))
+
+	for i := 4; i >= 0; i-- {
+		counter.Add(ctx, int64(i), attribute.Int("I", i))
+	}
+
+	mock.Add(time.Second)
+	runtime.Gosched()
+
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/I=0/R=V": 0,
+		"counter.sum/I=1/R=V": 1,
+		"counter.sum/I=2/R=V": 2,
+	}, exporter.Values())
+
+	err = testHandler.Flush()
+	require.ErrorIs(t, err, controller.ErrMaxExportRecords)
+	require.Contains(t, err.Error(), "2 records dropped")
+
+	require.NoError(t, p.Stop(ctx))
+}
+
+func TestPushMaxExportRecordsResources(t *testing.T) {
+	exporter := newExporter()
+	checkpointer := newCheckpointerFactory()
+	p := controller.New(
+		checkpointer,
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Second),
+		controller.WithResource(testResource),
+		controller.WithMaxExportRecords(1),
+	)
+	meter := p.Meter("name")
+
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	ctx := context.Background()
+
+	// The records only differ by their Resource.
+	gauge, err := meter.AsyncInt64().Gauge("gauge.lastvalue")
+	require.NoError(t, err)
+	require.NoError(t, meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+		for i := 1; i >= 0; i-- {
+			res := resource.NewSchemaless(attribute.Int("T", i))
+			gauge.Observe(sdkmetric.ContextWithResource(ctx, res), int64(i), attribute.Int("I", 0))
+		}
+	}))
+
+	require.NoError(t, p.Start(ctx))
+
+	mock.Add(time.Second)
+	runtime.Gosched()
+
+	require.EqualValues(t, map[string]float64{
+		"gauge.lastvalue/I=0/R=V,T=0": 0,
+	}, exporter.Values())
+
+	err = testHandler.Flush()
+	require.ErrorIs(t, err, controller.ErrMaxExportRecords)
+	require.Contains(t, err.Error(), "1 records dropped")
+
+	require.NoError(t, p.Stop(ctx))
+}
+
+// internalSums returns the sums of the counters of cont by name.
+func internalSums(t *testing.T, cont *controller.Controller) map[string]int64 {
+	sums := map[string]int64{}
+	require.NoError(t, cont.ForEach(
+		func(_ instrumentation.Library, reader export.Reader) error {
+			return reader.ForEach(
+				aggregation.CumulativeTemporalitySelector(),
+				func(record export.Record) error {
+					sum, err := record.Aggregation().(aggregation.Sum).Sum()
+					if err != nil {
+						return err
+					}
+					sums[record.Descriptor().Name()] += sum.AsInt64()
+					return nil
+				},
+			)
+		}))
+	return sums
+}
+
+func TestPushInternalMetrics(t *testing.T) {
+	// Discard errors of the final exports of previous tests.
+	_ = testHandler.Flush()
+
+	injector := func(r export.Record) error {
+		if r.Descriptor().Name() == "failing.sum" {
+			return fmt.Errorf("unexpected error")
+		}
+		return nil
+	}
+
+	internal := controller.New(
+		processor.NewFactory(
+			simple.NewWithInexpensiveDistribution(),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+
+	exporter := newExporter()
+	exporter.InjectErr = injector
+	p := controller.New(
+		processor.NewFactory(processortest.AggregatorSelector(), exporter),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Second),
+		controller.WithResource(testResource),
+		controller.WithInternalMetrics(internal.Meter("internal")),
+	)
+	meter := p.Meter("name")
+
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	ctx := context.Background()
+
+	counter, err := meter.SyncInt64().Counter("counter.sum")
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(ctx))
+
+	counter.Add(ctx, 1, attribute.String("A", "1"))
+	counter.Add(ctx, 1, attribute.String("A", "2"))
+	mock.Add(time.Second)
+	runtime.Gosched()
+	require.NoError(t, testHandler.Flush())
+
+	require.NoError(t, internal.Collect(ctx))
+	require.Equal(t, map[string]int64{
+		controller.RecordsExportedName: 2,
+	}, internalSums(t, internal))
+
+	failing, err := meter.SyncInt64().Counter("failing.sum")
+	require.NoError(t, err)
+	failing.Add(ctx, 1)
+	mock.Add(time.Second)
+	runtime.Gosched()
+	require.Error(t, testHandler.Flush())
+
+	require.NoError(t, internal.Collect(ctx))
+	require.Equal(t, map[string]int64{
+		controller.RecordsExportedName: 2,
+		controller.RecordsFailedName:   1,
+	}, internalSums(t, internal))
+
+	require.NoError(t, p.Stop(ctx))
+}
+
+// slowExporter blocks exports until released and records the maximum
+// number of concurrent exports.
+type slowExporter struct {
+	*processortest.Exporter
+
+	started chan struct{}
+	release chan struct{}
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (e *slowExporter) Export(ctx context.Context, res *resource.Resource, ckpt export.InstrumentationLibraryReader) error {
+	e.mu.Lock()
+	e.active++
+	if e.active > e.maxActive {
+		e.maxActive = e.active
+	}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.active--
+		e.mu.Unlock()
+	}()
+
+	select {
+	case e.started <- struct{}{}:
+	default:
+	}
+	<-e.release
+	return e.Exporter.Export(ctx, res, ckpt)
+}
+
+func TestPushSkippedTicks(t *testing.T) {
+	exporter := &slowExporter{
+		Exporter: newExporter(),
+		started:  make(chan struct{}, 1),
+		release:  make(chan struct{}),
+	}
+	p := controller.New(
+		newCheckpointerFactory(),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Second),
+	)
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx))
+
+	mock.Add(time.Second)
+	<-exporter.started
+
+	// The export is slower than the period.
+	for i := 0; i < 3; i++ {
+		mock.Add(time.Second)
+	}
+	require.Equal(t, 0, p.SkippedTicks())
+	close(exporter.release)
+
+	require.Eventually(t, func() bool {
+		return p.SkippedTicks() == 3
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 1, exporter.ExportCount())
+
+	// The skipped ticks are coalesced, the next collection is on the next
+	// tick.
+	mock.Add(time.Second)
+	require.Eventually(t, func() bool {
+		return exporter.ExportCount() == 2
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, p.Stop(ctx))
+	require.Equal(t, 3, p.SkippedTicks())
+	exporter.mu.Lock()
+	require.Equal(t, 1, exporter.maxActive)
+	exporter.mu.Unlock()
+}
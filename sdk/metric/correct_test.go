@@ -28,12 +28,15 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 type handler struct {
@@ -115,6 +118,25 @@ func TestInputRangeCounter(t *testing.T) {
 	require.Nil(t, testHandler.Flush())
 }
 
+func TestInputRangeFloatCounter(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	counter, err := meter.SyncFloat64().Counter("name.sum")
+	require.NoError(t, err)
+
+	counter.Add(ctx, 1)
+	counter.Add(ctx, math.Inf(1))
+	require.Equal(t, aggregation.ErrInfInput, testHandler.Flush())
+
+	checkpointed := sdk.Collect(ctx)
+	require.Equal(t, map[string]float64{
+		"name.sum//": 1,
+	}, processor.Values())
+	require.Equal(t, 1, checkpointed)
+	require.Nil(t, testHandler.Flush())
+}
+
 func TestInputRangeUpDownCounter(t *testing.T) {
 	ctx := context.Background()
 	meter, sdk, _, processor := newSDK(t)
@@ -175,6 +197,28 @@ func TestDisabledInstrument(t *testing.T) {
 	require.Equal(t, map[string]float64{}, processor.Values())
 }
 
+func TestDisabledInstrumentDroppedUpdates(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, _ := newSDK(t)
+
+	histogram, err := meter.SyncFloat64().Histogram("name.disabled")
+	require.NoError(t, err)
+	counter, err := meter.SyncInt64().Counter("name.sum")
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int64{}, sdk.DroppedUpdates())
+
+	histogram.Record(ctx, 1)
+	histogram.Record(ctx, 2, attribute.String("A", "B"))
+	counter.Add(ctx, 1)
+	require.Equal(t, map[string]int64{"name.disabled": 2}, sdk.DroppedUpdates())
+
+	// The count is not reset by collection.
+	sdk.Collect(ctx)
+	histogram.Record(ctx, 3)
+	require.Equal(t, map[string]int64{"name.disabled": 3}, sdk.DroppedUpdates())
+}
+
 func TestRecordNaN(t *testing.T) {
 	ctx := context.Background()
 	meter, _, _, _ := newSDK(t)
@@ -388,6 +432,85 @@ func TestObserverCollection(t *testing.T) {
 	}
 }
 
+func TestObserverResource(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	tenantA := resource.NewSchemaless(attribute.String("tenant", "a"))
+	tenantB := resource.NewSchemaless(attribute.String("tenant", "b"))
+
+	gauge, err := meter.AsyncInt64().Gauge("int.gauge.lastvalue")
+	require.NoError(t, err)
+	err = meter.RegisterCallback([]instrument.Asynchronous{
+		gauge,
+	}, func(ctx context.Context) {
+		gauge.Observe(metricsdk.ContextWithResource(ctx, tenantA), 1, attribute.String("A", "B"))
+	})
+	require.NoError(t, err)
+
+	counter, err := meter.AsyncInt64().Counter("int.counterobserver.sum")
+	require.NoError(t, err)
+	err = meter.RegisterCallback([]instrument.Asynchronous{
+		gauge, counter,
+	}, func(ctx context.Context) {
+		ctx = metricsdk.ContextWithResource(ctx, tenantB)
+		gauge.Observe(ctx, 2, attribute.String("A", "B"))
+		counter.Observe(ctx, 3)
+	})
+	require.NoError(t, err)
+
+	// Observations without a Resource are kept apart from the others.
+	err = meter.RegisterCallback([]instrument.Asynchronous{
+		counter,
+	}, func(ctx context.Context) {
+		counter.Observe(ctx, 4)
+	})
+	require.NoError(t, err)
+
+	collected := sdk.Collect(ctx)
+	require.Equal(t, 4, collected)
+	require.EqualValues(t, map[string]float64{
+		"int.gauge.lastvalue/A=B/tenant=a":  1,
+		"int.gauge.lastvalue/A=B/tenant=b":  2,
+		"int.counterobserver.sum//tenant=b": 3,
+		"int.counterobserver.sum//":         4,
+	}, processor.Values())
+}
+
+func TestObserverCallbackPanic(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	broken, err := meter.AsyncInt64().Gauge("broken.lastvalue")
+	require.NoError(t, err)
+	err = meter.RegisterCallback([]instrument.Asynchronous{
+		broken,
+	}, func(ctx context.Context) {
+		panic("callback failure")
+	})
+	require.NoError(t, err)
+
+	healthy, err := meter.AsyncInt64().Gauge("healthy.lastvalue")
+	require.NoError(t, err)
+	err = meter.RegisterCallback([]instrument.Asynchronous{
+		healthy,
+	}, func(ctx context.Context) {
+		healthy.Observe(ctx, 1)
+	})
+	require.NoError(t, err)
+
+	require.NotPanics(t, func() {
+		require.Equal(t, 1, sdk.Collect(ctx))
+	})
+	require.EqualValues(t, map[string]float64{
+		"healthy.lastvalue//": 1,
+	}, processor.Values())
+
+	err = testHandler.Flush()
+	require.ErrorIs(t, err, metricsdk.ErrCallbackPanic)
+	require.Contains(t, err.Error(), "callback failure")
+}
+
 func TestCounterObserverInputRange(t *testing.T) {
 	ctx := context.Background()
 	meter, sdk, _, processor := newSDK(t)
@@ -487,6 +610,45 @@ func TestObserverBatch(t *testing.T) {
 	}, processor.Values())
 }
 
+// TestObserverBatchCorrelated ensures instruments registered with a single
+// callback are observed from the same invocation, so related measurements
+// are consistent with one another in every collection.
+func TestObserverBatchCorrelated(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	cpu, _ := meter.AsyncFloat64().Gauge("cpu.lastvalue")
+	mem, _ := meter.AsyncInt64().Gauge("mem.lastvalue")
+	goroutines, _ := meter.AsyncInt64().Gauge("goroutines.lastvalue")
+
+	var calls int
+	err := meter.RegisterCallback([]instrument.Asynchronous{
+		cpu,
+		mem,
+		goroutines,
+	}, func(ctx context.Context) {
+		calls++
+		attrs := attribute.String("host", "a")
+		cpu.Observe(ctx, float64(calls)/10, attrs)
+		mem.Observe(ctx, int64(calls)*1000, attrs)
+		goroutines.Observe(ctx, int64(calls), attrs)
+	})
+	require.NoError(t, err)
+
+	for i := 1; i <= 2; i++ {
+		processor.Reset()
+		collected := sdk.Collect(ctx)
+
+		require.Equal(t, i, calls)
+		require.Equal(t, 3, collected)
+		require.EqualValues(t, map[string]float64{
+			"cpu.lastvalue/host=a/":        float64(i) / 10,
+			"mem.lastvalue/host=a/":        float64(i) * 1000,
+			"goroutines.lastvalue/host=a/": float64(i),
+		}, processor.Values())
+	}
+}
+
 // TestRecordPersistence ensures that a direct-called instrument that is
 // repeatedly used each interval results in a persistent record, so that its
 // encoded attribute will be cached across collection intervals.
@@ -507,6 +669,67 @@ func TestRecordPersistence(t *testing.T) {
 	require.Equal(t, 2, selector.newAggCount)
 }
 
+func TestRecordSet(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	counter, err := meter.SyncInt64().Counter("name.sum")
+	require.NoError(t, err)
+	histogram, err := meter.SyncFloat64().Histogram("name.histogram")
+	require.NoError(t, err)
+
+	set := attribute.NewSet(attribute.String("B", "2"), attribute.String("A", "1"))
+	syncint64.AddSet(ctx, counter, 1, &set)
+	syncint64.AddSet(ctx, counter, 2, &set)
+	// Records with a set and with the same attributes are aggregated together.
+	counter.Add(ctx, 4, attribute.String("A", "1"), attribute.String("B", "2"))
+	syncfloat64.RecordSet(ctx, histogram, 8, &set)
+
+	checkpointed := sdk.Collect(ctx)
+	require.Equal(t, 2, checkpointed)
+	require.Equal(t, map[string]float64{
+		"name.sum/A=1,B=2/":       7,
+		"name.histogram/A=1,B=2/": 8,
+	}, processor.Values())
+	require.Nil(t, testHandler.Flush())
+
+	// The set is used as is, without copying and sorting its attributes
+	// like the attributes passed to Add.
+	kvs := set.ToSlice()
+	addAllocs := testing.AllocsPerRun(10, func() { counter.Add(ctx, 1, kvs...) })
+	setAllocs := testing.AllocsPerRun(10, func() { syncint64.AddSet(ctx, counter, 1, &set) })
+	require.Less(t, setAllocs, addAllocs, "AddSet did not take the set fast path")
+}
+
+func TestRecordHistogram(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, _, processor := newSDK(t)
+
+	histogram, err := meter.SyncFloat64().Histogram("name.histogram")
+	require.NoError(t, err)
+	counter, err := meter.SyncFloat64().Histogram("name.sum")
+	require.NoError(t, err)
+
+	bounds := []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	counts := make([]uint64, len(bounds)+1)
+	counts[len(bounds)] = 2
+	attr := attribute.String("A", "1")
+	require.NoError(t, syncfloat64.RecordHistogram(ctx, histogram, counts, bounds, 30, attr))
+	histogram.Record(ctx, 12, attr)
+
+	err = syncfloat64.RecordHistogram(ctx, histogram, counts[1:], bounds[1:], 30, attr)
+	require.ErrorIs(t, err, aggregation.ErrInconsistentBuckets)
+	err = syncfloat64.RecordHistogram(ctx, counter, counts, bounds, 30, attr)
+	require.ErrorIs(t, err, instrument.ErrBucketsUnsupported)
+
+	checkpointed := sdk.Collect(ctx)
+	require.Equal(t, 1, checkpointed)
+	require.Equal(t, map[string]float64{
+		"name.histogram/A=1/": 42,
+	}, processor.Values())
+	require.Nil(t, testHandler.Flush())
+}
+
 func TestIncorrectInstruments(t *testing.T) {
 	// The Batch observe/record APIs are susceptible to
 	// uninitialized instruments.
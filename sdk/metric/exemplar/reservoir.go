@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar provides a fixed-size reservoir of exemplars that
+// aggregators can embed to sample measurements.
+//
+// An exemplar is a measurement recorded along with the time it was made,
+// the trace and span IDs of the span active when it was made, and the
+// attributes filtered out of its timeseries. A Reservoir keeps a uniform
+// random sample of the measurements offered to it between collections.
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/exemplar"
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Measurement is a value offered to a Reservoir.
+type Measurement struct {
+	// Value is the measured value. It is interpreted with the number kind
+	// of the instrument that made the measurement.
+	Value number.Number
+	// Time is when the measurement was made.
+	Time time.Time
+	// SpanContext is the SpanContext of the span active when the
+	// measurement was made. The trace and span IDs are only recorded if it
+	// is valid.
+	SpanContext trace.SpanContext
+	// FilteredAttributes are the attributes of the measurement that are not
+	// part of its timeseries.
+	FilteredAttributes []attribute.KeyValue
+}
+
+// Exemplar is a measurement sampled by a Reservoir.
+type Exemplar struct {
+	// Value is the measured value.
+	Value number.Number
+	// Time is when the measurement was made.
+	Time time.Time
+	// TraceID is the ID of the trace the measurement was made in, if any.
+	TraceID trace.TraceID
+	// SpanID is the ID of the span the measurement was made in, if any.
+	SpanID trace.SpanID
+	// FilteredAttributes are the attributes of the measurement that are not
+	// part of its timeseries.
+	FilteredAttributes []attribute.KeyValue
+}
+
+// Reservoir samples the measurements offered to it with fixed-size
+// reservoir sampling. Each measurement offered between two collections has
+// the same probability to be kept. It is safe for concurrent use.
+type Reservoir struct {
+	lock  sync.Mutex
+	store []Exemplar
+	// count is the number of measurements offered since the last Collect.
+	count int64
+	rng   *rand.Rand
+}
+
+// NewReservoir returns a Reservoir that keeps at most size exemplars. A
+// size less than 1 returns a Reservoir that never keeps any.
+func NewReservoir(size int) *Reservoir {
+	if size < 0 {
+		size = 0
+	}
+	return &Reservoir{
+		store: make([]Exemplar, 0, size),
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Offer offers m to the Reservoir. Once the Reservoir is full, m replaces a
+// randomly chosen exemplar with a probability of size / n, where n is the
+// number of measurements offered since the last Collect.
+func (r *Reservoir) Offer(m Measurement) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.count++
+	if len(r.store) < cap(r.store) {
+		r.store = append(r.store, newExemplar(m))
+		return
+	}
+	if cap(r.store) == 0 {
+		return
+	}
+	if j := r.rng.Int63n(r.count); j < int64(len(r.store)) {
+		r.store[j] = newExemplar(m)
+	}
+}
+
+// Collect returns the exemplars kept since the last Collect and empties
+// the Reservoir.
+func (r *Reservoir) Collect() []Exemplar {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	out := make([]Exemplar, len(r.store))
+	copy(out, r.store)
+	r.store = r.store[:0]
+	r.count = 0
+	return out
+}
+
+func newExemplar(m Measurement) Exemplar {
+	e := Exemplar{
+		Value:              m.Value,
+		Time:               m.Time,
+		FilteredAttributes: m.FilteredAttributes,
+	}
+	if m.SpanContext.IsValid() {
+		e.TraceID = m.SpanContext.TraceID()
+		e.SpanID = m.SpanContext.SpanID()
+	}
+	return e
+}
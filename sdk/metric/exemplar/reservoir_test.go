@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func measurement(v int64) Measurement {
+	return Measurement{Value: number.NewInt64Number(v)}
+}
+
+func TestReservoirCapacity(t *testing.T) {
+	r := NewReservoir(3)
+	r.Offer(measurement(1))
+	r.Offer(measurement(2))
+	got := r.Collect()
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(1), got[0].Value.AsInt64())
+	assert.Equal(t, int64(2), got[1].Value.AsInt64())
+
+	for i := 0; i < 100; i++ {
+		r.Offer(measurement(int64(i)))
+	}
+	assert.Len(t, r.Collect(), 3)
+	assert.Empty(t, r.Collect(), "Collect did not empty the reservoir")
+
+	r = NewReservoir(0)
+	r.Offer(measurement(1))
+	assert.Empty(t, r.Collect())
+}
+
+func TestReservoirExemplar(t *testing.T) {
+	now := time.Now()
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	attrs := []attribute.KeyValue{attribute.String("user", "alice")}
+
+	r := NewReservoir(2)
+	r.Offer(Measurement{
+		Value:              number.NewFloat64Number(1.5),
+		Time:               now,
+		SpanContext:        sc,
+		FilteredAttributes: attrs,
+	})
+	r.Offer(Measurement{Value: number.NewFloat64Number(2.5), Time: now})
+
+	got := r.Collect()
+	require.Len(t, got, 2)
+	assert.Equal(t, Exemplar{
+		Value:              number.NewFloat64Number(1.5),
+		Time:               now,
+		TraceID:            sc.TraceID(),
+		SpanID:             sc.SpanID(),
+		FilteredAttributes: attrs,
+	}, got[0])
+	assert.Equal(t, Exemplar{Value: number.NewFloat64Number(2.5), Time: now}, got[1])
+}
+
+func TestReservoirReplacementDistribution(t *testing.T) {
+	const (
+		size   = 10
+		n      = 100
+		trials = 2000
+	)
+	r := NewReservoir(size)
+	r.rng = rand.New(rand.NewSource(1))
+
+	var kept [n]int
+	for i := 0; i < trials; i++ {
+		for v := 0; v < n; v++ {
+			r.Offer(measurement(int64(v)))
+		}
+		for _, e := range r.Collect() {
+			kept[e.Value.AsInt64()]++
+		}
+	}
+
+	// Each measurement is expected to be kept size/n of the time.
+	want := trials * size / n
+	for v, k := range kept {
+		assert.InDeltaf(t, want, k, float64(want)/2, "measurement %d", v)
+	}
+}
@@ -72,6 +72,45 @@ type (
 		Sum() (number.Number, error)
 		Histogram() (Buckets, error)
 	}
+
+	// ExponentialBuckets is a contiguous set of base-2 exponential
+	// histogram bucket counts.
+	//
+	// The bucket at position i counts values in the range
+	// [base**(Offset()+i), base**(Offset()+i+1)), where base is
+	// 2**(2**-scale).
+	ExponentialBuckets interface {
+		// Offset is the bucket index of the first count.
+		Offset() int32
+
+		// Len is the number of counts.
+		Len() uint32
+
+		// At returns the count at position i, for 0 <= i < Len().
+		At(i uint32) uint64
+	}
+
+	// ExponentialHistogram returns the count of events in base-2
+	// exponential buckets.
+	ExponentialHistogram interface {
+		Aggregation
+		Count() (uint64, error)
+		Sum() (number.Number, error)
+
+		// Scale is the resolution of the buckets, see
+		// ExponentialBuckets.
+		Scale() (int32, error)
+
+		// ZeroCount is the number of values equal to zero.
+		ZeroCount() (uint64, error)
+
+		// Positive returns the buckets of values greater than zero.
+		Positive() (ExponentialBuckets, error)
+
+		// Negative returns the buckets of values less than zero,
+		// indexed by their absolute value.
+		Negative() (ExponentialBuckets, error)
+	}
 )
 
 type (
@@ -95,12 +134,15 @@ const (
 	SumKind       Kind = "Sum"
 	HistogramKind Kind = "Histogram"
 	LastValueKind Kind = "Lastvalue"
+
+	ExponentialHistogramKind Kind = "ExponentialHistogram"
 )
 
 // Sentinel errors for Aggregation interface.
 var (
 	ErrNegativeInput    = fmt.Errorf("negative value is out of range for this instrument")
 	ErrNaNInput         = fmt.Errorf("invalid input value: NaN")
+	ErrInfInput         = fmt.Errorf("invalid input value: Inf")
 	ErrInconsistentType = fmt.Errorf("inconsistent aggregator types")
 
 	// ErrNoCumulativeToDelta is returned when requesting delta
@@ -111,6 +153,15 @@ var (
 	// the Aggregator is check-pointed before the first value is set.
 	// The aggregator should simply be skipped in this case.
 	ErrNoData = fmt.Errorf("no data collected by this aggregator")
+
+	// ErrOverflow is reported when a sum exceeds the range of its
+	// number kind. It is only reported by aggregators configured to
+	// detect overflow.
+	ErrOverflow = fmt.Errorf("sum overflows its number kind")
+
+	// ErrInconsistentBuckets is returned when pre-aggregated buckets
+	// do not match the bucket boundaries of a histogram.
+	ErrInconsistentBuckets = fmt.Errorf("inconsistent histogram buckets")
 )
 
 // String returns the string value of Kind.
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export // import "go.opentelemetry.io/otel/sdk/metric/export"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+)
+
+// MergeReaders returns a Reader of the records of all readers. This can be
+// used to export the metrics of multiple in-process SDKs, e.g. the SDKs of
+// plugins, as a single checkpoint.
+//
+// Records with identical instrument names, attributes, and resources are
+// merged into a single record using the Merge method of their aggregators.
+// The merged aggregators are allocated with aggSelector, the aggregators of
+// readers are never modified. A record that cannot be merged, because its
+// instrument or aggregator is incompatible with the record it would be
+// merged into, is reported to the global ErrorHandler and skipped.
+//
+// Locking the returned Reader locks all readers.
+func MergeReaders(aggSelector AggregatorSelector, readers ...Reader) Reader {
+	return &mergedReader{
+		selector: aggSelector,
+		readers:  readers,
+	}
+}
+
+type mergedReader struct {
+	selector AggregatorSelector
+	readers  []Reader
+}
+
+var _ Reader = (*mergedReader)(nil)
+
+// mergeKey identifies the records merged together.
+type mergeKey struct {
+	name  string
+	attrs attribute.Distinct
+	res   attribute.Distinct
+}
+
+// mergedRecord is the result of merging records.
+type mergedRecord struct {
+	record Record
+	// agg holds the merged aggregation. It is nil until a second record
+	// is merged.
+	agg aggregator.Aggregator
+}
+
+// ForEach iterates over the merged records of all readers. Records are
+// passed to recordFunc in the order they are first seen.
+func (m *mergedReader) ForEach(tempSelector aggregation.TemporalitySelector, recordFunc func(Record) error) error {
+	var (
+		order   []mergeKey
+		records = make(map[mergeKey]*mergedRecord)
+	)
+	for _, r := range m.readers {
+		err := r.ForEach(tempSelector, func(rec Record) error {
+			key := mergeKey{
+				name:  rec.Descriptor().Name(),
+				attrs: rec.Attributes().Equivalent(),
+				res:   rec.Resource().Equivalent(),
+			}
+			mr, ok := records[key]
+			if !ok {
+				records[key] = &mergedRecord{record: rec}
+				order = append(order, key)
+				return nil
+			}
+			if err := m.merge(mr, rec); err != nil {
+				otel.Handle(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range order {
+		if err := recordFunc(records[key].record); err != nil && err != aggregation.ErrNoData {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge merges rec into mr.
+func (m *mergedReader) merge(mr *mergedRecord, rec Record) error {
+	desc := mr.record.Descriptor()
+	if d := rec.Descriptor(); d.InstrumentKind() != desc.InstrumentKind() || d.NumberKind() != desc.NumberKind() {
+		return fmt.Errorf("%w: %s: incompatible instruments", aggregation.ErrInconsistentType, desc.Name())
+	}
+	src, ok := rec.Aggregation().(aggregator.Aggregator)
+	if !ok {
+		return fmt.Errorf("%w: %s: %T is not an aggregator", aggregation.ErrInconsistentType, desc.Name(), rec.Aggregation())
+	}
+
+	if mr.agg == nil {
+		first, ok := mr.record.Aggregation().(aggregator.Aggregator)
+		if !ok {
+			return fmt.Errorf("%w: %s: %T is not an aggregator", aggregation.ErrInconsistentType, desc.Name(), mr.record.Aggregation())
+		}
+		var agg aggregator.Aggregator
+		m.selector.AggregatorFor(desc, &agg)
+		if agg == nil {
+			return fmt.Errorf("%w: %s: no aggregator selected", aggregation.ErrInconsistentType, desc.Name())
+		}
+		if err := agg.Merge(first, desc); err != nil {
+			return err
+		}
+		mr.agg = agg
+	}
+	if err := mr.agg.Merge(src, desc); err != nil {
+		return err
+	}
+
+	start, end := mr.record.StartTime(), mr.record.EndTime()
+	if s := rec.StartTime(); s.Before(start) {
+		start = s
+	}
+	if e := rec.EndTime(); e.After(end) {
+		end = e
+	}
+	mr.record = NewRecordWithResource(desc, mr.record.Attributes(), mr.record.Resource(), mr.agg.Aggregation(), start, end)
+	return nil
+}
+
+// Lock locks all merged readers.
+func (m *mergedReader) Lock() {
+	for _, r := range m.readers {
+		r.Lock()
+	}
+}
+
+// Unlock unlocks all merged readers.
+func (m *mergedReader) Unlock() {
+	for i := len(m.readers) - 1; i >= 0; i-- {
+		m.readers[i].Unlock()
+	}
+}
+
+// RLock read locks all merged readers.
+func (m *mergedReader) RLock() {
+	for _, r := range m.readers {
+		r.RLock()
+	}
+}
+
+// RUnlock read unlocks all merged readers.
+func (m *mergedReader) RUnlock() {
+	for i := len(m.readers) - 1; i >= 0; i-- {
+		m.readers[i].RUnlock()
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+)
+
+type testReader struct {
+	sync.RWMutex
+	records []export.Record
+}
+
+func (r *testReader) ForEach(_ aggregation.TemporalitySelector, fn func(export.Record) error) error {
+	for _, rec := range r.records {
+		if err := fn(rec); err != nil && err != aggregation.ErrNoData {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	counterA = metrictest.NewDescriptor("a", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	counterB = metrictest.NewDescriptor("b", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	counterC = metrictest.NewDescriptor("c", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	gaugeB   = metrictest.NewDescriptor("b", sdkapi.GaugeObserverInstrumentKind, number.Int64Kind)
+
+	attrs = attribute.NewSet(attribute.String("k", "v"))
+	t0    = time.Unix(100, 0)
+	t1    = time.Unix(200, 0)
+	t2    = time.Unix(300, 0)
+)
+
+func newRecord(t *testing.T, desc *sdkapi.Descriptor, v int64, start, end time.Time) export.Record {
+	var agg aggregator.Aggregator
+	if desc.InstrumentKind() == sdkapi.GaugeObserverInstrumentKind {
+		agg = &lastvalue.New(1)[0]
+	} else {
+		agg = &sum.New(1)[0]
+	}
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(v), desc))
+	return export.NewRecord(desc, &attrs, agg.Aggregation(), start, end)
+}
+
+func sumOf(t *testing.T, rec export.Record) int64 {
+	s, err := rec.Aggregation().(aggregation.Sum).Sum()
+	require.NoError(t, err)
+	return s.AsInt64()
+}
+
+func TestMergeReaders(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	first := &testReader{records: []export.Record{
+		newRecord(t, &counterA, 1, t1, t2),
+		newRecord(t, &counterB, 5, t1, t2),
+	}}
+	second := &testReader{records: []export.Record{
+		newRecord(t, &counterA, 2, t0, t1),
+		newRecord(t, &gaugeB, 3, t0, t1),
+		newRecord(t, &counterC, 7, t0, t1),
+	}}
+
+	merged := export.MergeReaders(simple.NewWithInexpensiveDistribution(), first, second)
+	merged.RLock()
+	defer merged.RUnlock()
+
+	var got []export.Record
+	require.NoError(t, merged.ForEach(aggregation.CumulativeTemporalitySelector(), func(rec export.Record) error {
+		got = append(got, rec)
+		return nil
+	}))
+
+	require.Len(t, got, 3)
+	// Overlapping records are merged and span both intervals.
+	assert.Equal(t, "a", got[0].Descriptor().Name())
+	assert.Equal(t, int64(3), sumOf(t, got[0]))
+	assert.Equal(t, t0, got[0].StartTime())
+	assert.Equal(t, t2, got[0].EndTime())
+	assert.Equal(t, attrs.Equivalent(), got[0].Attributes().Equivalent())
+	// The incompatible gauge is skipped.
+	assert.Equal(t, "b", got[1].Descriptor().Name())
+	assert.Equal(t, int64(5), sumOf(t, got[1]))
+	// Disjoint records are passed through.
+	assert.Equal(t, "c", got[2].Descriptor().Name())
+	assert.Equal(t, int64(7), sumOf(t, got[2]))
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], aggregation.ErrInconsistentType)
+
+	// The aggregators of the merged readers are not modified.
+	assert.Equal(t, int64(1), sumOf(t, first.records[0]))
+	assert.Equal(t, int64(2), sumOf(t, second.records[0]))
+}
@@ -198,6 +198,7 @@ type Reader interface {
 type Metadata struct {
 	descriptor *sdkapi.Descriptor
 	attrs      *attribute.Set
+	resource   *resource.Resource
 }
 
 // Accumulation contains the exported data for a single metric instrument
@@ -228,15 +229,33 @@ func (m Metadata) Attributes() *attribute.Set {
 	return m.attrs
 }
 
+// Resource returns the Resource associated with the aggregated data by the
+// instrumentation, or nil if there is none.
+//
+// This Resource is in addition to the Resource passed to Exporter.Export.
+// Exporters merge the two, with the attributes of this Resource taking
+// precedence over attributes of the same key in the Resource passed to
+// Export, i.e. resource.Merge(res, m.Resource()).
+func (m Metadata) Resource() *resource.Resource {
+	return m.resource
+}
+
 // NewAccumulation allows Accumulator implementations to construct new
 // Accumulations to send to Processors. The Descriptor, attributes, and
 // Aggregator represent aggregate metric events received over a single
 // collection period.
 func NewAccumulation(descriptor *sdkapi.Descriptor, attrs *attribute.Set, agg aggregator.Aggregator) Accumulation {
+	return NewAccumulationWithResource(descriptor, attrs, nil, agg)
+}
+
+// NewAccumulationWithResource is like NewAccumulation, but also associates
+// the Accumulation with res. A nil res means no Resource is associated.
+func NewAccumulationWithResource(descriptor *sdkapi.Descriptor, attrs *attribute.Set, res *resource.Resource, agg aggregator.Aggregator) Accumulation {
 	return Accumulation{
 		Metadata: Metadata{
 			descriptor: descriptor,
 			attrs:      attrs,
+			resource:   res,
 		},
 		aggregator: agg,
 	}
@@ -252,10 +271,17 @@ func (r Accumulation) Aggregator() aggregator.Aggregator {
 // The Descriptor, attributes, and Aggregator represent aggregate metric
 // events received over a single collection period.
 func NewRecord(descriptor *sdkapi.Descriptor, attrs *attribute.Set, agg aggregation.Aggregation, start, end time.Time) Record {
+	return NewRecordWithResource(descriptor, attrs, nil, agg, start, end)
+}
+
+// NewRecordWithResource is like NewRecord, but also associates the Record
+// with res. A nil res means no Resource is associated.
+func NewRecordWithResource(descriptor *sdkapi.Descriptor, attrs *attribute.Set, res *resource.Resource, agg aggregation.Aggregation, start, end time.Time) Record {
 	return Record{
 		Metadata: Metadata{
 			descriptor: descriptor,
 			attrs:      attrs,
+			resource:   res,
 		},
 		aggregation: agg,
 		start:       start,
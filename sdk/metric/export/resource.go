@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export // import "go.opentelemetry.io/otel/sdk/metric/export"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// WithResourceOverride returns an Exporter that exports with exporter, but
+// with res as the Resource of all exported metric data. The Resource passed
+// to Export, and any Resource associated with a Record, is replaced by res
+// before exporter sees them. This can be used to send the same metric data to
+// multiple backends that expect different Resources.
+func WithResourceOverride(exporter Exporter, res *resource.Resource) Exporter {
+	return resourceOverrideExporter{
+		Exporter: exporter,
+		res:      res,
+	}
+}
+
+type resourceOverrideExporter struct {
+	Exporter
+	res *resource.Resource
+}
+
+var _ Exporter = resourceOverrideExporter{}
+
+// Export exports the metric data of reader with the overriding Resource.
+func (e resourceOverrideExporter) Export(ctx context.Context, _ *resource.Resource, reader InstrumentationLibraryReader) error {
+	return e.Exporter.Export(ctx, e.res, resourceOverrideLibraryReader{reader})
+}
+
+// resourceOverrideLibraryReader is an InstrumentationLibraryReader of Readers
+// that drop the Resources associated with Records.
+type resourceOverrideLibraryReader struct {
+	InstrumentationLibraryReader
+}
+
+func (r resourceOverrideLibraryReader) ForEach(readerFunc func(instrumentation.Library, Reader) error) error {
+	return r.InstrumentationLibraryReader.ForEach(func(lib instrumentation.Library, reader Reader) error {
+		return readerFunc(lib, resourceOverrideReader{reader})
+	})
+}
+
+// resourceOverrideReader is a Reader of Records without Resources.
+type resourceOverrideReader struct {
+	Reader
+}
+
+func (r resourceOverrideReader) ForEach(tempSelector aggregation.TemporalitySelector, recordFunc func(Record) error) error {
+	return r.Reader.ForEach(tempSelector, func(rec Record) error {
+		if rec.Resource() != nil {
+			rec = NewRecord(rec.Descriptor(), rec.Attributes(), rec.Aggregation(), rec.StartTime(), rec.EndTime())
+		}
+		return recordFunc(rec)
+	})
+}
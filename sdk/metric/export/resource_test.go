@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestWithResourceOverride(t *testing.T) {
+	original := resource.NewSchemaless(attribute.String("R", "original"))
+	override := resource.NewSchemaless(attribute.String("R", "override"))
+	recRes := resource.NewSchemaless(attribute.String("S", "record"))
+
+	// The processortest Exporter selects aggregators by name suffix.
+	descA := metrictest.NewDescriptor("a.sum", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	descB := metrictest.NewDescriptor("b.sum", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	rec := newRecord(t, &descA, 1, t0, t1)
+	reader := &testReader{records: []export.Record{
+		rec,
+		export.NewRecordWithResource(&descB, &attrs, recRes, rec.Aggregation(), t0, t1),
+	}}
+	libReader := processortest.OneInstrumentationLibraryReader(instrumentation.Library{Name: "test"}, reader)
+
+	plain := processortest.New(aggregation.CumulativeTemporalitySelector(), attribute.DefaultEncoder())
+	overridden := processortest.New(aggregation.CumulativeTemporalitySelector(), attribute.DefaultEncoder())
+
+	for _, exp := range []export.Exporter{
+		plain,
+		export.WithResourceOverride(overridden, override),
+	} {
+		require.NoError(t, exp.Export(context.Background(), original, libReader))
+	}
+
+	assert.Equal(t, map[string]float64{
+		"a.sum/k=v/R=original":          1,
+		"b.sum/k=v/R=original,S=record": 1,
+	}, plain.Values())
+	assert.Equal(t, map[string]float64{
+		"a.sum/k=v/R=override": 1,
+		"b.sum/k=v/R=override": 1,
+	}, overridden.Values())
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+)
+
+// RecordsToMap returns the point values of all Records of reader, read with
+// the temporality chosen by selector, for test validation. It can be used
+// with any Reader, e.g. the Reader of a basic Processor or one passed to an
+// Exporter, to assert metric values without a test Exporter.
+//
+// The values are keyed by "name/attributes/resource", where the attributes
+// and the resource of each Record are encoded with encoder. The resource is
+// the one a Record itself carries, it is empty unless the Record was created
+// with one. For example:
+//
+//	require.EqualValues(t, map[string]float64{
+//	    "counter.sum/A=1,B=2/R=V": 100,
+//	}, values)
+//
+// A point value is the Sum or LastValue of a Record's Aggregation, whichever
+// is implemented. An error is returned if a Record's Aggregation implements
+// neither.
+func RecordsToMap(reader export.Reader, selector aggregation.TemporalitySelector, encoder attribute.Encoder) (map[string]float64, error) {
+	r := make(map[string]float64)
+	err := reader.ForEach(selector, func(rec export.Record) error {
+		desc := rec.Descriptor()
+		var value number.Number
+		switch agg := rec.Aggregation().(type) {
+		case aggregation.Sum:
+			var err error
+			if value, err = agg.Sum(); err != nil {
+				return err
+			}
+		case aggregation.LastValue:
+			var err error
+			if value, _, err = agg.LastValue(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: unhandled aggregation %s", desc.Name(), agg.Kind())
+		}
+
+		key := fmt.Sprint(desc.Name(), "/", rec.Attributes().Encoded(encoder), "/", rec.Resource().Encoded(encoder))
+		r[key] = value.CoerceToFloat64(desc.NumberKind())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+)
+
+func TestRecordsToMap(t *testing.T) {
+	ctx := context.Background()
+	checkpointer := processortest.NewCheckpointer(
+		processortest.NewProcessor(
+			processortest.AggregatorSelector(),
+			attribute.DefaultEncoder(),
+		),
+	)
+	accum := metricsdk.NewAccumulator(checkpointer)
+	meter := sdkapi.WrapMeterImpl(accum)
+
+	counter, err := meter.SyncFloat64().Counter("counter.sum")
+	require.NoError(t, err)
+	counter.Add(ctx, 100, attribute.String("K1", "V1"))
+	counter.Add(ctx, 101, attribute.String("K1", "V2"))
+
+	gauge, err := meter.AsyncInt64().Gauge("gauge.lastvalue")
+	require.NoError(t, err)
+	require.NoError(t, meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+		gauge.Observe(ctx, 10, attribute.String("K1", "V1"))
+	}))
+
+	accum.Collect(ctx)
+
+	got, err := metrictest.RecordsToMap(
+		checkpointer.Reader(),
+		aggregation.StatelessTemporalitySelector(),
+		attribute.DefaultEncoder(),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/K1=V1/":     100,
+		"counter.sum/K1=V2/":     101,
+		"gauge.lastvalue/K1=V1/": 10,
+	}, got)
+}
@@ -15,6 +15,7 @@
 package basic // import "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -22,9 +23,16 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 type (
@@ -50,12 +58,16 @@ type (
 		// https://github.com/open-telemetry/opentelemetry-go/issues/862.
 		descriptor *sdkapi.Descriptor
 		distinct   attribute.Distinct
+		resource   attribute.Distinct
 	}
 
 	stateValue struct {
 		// attrs corresponds to the stateKey.distinct field.
 		attrs *attribute.Set
 
+		// resource corresponds to the stateKey.resource field.
+		resource *resource.Resource
+
 		// updated indicates the last sequence number when this value had
 		// Process() called by an accumulator.
 		updated int64
@@ -82,6 +94,31 @@ type (
 		// by the processor used to store the last cumulative
 		// value.
 		cumulative aggregator.Aggregator
+
+		// delta, if non-nil, refers to an Aggregator owned by
+		// the processor holding the delta computed from the
+		// last two sums of a precomputed-sum instrument.
+		delta aggregator.Aggregator
+
+		// previous is the last sum of a precomputed-sum
+		// instrument, used to compute delta.
+		previous number.Number
+	}
+
+	// viewState is the outcome of applying the configured views to an
+	// instrument.
+	viewState struct {
+		// descriptor is the descriptor the instrument data is
+		// exported with.
+		descriptor *sdkapi.Descriptor
+
+		// filter is the filter of the attributes the instrument
+		// data is exported with, or nil to keep all attributes.
+		filter attribute.Filter
+
+		// cardinalityLimit is the maximum number of attribute sets
+		// the instrument data is exported with, or 0 if unlimited.
+		cardinalityLimit int
 	}
 
 	state struct {
@@ -91,6 +128,14 @@ type (
 		sync.RWMutex
 		values map[stateKey]*stateValue
 
+		// views caches the viewState of processed instruments when
+		// views are configured.
+		views map[*sdkapi.Descriptor]viewState
+
+		// series holds the attribute sets exported for the
+		// instruments with a cardinality limit.
+		series map[*sdkapi.Descriptor]map[attribute.Distinct]struct{}
+
 		processStart  time.Time
 		intervalStart time.Time
 		intervalEnd   time.Time
@@ -152,6 +197,8 @@ func (f factory) NewCheckpointer() export.Checkpointer {
 		TemporalitySelector: f.tselector,
 		state: state{
 			values:        map[stateKey]*stateValue{},
+			views:         map[*sdkapi.Descriptor]viewState{},
+			series:        map[*sdkapi.Descriptor]map[attribute.Distinct]struct{}{},
 			processStart:  now,
 			intervalStart: now,
 			config:        f.config,
@@ -166,9 +213,21 @@ func (b *Processor) Process(accum export.Accumulation) error {
 		return ErrInconsistentState
 	}
 	desc := accum.Descriptor()
+	attrs := accum.Attributes()
+	if len(b.config.Views) > 0 {
+		vs := b.viewStateFor(desc)
+		if vs.filter != nil {
+			reduced, _ := attrs.Filter(vs.filter)
+			attrs = &reduced
+		}
+		if vs.cardinalityLimit > 0 {
+			attrs = b.limitCardinality(desc, attrs, vs.cardinalityLimit)
+		}
+	}
 	key := stateKey{
 		descriptor: desc,
-		distinct:   accum.Attributes().Equivalent(),
+		distinct:   attrs.Equivalent(),
+		resource:   accum.Resource().Equivalent(),
 	}
 	agg := accum.Aggregator()
 
@@ -178,20 +237,26 @@ func (b *Processor) Process(accum export.Accumulation) error {
 		stateful := b.TemporalityFor(desc, agg.Aggregation().Kind()).MemoryRequired(desc.InstrumentKind())
 
 		newValue := &stateValue{
-			attrs:    accum.Attributes(),
+			attrs:    attrs,
+			resource: accum.Resource(),
 			updated:  b.state.finishedCollection,
 			stateful: stateful,
 			current:  agg,
 		}
 		if stateful {
 			if desc.InstrumentKind().PrecomputedSum() {
-				// To convert precomputed sums to
-				// deltas requires two aggregators to
-				// be allocated, one for the prior
-				// value and one for the output delta.
-				// This functionality was removed from
-				// the basic processor in PR #2350.
-				return aggregation.ErrNoCumulativeToDelta
+				// Converting precomputed sums to
+				// deltas is only supported for sums,
+				// when enabled.
+				if !b.config.CumulativeToDelta || agg.Aggregation().Kind() != aggregation.SumKind {
+					return aggregation.ErrNoCumulativeToDelta
+				}
+				// Allocate one aggregator to output
+				// the delta, the prior sum is kept as
+				// a number.
+				b.AggregatorFor(desc, &newValue.delta)
+				b.state.values[key] = newValue
+				return nil
 			}
 			// In this case allocate one aggregator to
 			// save the current state.
@@ -251,7 +316,7 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	// before merging below.
 	if !value.currentOwned {
 		tmp := value.current
-		b.AggregatorSelector.AggregatorFor(desc, &value.current)
+		b.AggregatorFor(desc, &value.current)
 		value.currentOwned = true
 		if err := tmp.SynchronizedMove(value.current, desc); err != nil {
 			return err
@@ -262,6 +327,87 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	return value.current.Merge(agg, desc)
 }
 
+// AggregatorFor implements export.AggregatorSelector. The aggregators of
+// instruments matched by a View changing their aggregation are allocated
+// according to the View, all others are allocated by the AggregatorSelector
+// of the Processor.
+func (b *Processor) AggregatorFor(desc *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	if v, ok := b.config.viewFor(desc); ok && v.Aggregation() != "" {
+		viewAggregators(v.Aggregation(), desc, aggPtrs)
+		return
+	}
+	b.AggregatorSelector.AggregatorFor(desc, aggPtrs...)
+}
+
+// viewAggregators allocates aggregators of kind into aggPtrs.
+func viewAggregators(kind aggregation.Kind, desc *sdkapi.Descriptor, aggPtrs []*aggregator.Aggregator) {
+	switch kind {
+	case aggregation.SumKind:
+		aggs := sum.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.LastValueKind:
+		aggs := lastvalue.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.HistogramKind:
+		aggs := histogram.New(len(aggPtrs), desc)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.ExponentialHistogramKind:
+		aggs := exponential.New(len(aggPtrs), desc)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	}
+}
+
+// viewStateFor returns the viewState of the instrument described by desc.
+// This must be called with the lock held.
+func (b *state) viewStateFor(desc *sdkapi.Descriptor) viewState {
+	if vs, ok := b.views[desc]; ok {
+		return vs
+	}
+	vs := viewState{descriptor: desc}
+	if v, ok := b.config.viewFor(desc); ok {
+		if name := v.Name(desc.Name()); name != desc.Name() {
+			renamed := sdkapi.NewDescriptor(name, desc.InstrumentKind(), desc.NumberKind(), desc.Description(), desc.Unit())
+			vs.descriptor = &renamed
+		}
+		vs.filter = v.AttributeFilter()
+		vs.cardinalityLimit = v.CardinalityLimit()
+	}
+	b.views[desc] = vs
+	return vs
+}
+
+// overflowAttrs are the attributes of the series of the attribute sets
+// exceeding the cardinality limit of a View.
+var overflowAttrs = attribute.NewSet(view.OverflowKey.Bool(true))
+
+// limitCardinality returns attrs if it is one of the first limit attribute
+// sets of the instrument described by desc, and the overflow attributes
+// otherwise. This must be called with the lock held.
+func (b *state) limitCardinality(desc *sdkapi.Descriptor, attrs *attribute.Set, limit int) *attribute.Set {
+	series, ok := b.series[desc]
+	if !ok {
+		series = make(map[attribute.Distinct]struct{})
+		b.series[desc] = series
+	}
+	distinct := attrs.Equivalent()
+	if _, ok := series[distinct]; ok {
+		return attrs
+	}
+	if len(series) >= limit {
+		return &overflowAttrs
+	}
+	series[distinct] = struct{}{}
+	return attrs
+}
+
 // Reader returns the associated Reader.  Use the
 // Reader Locker interface to synchronize access to this
 // object.  The Reader.ForEach() method cannot be called
@@ -297,6 +443,12 @@ func (b *Processor) FinishCollection() error {
 		// The following branch updates stateful aggregators.  Skip
 		// these updates if the aggregator is not stateful or if the
 		// aggregator is stale.
+		if stale && !stateless && mkind.PrecomputedSum() {
+			// Nothing changed since the prior collection.
+			if err := value.delta.SynchronizedMove(nil, key.descriptor); err != nil {
+				return err
+			}
+		}
 		if stale || stateless {
 			// If this processor does not require memeory,
 			// stale, stateless entries can be removed.
@@ -304,14 +456,21 @@ func (b *Processor) FinishCollection() error {
 			// over the previous full collection interval.
 			if stale && stateless && !b.config.Memory {
 				delete(b.values, key)
+				// The attribute set no longer counts
+				// against the cardinality limit.
+				delete(b.series[key.descriptor], key.distinct)
 			}
 			continue
 		}
 
-		// The only kind of aggregators that are not stateless
-		// are the ones needing delta to cumulative
-		// conversion.  Merge aggregator state in this case.
-		if !mkind.PrecomputedSum() {
+		// The aggregators that are not stateless are the ones
+		// needing delta to cumulative conversion or, when
+		// enabled, cumulative to delta conversion.
+		if mkind.PrecomputedSum() {
+			if err := value.computeDelta(key.descriptor); err != nil {
+				return err
+			}
+		} else {
 			// This line is equivalent to:
 			// value.cumulative = value.cumulative + value.current
 			if err := value.cumulative.Merge(value.current, key.descriptor); err != nil {
@@ -322,6 +481,37 @@ func (b *Processor) FinishCollection() error {
 	return nil
 }
 
+// computeDelta sets the delta aggregator of v to the difference between the
+// current sum and the prior one. A monotonic sum lower than the prior one is
+// a reset, the current sum is then the delta.
+func (v *stateValue) computeDelta(desc *sdkapi.Descriptor) error {
+	s, ok := v.current.Aggregation().(aggregation.Sum)
+	if !ok {
+		return aggregation.ErrNoCumulativeToDelta
+	}
+	current, err := s.Sum()
+	if err != nil {
+		return err
+	}
+
+	kind := desc.NumberKind()
+	var delta number.Number
+	if kind == number.Float64Kind {
+		delta = number.NewFloat64Number(current.AsFloat64() - v.previous.AsFloat64())
+	} else {
+		delta = number.NewInt64Number(current.AsInt64() - v.previous.AsInt64())
+	}
+	if desc.InstrumentKind().Monotonic() && delta.IsNegative(kind) {
+		delta = current
+	}
+	v.previous = current
+
+	if err := v.delta.SynchronizedMove(nil, desc); err != nil {
+		return err
+	}
+	return v.delta.Update(context.Background(), delta, desc)
+}
+
 // ForEach iterates through the Reader, passing an
 // export.Record with the appropriate Cumulative or Delta aggregation
 // to an exporter.
@@ -342,7 +532,7 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 			// If stateful, the sum has been computed.  If stateless, the
 			// input was already cumulative.  Either way, use the checkpointed
 			// value:
-			if value.stateful {
+			if value.stateful && !mkind.PrecomputedSum() {
 				agg = value.cumulative.Aggregation()
 			} else {
 				agg = value.current.Aggregation()
@@ -350,13 +540,16 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 			start = b.processStart
 
 		case aggregation.DeltaTemporality:
-			// Precomputed sums are a special case.
+			// Precomputed sums are a special case, they
+			// are only converted when stateful.
 			if mkind.PrecomputedSum() {
-				// This functionality was removed from
-				// the basic processor in PR #2350.
-				return aggregation.ErrNoCumulativeToDelta
+				if !value.stateful {
+					return aggregation.ErrNoCumulativeToDelta
+				}
+				agg = value.delta.Aggregation()
+			} else {
+				agg = value.current.Aggregation()
 			}
-			agg = value.current.Aggregation()
 			start = b.intervalStart
 
 		default:
@@ -369,9 +562,15 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 			continue
 		}
 
-		if err := f(export.NewRecord(
-			key.descriptor,
+		desc := key.descriptor
+		if vs, ok := b.views[desc]; ok {
+			desc = vs.descriptor
+		}
+
+		if err := f(export.NewRecordWithResource(
+			desc,
 			value.attrs,
+			value.resource,
 			agg,
 			start,
 			b.intervalEnd,
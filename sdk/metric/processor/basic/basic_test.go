@@ -37,6 +37,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/metric/view"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -398,6 +399,39 @@ func TestStatefulNoMemoryCumulative(t *testing.T) {
 	}
 }
 
+func TestResourceRecords(t *testing.T) {
+	aggTempSel := aggregation.CumulativeTemporalitySelector()
+	desc := metrictest.NewDescriptor("observe.sum", sdkapi.CounterObserverInstrumentKind, number.Int64Kind)
+	selector := processortest.AggregatorSelector()
+
+	processor := basic.New(selector, aggTempSel)
+	reader := processor.Reader()
+
+	res := resource.NewSchemaless(attribute.String("tenant", "a"))
+	withResource := func(acc export.Accumulation, res *resource.Resource) export.Accumulation {
+		return export.NewAccumulationWithResource(acc.Descriptor(), acc.Attributes(), res, acc.Aggregator())
+	}
+
+	processor.StartCollection()
+	require.NoError(t, processor.Process(withResource(updateFor(t, &desc, selector, 10, attribute.String("A", "B")), res)))
+	require.NoError(t, processor.Process(updateFor(t, &desc, selector, 20, attribute.String("A", "B"))))
+	require.NoError(t, processor.FinishCollection())
+
+	found := map[string]bool{}
+	require.NoError(t, reader.ForEach(aggTempSel, func(rec export.Record) error {
+		found[rec.Resource().Encoded(attribute.DefaultEncoder())] = true
+		return nil
+	}))
+	require.Equal(t, map[string]bool{"tenant=a": true, "": true}, found)
+
+	records := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, reader.ForEach(aggTempSel, records.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"observe.sum/A=B/tenant=a": 10,
+		"observe.sum/A=B/":         20,
+	}, records.Map())
+}
+
 func TestMultiObserverSum(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -439,6 +473,79 @@ func TestMultiObserverSum(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDelta(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		kind   sdkapi.InstrumentKind
+		inputs []int64
+		want   []float64
+	}{
+		{
+			name:   "counter",
+			kind:   sdkapi.CounterObserverInstrumentKind,
+			inputs: []int64{10, 25, 25, 40},
+			want:   []float64{10, 15, 0, 15},
+		},
+		{
+			name:   "counter reset",
+			kind:   sdkapi.CounterObserverInstrumentKind,
+			inputs: []int64{10, 25, 5, 12},
+			want:   []float64{10, 15, 5, 7},
+		},
+		{
+			name:   "up-down counter",
+			kind:   sdkapi.UpDownCounterObserverInstrumentKind,
+			inputs: []int64{10, 25, 5, 12},
+			want:   []float64{10, 15, -20, 7},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			aggTempSel := aggregation.DeltaTemporalitySelector()
+			desc := metrictest.NewDescriptor("observe.sum", test.kind, number.Int64Kind)
+			selector := processortest.AggregatorSelector()
+
+			processor := basic.New(selector, aggTempSel, basic.WithCumulativeToDelta(true))
+			reader := processor.Reader()
+
+			for i, input := range test.inputs {
+				processor.StartCollection()
+				require.NoError(t, processor.Process(updateFor(t, &desc, selector, input, attribute.String("A", "B"))))
+				require.NoError(t, processor.FinishCollection())
+
+				records := processortest.NewOutput(attribute.DefaultEncoder())
+				require.NoError(t, reader.ForEach(aggTempSel, records.AddRecord))
+				require.EqualValues(t, map[string]float64{
+					"observe.sum/A=B/": test.want[i],
+				}, records.Map(), "collection %d", i)
+			}
+		})
+	}
+}
+
+func TestCumulativeToDeltaMemory(t *testing.T) {
+	aggTempSel := aggregation.DeltaTemporalitySelector()
+	desc := metrictest.NewDescriptor("observe.sum", sdkapi.CounterObserverInstrumentKind, number.Float64Kind)
+	selector := processortest.AggregatorSelector()
+
+	processor := basic.New(selector, aggTempSel, basic.WithCumulativeToDelta(true), basic.WithMemory(true))
+	reader := processor.Reader()
+
+	collect := func(process bool) map[string]float64 {
+		processor.StartCollection()
+		if process {
+			require.NoError(t, processor.Process(updateFor(t, &desc, selector, 10)))
+		}
+		require.NoError(t, processor.FinishCollection())
+		records := processortest.NewOutput(attribute.DefaultEncoder())
+		require.NoError(t, reader.ForEach(aggTempSel, records.AddRecord))
+		return records.Map()
+	}
+
+	require.Equal(t, map[string]float64{"observe.sum//": 10}, collect(true))
+	// A series not observed in a collection has no delta.
+	require.Equal(t, map[string]float64{"observe.sum//": 0}, collect(false))
+}
+
 func TestCounterObserverEndToEnd(t *testing.T) {
 	ctx := context.Background()
 	eselector := aggregation.CumulativeTemporalitySelector()
@@ -508,3 +615,127 @@ func TestCounterObserverEndToEnd(t *testing.T) {
 	requireNotAfter(t, endTime[0], endTime[1])
 	requireNotAfter(t, endTime[1], endTime[2])
 }
+
+func TestViews(t *testing.T) {
+	ctx := context.Background()
+	rename, err := view.New("legacy.*", view.WithRename("requests.sum"))
+	require.NoError(t, err)
+	reaggregate, err := view.New("latency.histogram", view.WithAggregation(aggregation.SumKind))
+	require.NoError(t, err)
+	prune, err := view.New("bytes.sum", view.WithKeepAttributes("host"))
+	require.NoError(t, err)
+
+	eselector := aggregation.CumulativeTemporalitySelector()
+	proc := basic.New(
+		processortest.AggregatorSelector(),
+		eselector,
+		basic.WithViews(rename, reaggregate, prune),
+	)
+	accum := sdk.NewAccumulator(proc)
+	meter := sdkapi.WrapMeterImpl(accum)
+
+	requests, err := meter.SyncInt64().Counter("legacy.requests.sum")
+	require.NoError(t, err)
+	latency, err := meter.SyncInt64().Histogram("latency.histogram")
+	require.NoError(t, err)
+	bytes, err := meter.SyncInt64().Counter("bytes.sum")
+	require.NoError(t, err)
+
+	for i := 1; i <= 2; i++ {
+		requests.Add(ctx, 1, attribute.String("A", "B"))
+		latency.Record(ctx, 10)
+		latency.Record(ctx, 20)
+		bytes.Add(ctx, 100, attribute.String("host", "a"), attribute.String("path", "/x"))
+		bytes.Add(ctx, 200, attribute.String("host", "a"), attribute.String("path", "/y"))
+
+		reader := proc.Reader()
+		reader.Lock()
+		proc.StartCollection()
+		accum.Collect(ctx)
+		require.NoError(t, proc.FinishCollection())
+
+		got := map[string]float64{}
+		kinds := map[string]aggregation.Kind{}
+		require.NoError(t, reader.ForEach(eselector, func(rec export.Record) error {
+			name := fmt.Sprint(rec.Descriptor().Name(), "/", rec.Attributes().Encoded(attribute.DefaultEncoder()))
+			s, ok := rec.Aggregation().(aggregation.Sum)
+			require.True(t, ok, "%s is not a sum", name)
+			sum, err := s.Sum()
+			require.NoError(t, err)
+			got[name] = sum.CoerceToFloat64(rec.Descriptor().NumberKind())
+			kinds[name] = rec.Aggregation().Kind()
+			return nil
+		}))
+		reader.Unlock()
+
+		require.EqualValues(t, map[string]float64{
+			"requests.sum/A=B":   float64(i),
+			"latency.histogram/": float64(30 * i),
+			"bytes.sum/host=a":   float64(300 * i),
+		}, got)
+		require.Equal(t, aggregation.SumKind, kinds["latency.histogram/"])
+	}
+}
+
+func TestViewsCardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+	v, err := view.New("requests.sum", view.WithCardinalityLimit(2))
+	require.NoError(t, err)
+
+	eselector := aggregation.CumulativeTemporalitySelector()
+	proc := basic.New(
+		processortest.AggregatorSelector(),
+		eselector,
+		basic.WithViews(v),
+	)
+	accum := sdk.NewAccumulator(proc)
+	meter := sdkapi.WrapMeterImpl(accum)
+
+	requests, err := meter.SyncInt64().Counter("requests.sum")
+	require.NoError(t, err)
+
+	requests.Add(ctx, 1, attribute.String("path", "/a"))
+	requests.Add(ctx, 2, attribute.String("path", "/b"))
+	requests.Add(ctx, 4, attribute.String("path", "/c"))
+
+	reader := proc.Reader()
+	reader.Lock()
+	defer reader.Unlock()
+	proc.StartCollection()
+	accum.Collect(ctx)
+	require.NoError(t, proc.FinishCollection())
+
+	got := map[string]int64{}
+	require.NoError(t, reader.ForEach(eselector, func(rec export.Record) error {
+		sum, err := rec.Aggregation().(aggregation.Sum).Sum()
+		require.NoError(t, err)
+		got[rec.Attributes().Encoded(attribute.DefaultEncoder())] = sum.AsInt64()
+		return nil
+	}))
+	// The accumulator does not process the attribute sets in order, any two
+	// of them get a series of their own and the third overflows.
+	require.Len(t, got, 3)
+	overflow, ok := got["otel.metric.overflow=true"]
+	require.True(t, ok, "no overflow series in %v", got)
+	var total int64
+	for _, s := range got {
+		total += s
+	}
+	require.Equal(t, int64(7), total)
+	require.Contains(t, []int64{1, 2, 4}, overflow)
+}
+
+func TestViewsSelectAggregators(t *testing.T) {
+	v, err := view.New("*", view.WithAggregation(aggregation.LastValueKind))
+	require.NoError(t, err)
+	proc := basic.New(
+		processortest.AggregatorSelector(),
+		aggregation.CumulativeTemporalitySelector(),
+		basic.WithViews(v),
+	)
+
+	desc := metrictest.NewDescriptor("inst.sum", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	var agg aggregator.Aggregator
+	proc.AggregatorFor(&desc, &agg)
+	require.Equal(t, aggregation.LastValueKind, agg.Aggregation().Kind())
+}
@@ -14,6 +14,11 @@
 
 package basic // import "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 
+import (
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+)
+
 // config contains the options for configuring a basic metric processor.
 type config struct {
 	// Memory controls whether the processor remembers metric instruments and
@@ -21,6 +26,25 @@ type config struct {
 	// Reader.ForEach() will visit metrics that were not updated in the most
 	// recent interval.
 	Memory bool
+
+	// Views are the views applied to the processed instruments.
+	Views []view.View
+
+	// CumulativeToDelta controls whether the processor converts the
+	// cumulative sums of precomputed-sum instruments to deltas when delta
+	// temporality is selected for them.
+	CumulativeToDelta bool
+}
+
+// viewFor returns the first of the configured Views matching the instrument
+// described by desc, if any.
+func (c config) viewFor(desc *sdkapi.Descriptor) (view.View, bool) {
+	for _, v := range c.Views {
+		if v.Matches(desc.Name()) {
+			return v, true
+		}
+	}
+	return view.View{}, false
 }
 
 // Option configures a basic processor configuration.
@@ -41,3 +65,37 @@ func (m memoryOption) applyProcessor(cfg config) config {
 	cfg.Memory = bool(m)
 	return cfg
 }
+
+// WithViews sets the views of a Processor. The data of each instrument is
+// processed according to the first of the views matching it, or unchanged
+// if none match.
+func WithViews(views ...view.View) Option {
+	return viewsOption(views)
+}
+
+type viewsOption []view.View
+
+func (v viewsOption) applyProcessor(cfg config) config {
+	cfg.Views = append(cfg.Views, v...)
+	return cfg
+}
+
+// WithCumulativeToDelta sets whether a Processor converts the cumulative sums
+// reported by precomputed-sum instruments, such as CounterObservers, to
+// deltas when delta temporality is selected for them. The prior sum of each
+// series is remembered and subtracted from the next one. A monotonic sum
+// lower than the prior one is treated as a reset, its value is then the
+// delta.
+//
+// Without this option, selecting delta temporality for these instruments
+// results in aggregation.ErrNoCumulativeToDelta.
+func WithCumulativeToDelta(enabled bool) Option {
+	return cumulativeToDeltaOption(enabled)
+}
+
+type cumulativeToDeltaOption bool
+
+func (c cumulativeToDeltaOption) applyProcessor(cfg config) config {
+	cfg.CumulativeToDelta = bool(c)
+	return cfg
+}
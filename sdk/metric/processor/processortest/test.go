@@ -221,9 +221,10 @@ func NewOutput(attrEncoder attribute.Encoder) *Output {
 // ForEach implements export.Reader.
 func (o *Output) ForEach(_ aggregation.TemporalitySelector, ff func(export.Record) error) error {
 	for key, value := range o.m {
-		if err := ff(export.NewRecord(
+		if err := ff(export.NewRecordWithResource(
 			key.desc,
 			value.attrs,
+			value.resource,
 			value.aggregator.Aggregation(),
 			time.Time{},
 			time.Time{},
@@ -248,7 +249,14 @@ func (o *Output) AddInstrumentationLibraryRecord(_ instrumentation.Library, rec
 }
 
 // AddRecordWithResource merges rec into this Output scoping it with res.
+// The Resource of rec, if any, is merged into res.
 func (o *Output) AddRecordWithResource(rec export.Record, res *resource.Resource) error {
+	if r := rec.Resource(); r != nil {
+		var err error
+		if res, err = resource.Merge(res, r); err != nil {
+			return err
+		}
+	}
 	key := mapKey{
 		desc:     rec.Descriptor(),
 		attrs:    rec.Attributes().Equivalent(),
@@ -309,9 +317,10 @@ func (o *Output) Reset() {
 // Aggregator().Aggregation(), whichever is defined.
 func (o *Output) AddAccumulation(acc export.Accumulation) error {
 	return o.AddRecord(
-		export.NewRecord(
+		export.NewRecordWithResource(
 			acc.Descriptor(),
 			acc.Attributes(),
+			acc.Resource(),
 			acc.Aggregator().Aggregation(),
 			time.Time{},
 			time.Time{},
@@ -57,9 +57,10 @@ func (p *Processor) Process(accum export.Accumulation) error {
 		),
 	)
 	return p.Checkpointer.Process(
-		export.NewAccumulation(
+		export.NewAccumulationWithResource(
 			accum.Descriptor(),
 			&reduced,
+			accum.Resource(),
 			accum.Aggregator(),
 		),
 	)
@@ -17,6 +17,7 @@ package registry // import "go.opentelemetry.io/otel/sdk/metric/registry"
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"go.opentelemetry.io/otel/metric/instrument"
@@ -137,3 +138,22 @@ func (u *UniqueInstrumentMeterImpl) RegisterCallback(insts []instrument.Asynchro
 
 	return u.impl.RegisterCallback(insts, callback)
 }
+
+// Instruments returns the descriptors of all instruments currently
+// registered, sorted by name. The returned slice is a snapshot, instruments
+// created after this returns are not included.
+//
+// This is safe to call concurrently with the creation of instruments.
+func (u *UniqueInstrumentMeterImpl) Instruments() []sdkapi.Descriptor {
+	u.lock.Lock()
+	descs := make([]sdkapi.Descriptor, 0, len(u.state))
+	for _, impl := range u.state {
+		descs = append(descs, impl.Descriptor())
+	}
+	u.lock.Unlock()
+
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].Name() < descs[j].Name()
+	})
+	return descs
+}
@@ -16,11 +16,15 @@ package registry_test
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/registry"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
@@ -110,3 +114,58 @@ func TestRegistryDiffInstruments(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistryInstruments(t *testing.T) {
+	meter := testMeterWithRegistry("meter")
+	reg := sdkapi.UnwrapMeterImpl(meter).(*registry.UniqueInstrumentMeterImpl)
+	require.Empty(t, reg.Instruments())
+
+	_, err := meter.SyncInt64().Counter("b.counter", instrument.WithUnit(unit.Bytes))
+	require.NoError(t, err)
+	_, err = meter.AsyncFloat64().Gauge("a.gauge", instrument.WithDescription("gauge"))
+	require.NoError(t, err)
+	// Registering the same instrument again does not duplicate it.
+	_, err = meter.SyncInt64().Counter("b.counter", instrument.WithUnit(unit.Bytes))
+	require.NoError(t, err)
+
+	got := reg.Instruments()
+	require.Len(t, got, 2)
+
+	require.Equal(t, "a.gauge", got[0].Name())
+	require.Equal(t, sdkapi.GaugeObserverInstrumentKind, got[0].InstrumentKind())
+	require.Equal(t, "gauge", got[0].Description())
+
+	require.Equal(t, "b.counter", got[1].Name())
+	require.Equal(t, sdkapi.CounterInstrumentKind, got[1].InstrumentKind())
+	require.Equal(t, unit.Bytes, got[1].Unit())
+}
+
+func TestRegistryInstrumentsConcurrent(t *testing.T) {
+	meter := testMeterWithRegistry("meter")
+	reg := sdkapi.UnwrapMeterImpl(meter).(*registry.UniqueInstrumentMeterImpl)
+
+	const n = 100
+	var (
+		wg   sync.WaitGroup
+		errs []error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			if _, err := meter.SyncInt64().Counter(fmt.Sprintf("counter.%d", i)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			_ = reg.Instruments()
+		}
+	}()
+	wg.Wait()
+
+	require.Empty(t, errs)
+	require.Len(t, reg.Instruments(), n)
+}
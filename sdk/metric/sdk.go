@@ -23,11 +23,13 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/export"
 	"go.opentelemetry.io/otel/sdk/metric/number"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 type (
@@ -56,8 +58,25 @@ type (
 
 		// collectLock prevents simultaneous calls to Collect().
 		collectLock sync.Mutex
+
+		// disabled holds the *baseInstrument of every instrument
+		// disabled by the AggregatorSelector that had an update
+		// dropped.
+		disabled sync.Map
+
+		// contextAttributes returns the attributes added to every
+		// synchronous measurement from its context. If nil, no
+		// attributes are added.
+		contextAttributes func(context.Context) []attribute.KeyValue
 	}
 
+	// AccumulatorOption configures an Accumulator.
+	AccumulatorOption interface {
+		apply(*Accumulator)
+	}
+
+	accumulatorOptionFunc func(*Accumulator)
+
 	callback struct {
 		insts map[*asyncInstrument]struct{}
 		f     func(context.Context)
@@ -65,6 +84,8 @@ type (
 
 	asyncContextKey struct{}
 
+	resourceContextKey struct{}
+
 	asyncInstrument struct {
 		baseInstrument
 		instrument.Asynchronous
@@ -76,10 +97,11 @@ type (
 	}
 
 	// mapkey uniquely describes a metric instrument in terms of its
-	// InstrumentID and the encoded form of its attributes.
+	// InstrumentID and the encoded form of its attributes and resource.
 	mapkey struct {
 		descriptor *sdkapi.Descriptor
 		ordered    attribute.Distinct
+		resource   attribute.Distinct
 	}
 
 	// record maintains the state of one metric instrument.  Due
@@ -106,6 +128,10 @@ type (
 		// during attributes creation to avoid allocation.
 		sortSlice attribute.Sortable
 
+		// resource is the Resource observations were associated with
+		// by the callback, or nil.
+		resource *resource.Resource
+
 		// inst is a pointer to the corresponding instrument.
 		inst *baseInstrument
 
@@ -117,6 +143,11 @@ type (
 	}
 
 	baseInstrument struct {
+		// dropped is the number of updates dropped because the
+		// instrument is disabled. It needs to be aligned for 64-bit
+		// atomic operations.
+		dropped int64
+
 		meter      *Accumulator
 		descriptor sdkapi.Descriptor
 	}
@@ -131,6 +162,10 @@ var (
 	// ErrBadInstrument is returned when an instrument from another SDK is
 	// attempted to be registered with this SDK.
 	ErrBadInstrument = fmt.Errorf("use of a instrument from another SDK")
+
+	// ErrCallbackPanic is passed to the global error handler, wrapped with
+	// the recovered value, when a registered callback panics.
+	ErrCallbackPanic = fmt.Errorf("panic in asynchronous instrument callback")
 )
 
 func (b *baseInstrument) Descriptor() sdkapi.Descriptor {
@@ -146,19 +181,31 @@ func (s *syncInstrument) Implementation() interface{} {
 }
 
 // acquireHandle gets or creates a `*record` corresponding to `kvs`,
-// the input attributes.
-func (b *baseInstrument) acquireHandle(kvs []attribute.KeyValue) *record {
+// the input attributes, and res, the input resource.
+func (b *baseInstrument) acquireHandle(kvs []attribute.KeyValue, res *resource.Resource) *record {
 	// This memory allocation may not be used, but it's
 	// needed for the `sortSlice` field, to avoid an
 	// allocation while sorting.
-	rec := &record{}
+	rec := &record{resource: res}
 	rec.attrs = attribute.NewSetWithSortable(kvs, &rec.sortSlice)
+	return b.acquireRecord(rec)
+}
 
+// acquireSetHandle is like acquireHandle for the already built attribute
+// set, it does not need to sort the attributes.
+func (b *baseInstrument) acquireSetHandle(set *attribute.Set, res *resource.Resource) *record {
+	return b.acquireRecord(&record{resource: res, attrs: *set})
+}
+
+// acquireRecord gets the `*record` with the same attributes and resource as
+// rec, or adds rec if there is none.
+func (b *baseInstrument) acquireRecord(rec *record) *record {
 	// Create lookup key for sync.Map (one allocation, as this
 	// passes through an interface{})
 	mk := mapkey{
 		descriptor: &b.descriptor,
 		ordered:    rec.attrs.Equivalent(),
+		resource:   rec.resource.Equivalent(),
 	}
 
 	if actual, ok := b.meter.current.Load(mk); ok {
@@ -211,16 +258,47 @@ func (b *baseInstrument) acquireHandle(kvs []attribute.KeyValue) *record {
 //
 // The order of the input array `kvs` may be sorted after the function is called.
 func (s *syncInstrument) RecordOne(ctx context.Context, num number.Number, kvs []attribute.KeyValue) {
-	h := s.acquireHandle(kvs)
+	if f := s.meter.contextAttributes; f != nil {
+		kvs = mergeContextAttributes(f(ctx), kvs)
+	}
+	h := s.acquireHandle(kvs, nil)
 	defer h.unbind()
 	h.captureOne(ctx, num)
 }
 
-// ObserveOne captures a single asynchronous metric event.
+// RecordSet captures a single synchronous metric event with the attributes
+// of set. Unlike RecordOne, the attributes do not need to be sorted.
+func (s *syncInstrument) RecordSet(ctx context.Context, num number.Number, set *attribute.Set) {
+	if s.meter.contextAttributes != nil {
+		// The extracted attributes need to be merged into a new set.
+		s.RecordOne(ctx, num, set.ToSlice())
+		return
+	}
+	h := s.acquireSetHandle(set, nil)
+	defer h.unbind()
+	h.captureOne(ctx, num)
+}
 
+// RecordHistogram captures pre-aggregated histogram buckets as a single
+// synchronous metric event. An error is returned if the instrument is not
+// aggregated by a histogram with the same bucket boundaries.
+func (s *syncInstrument) RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum number.Number, kvs []attribute.KeyValue) error {
+	if f := s.meter.contextAttributes; f != nil {
+		kvs = mergeContextAttributes(f(ctx), kvs)
+	}
+	h := s.acquireHandle(kvs, nil)
+	defer h.unbind()
+	return h.captureBuckets(counts, bounds, sum)
+}
+
+// ObserveOne captures a single asynchronous metric event.
+//
+// The event is associated with the Resource of ctx, if any, see
+// ContextWithResource.
+//
 // The order of the input array `kvs` may be sorted after the function is called.
 func (a *asyncInstrument) ObserveOne(ctx context.Context, num number.Number, attrs []attribute.KeyValue) {
-	h := a.acquireHandle(attrs)
+	h := a.acquireHandle(attrs, resourceFromContext(ctx))
 	defer h.unbind()
 	h.captureOne(ctx, num)
 }
@@ -234,11 +312,44 @@ func (a *asyncInstrument) ObserveOne(ctx context.Context, num number.Number, att
 // processor will call Collect() when it receives a request to scrape
 // current metric values.  A push-based processor should configure its
 // own periodic collection.
-func NewAccumulator(processor export.Processor) *Accumulator {
-	return &Accumulator{
+func NewAccumulator(processor export.Processor, opts ...AccumulatorOption) *Accumulator {
+	m := &Accumulator{
 		processor: processor,
 		callbacks: map[*callback]struct{}{},
 	}
+	for _, opt := range opts {
+		opt.apply(m)
+	}
+	return m
+}
+
+func (f accumulatorOptionFunc) apply(m *Accumulator) {
+	f(m)
+}
+
+// WithContextAttributes returns an AccumulatorOption that adds the
+// attributes returned by f for the context of every synchronous
+// measurement to the attributes of the measurement, e.g. to add a tenant ID
+// stored in the context to all measurements. When an attribute returned by
+// f has the same key as an attribute passed with the measurement, the
+// passed attribute takes precedence.
+//
+// Asynchronous measurements are not affected.
+func WithContextAttributes(f func(context.Context) []attribute.KeyValue) AccumulatorOption {
+	return accumulatorOptionFunc(func(m *Accumulator) {
+		m.contextAttributes = f
+	})
+}
+
+// mergeContextAttributes returns the attributes extracted from the context
+// followed by kvs, so that kvs take precedence for duplicate keys.
+func mergeContextAttributes(extracted, kvs []attribute.KeyValue) []attribute.KeyValue {
+	if len(extracted) == 0 {
+		return kvs
+	}
+	merged := make([]attribute.KeyValue, 0, len(extracted)+len(kvs))
+	merged = append(merged, extracted...)
+	return append(merged, kvs...)
 }
 
 var _ sdkapi.MeterImpl = &Accumulator{}
@@ -298,17 +409,45 @@ func (m *Accumulator) RegisterCallback(insts []instrument.Asynchronous, f func(c
 //
 // Returns the number of records that were checkpointed.
 func (m *Accumulator) Collect(ctx context.Context) int {
+	return m.collect(ctx, false)
+}
+
+// CollectPartial is like Collect, except that once ctx is done, records of
+// synchronous instruments not yet visited are skipped. They keep their
+// updates and are checkpointed by the next collection. This bounds the
+// duration of a collection by the deadline of ctx.
+//
+// Returns the number of records that were checkpointed.
+func (m *Accumulator) CollectPartial(ctx context.Context) int {
+	return m.collect(ctx, true)
+}
+
+func (m *Accumulator) collect(ctx context.Context, partial bool) int {
 	m.collectLock.Lock()
 	defer m.collectLock.Unlock()
 
 	m.runAsyncCallbacks(ctx)
-	checkpointed := m.collectInstruments()
+	checkpointed := m.collectInstruments(ctx, partial)
 	m.currentEpoch++
 
 	return checkpointed
 }
 
-func (m *Accumulator) collectInstruments() int {
+// DroppedUpdates returns the number of updates dropped, by instrument name,
+// because the AggregatorSelector disabled the instrument. Only instruments
+// with dropped updates are included. A non-zero count of an instrument not
+// meant to be disabled is a sign of a misconfigured AggregatorSelector.
+func (m *Accumulator) DroppedUpdates() map[string]int64 {
+	dropped := make(map[string]int64)
+	m.disabled.Range(func(key, _ interface{}) bool {
+		inst := key.(*baseInstrument)
+		dropped[inst.descriptor.Name()] += atomic.LoadInt64(&inst.dropped)
+		return true
+	})
+	return dropped
+}
+
+func (m *Accumulator) collectInstruments(ctx context.Context, partial bool) int {
 	checkpointed := 0
 
 	m.current.Range(func(key interface{}, value interface{}) bool {
@@ -316,6 +455,13 @@ func (m *Accumulator) collectInstruments() int {
 		// map by returning `true` in this function.
 		inuse := value.(*record)
 
+		if partial && ctx.Err() != nil && inuse.inst.descriptor.InstrumentKind().Synchronous() {
+			// The collection was canceled, leave the updates of
+			// synchronous instruments for the next collection.
+			// Observations of this collection are still checkpointed.
+			return true
+		}
+
 		mods := atomic.LoadInt64(&inuse.updateCount)
 		coll := inuse.collectedCount
 
@@ -358,10 +504,22 @@ func (m *Accumulator) runAsyncCallbacks(ctx context.Context) {
 	ctx = context.WithValue(ctx, asyncContextKey{}, m)
 
 	for cb := range m.callbacks {
-		cb.f(ctx)
+		cb.run(ctx)
 	}
 }
 
+// run calls the callback function. A panic in the function is recovered
+// and handled as an error so that the remaining callbacks and instruments
+// are still collected.
+func (cb *callback) run(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			otel.Handle(fmt.Errorf("%w: %v", ErrCallbackPanic, r))
+		}
+	}()
+	cb.f(ctx)
+}
+
 func (m *Accumulator) checkpointRecord(r *record) int {
 	if r.current == nil {
 		return 0
@@ -372,7 +530,7 @@ func (m *Accumulator) checkpointRecord(r *record) int {
 		return 0
 	}
 
-	a := export.NewAccumulation(&r.inst.descriptor, &r.attrs, r.checkpoint)
+	a := export.NewAccumulationWithResource(&r.inst.descriptor, &r.attrs, r.resource, r.checkpoint)
 	err = m.processor.Process(a)
 	if err != nil {
 		otel.Handle(err)
@@ -383,6 +541,7 @@ func (m *Accumulator) checkpointRecord(r *record) int {
 func (r *record) captureOne(ctx context.Context, num number.Number) {
 	if r.current == nil {
 		// The instrument is disabled according to the AggregatorSelector.
+		r.inst.drop()
 		return
 	}
 	if err := aggregator.RangeTest(num, &r.inst.descriptor); err != nil {
@@ -398,6 +557,40 @@ func (r *record) captureOne(ctx context.Context, num number.Number) {
 	atomic.AddInt64(&r.updateCount, 1)
 }
 
+// bucketMerger is implemented by the aggregators that merge pre-aggregated
+// histogram buckets.
+type bucketMerger interface {
+	MergeBuckets(counts []uint64, bounds []float64, sum number.Number, desc *sdkapi.Descriptor) error
+}
+
+func (r *record) captureBuckets(counts []uint64, bounds []float64, sum number.Number) error {
+	if r.current == nil {
+		// The instrument is disabled according to the AggregatorSelector.
+		r.inst.drop()
+		return nil
+	}
+	m, ok := r.current.(bucketMerger)
+	if !ok {
+		return fmt.Errorf("%w: %s is aggregated by %T", instrument.ErrBucketsUnsupported, r.inst.descriptor.Name(), r.current)
+	}
+	if err := aggregator.RangeTest(sum, &r.inst.descriptor); err != nil {
+		return err
+	}
+	if err := m.MergeBuckets(counts, bounds, sum, &r.inst.descriptor); err != nil {
+		return err
+	}
+	atomic.AddInt64(&r.updateCount, 1)
+	return nil
+}
+
+// drop counts an update dropped because b is disabled.
+func (b *baseInstrument) drop() {
+	if atomic.AddInt64(&b.dropped, 1) == 1 {
+		b.meter.disabled.Store(b, struct{}{})
+		global.Info("dropping updates of instrument disabled by the AggregatorSelector", "instrument", b.descriptor.Name())
+	}
+}
+
 func (r *record) unbind() {
 	r.refMapped.unref()
 }
@@ -406,9 +599,35 @@ func (r *record) mapkey() mapkey {
 	return mapkey{
 		descriptor: &r.inst.descriptor,
 		ordered:    r.attrs.Equivalent(),
+		resource:   r.resource.Equivalent(),
 	}
 }
 
+// ContextWithResource returns a copy of parent that associates the
+// observations made with it by asynchronous instruments with res.
+//
+// This is meant to be used in callbacks registered with a Meter to report
+// observations on behalf of different entities, e.g. tenants, from a single
+// process:
+//
+//	ctx = metric.ContextWithResource(ctx, tenantResource)
+//	counter.Observe(ctx, value)
+//
+// The resulting records are exported with res merged into the Resource
+// configured for the controller, the attributes of res taking precedence.
+// Observations of the same instrument and attributes with different
+// resources are aggregated separately. Synchronous instruments ignore res.
+func ContextWithResource(parent context.Context, res *resource.Resource) context.Context {
+	return context.WithValue(parent, resourceContextKey{}, res)
+}
+
+// resourceFromContext returns the Resource of ctx set with
+// ContextWithResource, or nil.
+func resourceFromContext(ctx context.Context) *resource.Resource {
+	res, _ := ctx.Value(resourceContextKey{}).(*resource.Resource)
+	return res
+}
+
 // fromSync gets an async implementation object, checking for
 // uninitialized instruments and instruments created by another SDK.
 func (m *Accumulator) fromAsync(async sdkapi.AsyncImpl) (*asyncInstrument, error) {
@@ -61,6 +61,22 @@ type SyncImpl interface {
 	RecordOne(ctx context.Context, n number.Number, attrs []attribute.KeyValue)
 }
 
+// SyncSetImpl is implemented by the SyncImpls that capture synchronous metric
+// events with a pre-built attribute.Set.
+type SyncSetImpl interface {
+	// RecordSet captures a single synchronous metric event with the
+	// attributes of set.
+	RecordSet(ctx context.Context, n number.Number, set *attribute.Set)
+}
+
+// SyncHistogramImpl is implemented by the SyncImpls that capture
+// pre-aggregated histogram buckets.
+type SyncHistogramImpl interface {
+	// RecordHistogram captures the bucket counts delimited by bounds,
+	// whose values sum to sum, as a single synchronous metric event.
+	RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum number.Number, attrs []attribute.KeyValue) error
+}
+
 // AsyncImpl is an implementation-level interface to an
 // asynchronous instrument (e.g., Observer instruments).
 type AsyncImpl interface {
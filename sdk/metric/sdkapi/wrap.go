@@ -170,6 +170,55 @@ func (a iRecorder) Record(ctx context.Context, value int64, attrs ...attribute.K
 	}
 }
 
+func (a fAdder) AddSet(ctx context.Context, value float64, set *attribute.Set) {
+	recordSet(ctx, a.SyncImpl, number.NewFloat64Number(value), set)
+}
+
+func (a iAdder) AddSet(ctx context.Context, value int64, set *attribute.Set) {
+	recordSet(ctx, a.SyncImpl, number.NewInt64Number(value), set)
+}
+
+func (a fRecorder) RecordSet(ctx context.Context, value float64, set *attribute.Set) {
+	recordSet(ctx, a.SyncImpl, number.NewFloat64Number(value), set)
+}
+
+func (a iRecorder) RecordSet(ctx context.Context, value int64, set *attribute.Set) {
+	recordSet(ctx, a.SyncImpl, number.NewInt64Number(value), set)
+}
+
+// recordSet captures n with the attributes of set with impl, passing set as
+// is if impl is a SyncSetImpl.
+func recordSet(ctx context.Context, impl SyncImpl, n number.Number, set *attribute.Set) {
+	if impl == nil {
+		return
+	}
+	if s, ok := impl.(SyncSetImpl); ok {
+		s.RecordSet(ctx, n, set)
+		return
+	}
+	impl.RecordOne(ctx, n, set.ToSlice())
+}
+
+func (a fRecorder) RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum float64, attrs ...attribute.KeyValue) error {
+	return recordHistogram(ctx, a.SyncImpl, counts, bounds, number.NewFloat64Number(sum), attrs)
+}
+
+func (a iRecorder) RecordHistogram(ctx context.Context, counts []uint64, bounds []float64, sum int64, attrs ...attribute.KeyValue) error {
+	return recordHistogram(ctx, a.SyncImpl, counts, bounds, number.NewInt64Number(sum), attrs)
+}
+
+// recordHistogram captures pre-aggregated buckets with impl, if impl is a
+// SyncHistogramImpl.
+func recordHistogram(ctx context.Context, impl SyncImpl, counts []uint64, bounds []float64, sum number.Number, attrs []attribute.KeyValue) error {
+	if impl == nil {
+		return nil
+	}
+	if h, ok := impl.(SyncHistogramImpl); ok {
+		return h.RecordHistogram(ctx, counts, bounds, sum, attrs)
+	}
+	return instrument.ErrBucketsUnsupported
+}
+
 func (a fObserver) Observe(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
 	if a.AsyncImpl != nil {
 		a.AsyncImpl.ObserveOne(ctx, number.NewFloat64Number(value), attrs)
@@ -15,7 +15,11 @@
 package simple // import "go.opentelemetry.io/otel/sdk/metric/selector/simple"
 
 import (
+	"runtime"
+	"sync"
+
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
@@ -28,11 +32,35 @@ type (
 	selectorHistogram   struct {
 		options []histogram.Option
 	}
+	selectorExponential struct {
+		options []exponential.Option
+	}
+	selectorSumOptions struct {
+		selector export.AggregatorSelector
+		options  []sum.Option
+	}
+	selectorLastValueOptions struct {
+		selector export.AggregatorSelector
+		options  []lastvalue.Option
+	}
+	selectorMemoryPressure struct {
+		selector export.AggregatorSelector
+		fallback export.AggregatorSelector
+		limit    uint64
+		probe    func() uint64
+
+		lock     sync.Mutex
+		selected map[sdkapi.Descriptor]export.AggregatorSelector
+	}
 )
 
 var (
 	_ export.AggregatorSelector = selectorInexpensive{}
 	_ export.AggregatorSelector = selectorHistogram{}
+	_ export.AggregatorSelector = selectorExponential{}
+	_ export.AggregatorSelector = selectorSumOptions{}
+	_ export.AggregatorSelector = selectorLastValueOptions{}
+	_ export.AggregatorSelector = &selectorMemoryPressure{}
 )
 
 // NewWithInexpensiveDistribution returns a simple aggregator selector
@@ -51,6 +79,68 @@ func NewWithHistogramDistribution(options ...histogram.Option) export.Aggregator
 	return selectorHistogram{options: options}
 }
 
+// NewWithExponentialDistribution returns a simple aggregator selector
+// that uses base-2 exponential histogram aggregators for `Histogram`
+// instruments.  Unlike NewWithHistogramDistribution, bucket boundaries
+// do not have to be known in advance.
+func NewWithExponentialDistribution(options ...exponential.Option) export.AggregatorSelector {
+	return selectorExponential{options: options}
+}
+
+// WithSumOptions returns an aggregator selector that selects the same
+// aggregators as selector, except that every sum aggregator it selects is
+// configured with options. For example, this can be used to opt-in to the
+// detection of overflowing sums with sum.WithOverflowDetection.
+func WithSumOptions(selector export.AggregatorSelector, options ...sum.Option) export.AggregatorSelector {
+	return selectorSumOptions{selector: selector, options: options}
+}
+
+// WithLastValueOptions returns an aggregator selector that selects the same
+// aggregators as selector, except that every lastvalue aggregator it selects
+// is configured with options. For example, this can be used to timestamp the
+// last values with a deterministic clock with lastvalue.WithClock.
+func WithLastValueOptions(selector export.AggregatorSelector, options ...lastvalue.Option) export.AggregatorSelector {
+	return selectorLastValueOptions{selector: selector, options: options}
+}
+
+// NewWithMemoryPressureFallback returns an aggregator selector that selects
+// the aggregators of selector unless the memory in use, as reported by probe,
+// exceeds limit bytes. Under that memory pressure the aggregators of
+// fallback are selected instead. For example, fallback can be the selector
+// returned by NewWithInexpensiveDistribution to replace histogram
+// aggregators of a selector returned by NewWithHistogramDistribution with
+// cheaper ones.
+//
+// The memory in use is checked the first time aggregators are selected for
+// an instrument, and the selector chosen then is used for every later record
+// of that instrument. The accumulator and the processor select aggregators
+// separately and merge them, so the aggregation of an instrument must not
+// change while the program runs. Only instruments first used under memory
+// pressure are aggregated with the fallback.
+//
+// If probe is nil, the heap memory allocated as reported by
+// runtime.ReadMemStats is used. Reading it stops the world, a cheaper probe
+// should be provided if new instruments are created frequently.
+func NewWithMemoryPressureFallback(selector, fallback export.AggregatorSelector, limit uint64, probe func() uint64) export.AggregatorSelector {
+	if probe == nil {
+		probe = heapAlloc
+	}
+	return &selectorMemoryPressure{
+		selector: selector,
+		fallback: fallback,
+		limit:    limit,
+		probe:    probe,
+		selected: map[sdkapi.Descriptor]export.AggregatorSelector{},
+	}
+}
+
+// heapAlloc returns the bytes of allocated heap objects.
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
 func sumAggs(aggPtrs []*aggregator.Aggregator) {
 	aggs := sum.New(len(aggPtrs))
 	for i := range aggPtrs {
@@ -92,3 +182,66 @@ func (s selectorHistogram) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs
 		sumAggs(aggPtrs)
 	}
 }
+
+func (s selectorExponential) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	switch descriptor.InstrumentKind() {
+	case sdkapi.GaugeObserverInstrumentKind:
+		lastValueAggs(aggPtrs)
+	case sdkapi.HistogramInstrumentKind:
+		aggs := exponential.New(len(aggPtrs), descriptor, s.options...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	default:
+		sumAggs(aggPtrs)
+	}
+}
+
+func (s selectorSumOptions) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	s.selector.AggregatorFor(descriptor, aggPtrs...)
+	if len(aggPtrs) == 0 {
+		return
+	}
+	if _, ok := (*aggPtrs[0]).(*sum.Aggregator); !ok {
+		return
+	}
+	aggs := sum.New(len(aggPtrs), s.options...)
+	for i := range aggPtrs {
+		*aggPtrs[i] = &aggs[i]
+	}
+}
+
+func (s selectorLastValueOptions) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	s.selector.AggregatorFor(descriptor, aggPtrs...)
+	if len(aggPtrs) == 0 {
+		return
+	}
+	if _, ok := (*aggPtrs[0]).(*lastvalue.Aggregator); !ok {
+		return
+	}
+	aggs := lastvalue.New(len(aggPtrs), s.options...)
+	for i := range aggPtrs {
+		*aggPtrs[i] = &aggs[i]
+	}
+}
+
+func (s *selectorMemoryPressure) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	s.selectorFor(descriptor).AggregatorFor(descriptor, aggPtrs...)
+}
+
+// selectorFor returns the selector chosen for descriptor, choosing it
+// according to the memory in use if descriptor is seen for the first time.
+func (s *selectorMemoryPressure) selectorFor(descriptor *sdkapi.Descriptor) export.AggregatorSelector {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if sel, ok := s.selected[*descriptor]; ok {
+		return sel
+	}
+	sel := s.selector
+	if s.probe() > s.limit {
+		sel = s.fallback
+	}
+	s.selected[*descriptor] = sel
+	return sel
+}
@@ -15,19 +15,29 @@
 package simple_test
 
 import (
+	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/metrictest"
 	"go.opentelemetry.io/otel/sdk/metric/number"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
 	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 var (
@@ -64,3 +74,120 @@ func TestHistogramDistribution(t *testing.T) {
 	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(hist, &testHistogramDesc))
 	testFixedSelectors(t, hist)
 }
+
+func TestExponentialDistribution(t *testing.T) {
+	exp := simple.NewWithExponentialDistribution()
+	require.IsType(t, (*exponential.Aggregator)(nil), oneAgg(exp, &testHistogramDesc))
+	testFixedSelectors(t, exp)
+}
+
+func TestWithSumOptions(t *testing.T) {
+	sel := simple.WithSumOptions(simple.NewWithHistogramDistribution(), sum.WithOverflowClamp())
+	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+	testFixedSelectors(t, sel)
+
+	agg := oneAgg(sel, &testCounterDesc)
+	ctx := context.Background()
+	require.NoError(t, agg.Update(ctx, number.NewInt64Number(math.MaxInt64), &testCounterDesc))
+	require.NoError(t, agg.Update(ctx, number.NewInt64Number(1), &testCounterDesc))
+	s, err := agg.(*sum.Aggregator).Sum()
+	require.NoError(t, err)
+	require.Equal(t, number.NewInt64Number(math.MaxInt64), s)
+}
+
+func TestWithLastValueOptions(t *testing.T) {
+	now := time.Unix(1, 0)
+	sel := simple.WithLastValueOptions(simple.NewWithHistogramDistribution(), lastvalue.WithClock(func() time.Time { return now }))
+	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+	testFixedSelectors(t, sel)
+
+	agg := oneAgg(sel, &testGaugeObserverDesc)
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(1), &testGaugeObserverDesc))
+	_, timestamp, err := agg.(*lastvalue.Aggregator).LastValue()
+	require.NoError(t, err)
+	require.Equal(t, now, timestamp)
+}
+
+func TestMemoryPressureFallback(t *testing.T) {
+	var inUse uint64
+	probe := func() uint64 { return inUse }
+	sel := simple.NewWithMemoryPressureFallback(
+		simple.NewWithHistogramDistribution(),
+		simple.NewWithInexpensiveDistribution(),
+		1<<20,
+		probe,
+	)
+
+	inUse = 1 << 10
+	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+	testFixedSelectors(t, sel)
+
+	// The aggregation of an instrument seen before does not change.
+	inUse = 1 << 30
+	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+
+	pressureDesc := metrictest.NewDescriptor("pressure", sdkapi.HistogramInstrumentKind, number.Int64Kind)
+	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &pressureDesc))
+
+	inUse = 1 << 10
+	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &pressureDesc))
+}
+
+func TestMemoryPressureFallbackCumulative(t *testing.T) {
+	var inUse uint64
+	probe := func() uint64 { return inUse }
+	sel := simple.NewWithMemoryPressureFallback(
+		simple.NewWithHistogramDistribution(),
+		simple.NewWithInexpensiveDistribution(),
+		1<<20,
+		probe,
+	)
+	cont := controller.New(
+		processor.NewFactory(sel, aggregation.CumulativeTemporalitySelector(), processor.WithMemory(true)),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	hist, err := cont.Meter("test").SyncInt64().Histogram("hist")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		// Flip the memory pressure between collections.
+		inUse = uint64(i%2) << 30
+		hist.Record(ctx, 1, attribute.Int("i", i%2))
+		require.NoError(t, cont.Collect(ctx))
+	}
+
+	counts := map[string]uint64{}
+	require.NoError(t, cont.ForEach(
+		func(_ instrumentation.Scope, reader export.Reader) error {
+			return reader.ForEach(
+				aggregation.CumulativeTemporalitySelector(),
+				func(record export.Record) error {
+					require.IsType(t, (*histogram.Aggregator)(nil), record.Aggregation())
+					count, err := record.Aggregation().(aggregation.Count).Count()
+					counts[record.Attributes().Encoded(attribute.DefaultEncoder())] = count
+					return err
+				},
+			)
+		}))
+	require.Equal(t, map[string]uint64{"i=0": 2, "i=1": 2}, counts)
+}
+
+func TestMemoryPressureFallbackDefaultProbe(t *testing.T) {
+	sel := simple.NewWithMemoryPressureFallback(
+		simple.NewWithHistogramDistribution(),
+		simple.NewWithInexpensiveDistribution(),
+		math.MaxUint64,
+		nil,
+	)
+	require.IsType(t, (*histogram.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+
+	sel = simple.NewWithMemoryPressureFallback(
+		simple.NewWithHistogramDistribution(),
+		simple.NewWithInexpensiveDistribution(),
+		0,
+		nil,
+	)
+	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &testHistogramDesc))
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package view provides views of metric instruments, configuring how the
+// metric data of instruments is exported without changing the
+// instrumentation producing it.
+//
+// A View matches instruments by name and can rename them, change their
+// aggregation, and limit the attributes their data is exported with. Views
+// are applied by a Processor, see the basic.WithViews option of the
+// go.opentelemetry.io/otel/sdk/metric/processor/basic package.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package view // import "go.opentelemetry.io/otel/sdk/metric/view"
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view // import "go.opentelemetry.io/otel/sdk/metric/view"
+
+import (
+	"fmt"
+	"path"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+)
+
+// ErrUnsupportedAggregation is returned by New when a View is configured
+// with an aggregation that is not supported.
+var ErrUnsupportedAggregation = fmt.Errorf("unsupported view aggregation")
+
+// OverflowKey is the key of the attribute of the series the data of the
+// attribute sets exceeding the cardinality limit of a View is merged into.
+// The attribute of this series is OverflowKey.Bool(true).
+const OverflowKey = attribute.Key("otel.metric.overflow")
+
+// View describes how the metric data of the instruments it matches is
+// exported.
+type View struct {
+	match       string
+	name        string
+	aggregation aggregation.Kind
+	keep        map[attribute.Key]struct{}
+	cardinality int
+}
+
+// New returns a View matching all instruments with a name matching the
+// matchName glob pattern. The pattern syntax is the one of path.Match, for
+// example "http.*" matches all instruments with a name starting with
+// "http.".
+//
+// Without any options, the View leaves the matched instruments unchanged.
+//
+// An error is returned if matchName is not a valid pattern or if the View
+// is configured with an unsupported aggregation.
+func New(matchName string, opts ...Option) (View, error) {
+	if _, err := path.Match(matchName, ""); err != nil {
+		return View{}, fmt.Errorf("invalid view pattern %q: %w", matchName, err)
+	}
+	v := View{match: matchName}
+	for _, opt := range opts {
+		v = opt.apply(v)
+	}
+	switch v.aggregation {
+	case "", aggregation.SumKind, aggregation.LastValueKind,
+		aggregation.HistogramKind, aggregation.ExponentialHistogramKind:
+	default:
+		return View{}, fmt.Errorf("%w: %s", ErrUnsupportedAggregation, v.aggregation)
+	}
+	return v, nil
+}
+
+// Matches returns if an instrument with name is matched by the View.
+func (v View) Matches(name string) bool {
+	// The pattern was validated by New, a no match is the only possible
+	// outcome of an error.
+	ok, _ := path.Match(v.match, name)
+	return ok
+}
+
+// Name returns the name the data of the instrument with name is exported
+// with.
+func (v View) Name(name string) string {
+	if v.name == "" {
+		return name
+	}
+	return v.name
+}
+
+// Aggregation returns the aggregation the data of the matched instruments is
+// computed with, or an empty Kind if the aggregation is unchanged.
+func (v View) Aggregation() aggregation.Kind {
+	return v.aggregation
+}
+
+// AttributeFilter returns a filter keeping the attributes the data of the
+// matched instruments is exported with, or nil if all attributes are kept.
+func (v View) AttributeFilter() attribute.Filter {
+	if v.keep == nil {
+		return nil
+	}
+	return func(kv attribute.KeyValue) bool {
+		_, ok := v.keep[kv.Key]
+		return ok
+	}
+}
+
+// CardinalityLimit returns the maximum number of attribute sets the data
+// of each matched instrument is exported with, or 0 if it is unlimited.
+func (v View) CardinalityLimit() int {
+	return v.cardinality
+}
+
+// Option configures a View.
+type Option interface {
+	apply(View) View
+}
+
+// WithRename sets the name the data of the matched instruments is exported
+// with. A View with this option is expected to match a single instrument,
+// the data of different instruments would otherwise be exported with the
+// same name.
+func WithRename(name string) Option {
+	return renameOption(name)
+}
+
+type renameOption string
+
+func (o renameOption) apply(v View) View {
+	v.name = string(o)
+	return v
+}
+
+// WithAggregation sets the aggregation the data of the matched instruments
+// is computed with. The supported aggregations are the Sum, LastValue,
+// Histogram, and ExponentialHistogram kinds.
+func WithAggregation(kind aggregation.Kind) Option {
+	return aggregationOption(kind)
+}
+
+type aggregationOption aggregation.Kind
+
+func (o aggregationOption) apply(v View) View {
+	v.aggregation = aggregation.Kind(o)
+	return v
+}
+
+// WithKeepAttributes sets the attributes the data of the matched
+// instruments is exported with. Attributes with other keys are removed and
+// the data of the attribute sets becoming the same is merged.
+func WithKeepAttributes(keys ...attribute.Key) Option {
+	return keepAttributesOption(keys)
+}
+
+type keepAttributesOption []attribute.Key
+
+func (o keepAttributesOption) apply(v View) View {
+	v.keep = make(map[attribute.Key]struct{}, len(o))
+	for _, k := range o {
+		v.keep[k] = struct{}{}
+	}
+	return v
+}
+
+// WithCardinalityLimit sets the maximum number of attribute sets the data of
+// each matched instrument is exported with. Once limit attribute sets are
+// exported, the data of any new attribute set is merged into a single
+// overflow series with the attribute OverflowKey.Bool(true). The limit
+// applies after the attributes are filtered with WithKeepAttributes. A
+// limit less than 1 leaves the cardinality unlimited.
+func WithCardinalityLimit(limit int) Option {
+	return cardinalityLimitOption(limit)
+}
+
+type cardinalityLimitOption int
+
+func (o cardinalityLimitOption) apply(v View) View {
+	if o < 1 {
+		o = 0
+	}
+	v.cardinality = int(o)
+	return v
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+)
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New("[")
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+
+	_, err = New("*", WithAggregation("unknown"))
+	assert.ErrorIs(t, err, ErrUnsupportedAggregation)
+}
+
+func TestViewDefault(t *testing.T) {
+	v, err := New("http.*")
+	require.NoError(t, err)
+
+	assert.True(t, v.Matches("http.requests"))
+	assert.False(t, v.Matches("grpc.requests"))
+	assert.Equal(t, "http.requests", v.Name("http.requests"))
+	assert.Equal(t, aggregation.Kind(""), v.Aggregation())
+	assert.Nil(t, v.AttributeFilter())
+	assert.Equal(t, 0, v.CardinalityLimit())
+}
+
+func TestViewOptions(t *testing.T) {
+	v, err := New(
+		"http.requests",
+		WithRename("requests"),
+		WithAggregation(aggregation.HistogramKind),
+		WithKeepAttributes("method", "code"),
+		WithCardinalityLimit(100),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "requests", v.Name("http.requests"))
+	assert.Equal(t, aggregation.HistogramKind, v.Aggregation())
+	assert.Equal(t, 100, v.CardinalityLimit())
+
+	filter := v.AttributeFilter()
+	require.NotNil(t, filter)
+	assert.True(t, filter(attribute.String("method", "GET")))
+	assert.True(t, filter(attribute.Int("code", 200)))
+	assert.False(t, filter(attribute.String("path", "/")))
+}
@@ -185,6 +185,44 @@ func WithProcessRuntimeDescription() Option {
 	return WithDetectors(processRuntimeDescriptionDetector{})
 }
 
+// WithProcessRuntime adds the name and version of the runtime of this
+// process, and its process identifier (PID), to the configured Resource.
+//
+// Unlike other options, the attributes it adds never override the ones
+// added by any other option, regardless of the order options are passed.
+// This allows user-set values of these attributes to always take
+// precedence.
+func WithProcessRuntime() Option {
+	return baseDetectorsOption{detectors: []Detector{
+		processRuntimeNameDetector{},
+		processRuntimeVersionDetector{},
+		processPIDDetector{},
+	}}
+}
+
+// WithK8s adds the Kubernetes pod, namespace, and node names read from the
+// K8S_POD_NAME, K8S_NAMESPACE, and K8S_NODE_NAME environment variables to the
+// configured Resource. These are typically set with the Kubernetes downward
+// API. Environment variables that are not set are omitted.
+//
+// Like WithProcessRuntime, the attributes it adds never override the ones
+// added by any other option, regardless of the order options are passed.
+func WithK8s() Option {
+	return baseDetectorsOption{detectors: []Detector{k8sDetector{}}}
+}
+
+// baseDetectorsOption adds detectors evaluated before all other detectors
+// of the configured resource, so their attributes are overridden by any other
+// detector.
+type baseDetectorsOption struct {
+	detectors []Detector
+}
+
+func (o baseDetectorsOption) apply(cfg config) config {
+	cfg.detectors = append(append([]Detector(nil), o.detectors...), cfg.detectors...)
+	return cfg
+}
+
 // WithContainer adds all the Container attributes to the configured Resource.
 // See individual WithContainer* functions to configure specific attributes.
 func WithContainer() Option {
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	// k8sPodNameKey is the environment variable name the Kubernetes pod
+	// name is read from.
+	k8sPodNameKey = "K8S_POD_NAME"
+
+	// k8sNamespaceKey is the environment variable name the Kubernetes
+	// namespace name is read from.
+	k8sNamespaceKey = "K8S_NAMESPACE"
+
+	// k8sNodeNameKey is the environment variable name the Kubernetes node
+	// name is read from.
+	k8sNodeNameKey = "K8S_NODE_NAME"
+)
+
+// k8sDetector is a Detector that collects the Kubernetes pod, namespace,
+// and node names from environment variables, typically set with the
+// downward API.
+type k8sDetector struct{}
+
+// compile time assertion that k8sDetector implements Detector interface.
+var _ Detector = k8sDetector{}
+
+// Detect returns a *Resource that describes the Kubernetes pod the process
+// is running in. Environment variables that are not set, or empty, are
+// omitted. If none is set, an empty resource is returned.
+func (k8sDetector) Detect(context.Context) (*Resource, error) {
+	var attrs []attribute.KeyValue
+	for _, v := range []struct {
+		env string
+		key attribute.Key
+	}{
+		{k8sPodNameKey, semconv.K8SPodNameKey},
+		{k8sNamespaceKey, semconv.K8SNamespaceNameKey},
+		{k8sNodeNameKey, semconv.K8SNodeNameKey},
+	} {
+		if value := strings.TrimSpace(os.Getenv(v.env)); value != "" {
+			attrs = append(attrs, v.key.String(value))
+		}
+	}
+	if len(attrs) == 0 {
+		return Empty(), nil
+	}
+	return NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
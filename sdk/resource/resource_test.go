@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -634,6 +635,45 @@ func TestWithProcess(t *testing.T) {
 	}, toMap(res))
 }
 
+func TestWithProcessRuntime(t *testing.T) {
+	restoreAttributesProviders()
+	ctx := context.Background()
+
+	res, err := resource.New(ctx,
+		resource.WithProcessRuntime(),
+	)
+
+	require.NoError(t, err)
+	got := toMap(res)
+	require.Len(t, got, 3)
+	require.Equal(t, resource.RuntimeName(), got["process.runtime.name"])
+	require.Equal(t, runtime.Version(), got["process.runtime.version"])
+	require.Equal(t, fmt.Sprint(os.Getpid()), got["process.pid"])
+}
+
+func TestWithProcessRuntimeDoesNotOverride(t *testing.T) {
+	mockProcessAttributesProviders()
+	t.Cleanup(restoreAttributesProviders)
+	ctx := context.Background()
+
+	userSet := resource.WithAttributes(semconv.ProcessRuntimeNameKey.String("custom"))
+	for name, opts := range map[string][]resource.Option{
+		"Before": {userSet, resource.WithProcessRuntime()},
+		"After":  {resource.WithProcessRuntime(), userSet},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := resource.New(ctx, opts...)
+
+			require.NoError(t, err)
+			require.EqualValues(t, map[string]string{
+				"process.pid":             fmt.Sprint(fakePID),
+				"process.runtime.name":    "custom",
+				"process.runtime.version": fakeRuntimeVersion,
+			}, toMap(res))
+		})
+	}
+}
+
 func toMap(res *resource.Resource) map[string]string {
 	m := map[string]string{}
 	for _, attr := range res.Attributes() {
@@ -720,3 +760,49 @@ func TestWithContainer(t *testing.T) {
 		string(semconv.ContainerIDKey): fakeContainerID,
 	}, toMap(res))
 }
+
+func TestWithK8s(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"K8S_POD_NAME":  "pod-1",
+		"K8S_NAMESPACE": "default",
+		"K8S_NODE_NAME": "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := resource.New(context.Background(), resource.WithK8s())
+	require.NoError(t, err)
+	// Missing variables are omitted.
+	assert.Equal(t, map[string]string{
+		string(semconv.K8SPodNameKey):       "pod-1",
+		string(semconv.K8SNamespaceNameKey): "default",
+	}, toMap(res))
+	assert.Equal(t, semconv.SchemaURL, res.SchemaURL())
+}
+
+func TestWithK8sDoesNotOverride(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"K8S_POD_NAME":  "pod-1",
+		"K8S_NAMESPACE": "default",
+		"K8S_NODE_NAME": "node-1",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	userSet := resource.WithAttributes(semconv.K8SNamespaceNameKey.String("custom"))
+	for name, opts := range map[string][]resource.Option{
+		"Before": {userSet, resource.WithK8s()},
+		"After":  {resource.WithK8s(), userSet},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := resource.New(context.Background(), opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{
+				string(semconv.K8SPodNameKey):       "pod-1",
+				string(semconv.K8SNamespaceNameKey): "custom",
+				string(semconv.K8SNodeNameKey):      "node-1",
+			}, toMap(res))
+		})
+	}
+}
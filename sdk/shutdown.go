@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sdk provides helpers to manage the components of the OpenTelemetry
+// SDK together.
+package sdk // import "go.opentelemetry.io/otel/sdk"
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ShutdownAll calls each of fns in order with ctx, and returns the errors
+// they returned. It is meant to tear all the components of an application
+// down in one call, e.g. the Shutdown method of a TracerProvider and the
+// Stop method of a metric Controller:
+//
+//	defer func() {
+//		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//		defer cancel()
+//		if err := sdk.ShutdownAll(ctx, tp.Shutdown, cont.Stop); err != nil {
+//			log.Print(err)
+//		}
+//	}()
+//
+// All of fns are called, even if some return an error, unless ctx is done.
+// Once ctx is done the remaining fns are not called and the error of ctx is
+// returned along with the errors of the fns called. The returned error
+// matches each of these errors with errors.Is.
+func ShutdownAll(ctx context.Context, fns ...func(context.Context) error) error {
+	var errs shutdownErrors
+	for _, fn := range fns {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// shutdownErrors are the errors returned by ShutdownAll.
+type shutdownErrors []error
+
+func (e shutdownErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "shutdown failed: " + strings.Join(msgs, "; ")
+}
+
+// Is returns if any of e matches target.
+func (e shutdownErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
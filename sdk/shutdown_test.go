@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownAll(t *testing.T) {
+	errTrace := errors.New("trace shutdown failed")
+
+	var calls []string
+	traceShutdown := func(context.Context) error {
+		calls = append(calls, "trace")
+		return errTrace
+	}
+	metricShutdown := func(context.Context) error {
+		calls = append(calls, "metric")
+		return nil
+	}
+
+	err := ShutdownAll(context.Background(), traceShutdown, metricShutdown)
+	assert.ErrorIs(t, err, errTrace)
+	assert.EqualError(t, err, "shutdown failed: trace shutdown failed")
+	assert.Equal(t, []string{"trace", "metric"}, calls)
+
+	calls = nil
+	assert.NoError(t, ShutdownAll(context.Background(), metricShutdown, metricShutdown))
+	assert.Equal(t, []string{"metric", "metric"}, calls)
+}
+
+func TestShutdownAllContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errTrace := errors.New("trace shutdown failed")
+
+	var calls []string
+	traceShutdown := func(context.Context) error {
+		calls = append(calls, "trace")
+		// The shutdown used up all the time.
+		cancel()
+		return errTrace
+	}
+	metricShutdown := func(context.Context) error {
+		calls = append(calls, "metric")
+		return nil
+	}
+
+	err := ShutdownAll(ctx, traceShutdown, metricShutdown)
+	assert.ErrorIs(t, err, errTrace)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"trace"}, calls)
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDAllowlist is a Sampler that samples all spans of the traces with an
+// ID in its allowlist. The allowlist can be updated while it is in use.
+type TraceIDAllowlist struct {
+	mu       sync.RWMutex
+	ids      map[trace.TraceID]struct{}
+	delegate Sampler
+}
+
+var _ Sampler = (*TraceIDAllowlist)(nil)
+
+// TraceIDAllowlistSampler returns a Sampler that samples every span of the
+// traces with one of ids as trace ID, whatever the sampling decision of
+// their parent. This can be used to debug a request in production, by
+// having its client inject a known trace ID. The sampling decision of all
+// other spans is made by delegate.
+//
+// The allowlist can be updated with the SetTraceIDs and AddTraceIDs methods
+// of the returned Sampler without restarting, e.g. by operators.
+func TraceIDAllowlistSampler(ids []trace.TraceID, delegate Sampler) *TraceIDAllowlist {
+	s := &TraceIDAllowlist{delegate: delegate}
+	s.SetTraceIDs(ids)
+	return s
+}
+
+// SetTraceIDs replaces the allowlist of s with ids.
+func (s *TraceIDAllowlist) SetTraceIDs(ids []trace.TraceID) {
+	m := make(map[trace.TraceID]struct{}, len(ids))
+	for _, id := range ids {
+		m[id] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.ids = m
+	s.mu.Unlock()
+}
+
+// AddTraceIDs adds ids to the allowlist of s.
+func (s *TraceIDAllowlist) AddTraceIDs(ids ...trace.TraceID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		s.ids[id] = struct{}{}
+	}
+}
+
+// ShouldSample returns a RecordAndSample decision if the trace ID of p is
+// allowlisted, and the decision of the delegate Sampler otherwise.
+func (s *TraceIDAllowlist) ShouldSample(p SamplingParameters) SamplingResult {
+	s.mu.RLock()
+	_, ok := s.ids[p.TraceID]
+	s.mu.RUnlock()
+	if ok {
+		return SamplingResult{
+			Decision:   RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.delegate.ShouldSample(p)
+}
+
+// Description returns the description of s.
+func (s *TraceIDAllowlist) Description() string {
+	s.mu.RLock()
+	n := len(s.ids)
+	s.mu.RUnlock()
+	return fmt.Sprintf("TraceIDAllowlistSampler{%d,%s}", n, s.delegate.Description())
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceIDAllowlistSampler(t *testing.T) {
+	allowed := trace.TraceID{0x01}
+	other := trace.TraceID{0x02}
+	sampler := TraceIDAllowlistSampler([]trace.TraceID{allowed}, NeverSample())
+
+	// A client injected a trace that is not sampled.
+	notSampled := trace.ContextWithRemoteSpanContext(
+		context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: allowed,
+			SpanID:  trace.SpanID{0x01},
+		}),
+	)
+
+	tests := []struct {
+		name string
+		p    SamplingParameters
+		want SamplingDecision
+	}{
+		{
+			name: "allowlisted root",
+			p:    SamplingParameters{ParentContext: context.Background(), TraceID: allowed},
+			want: RecordAndSample,
+		},
+		{
+			name: "allowlisted with parent not sampled",
+			p:    SamplingParameters{ParentContext: notSampled, TraceID: allowed},
+			want: RecordAndSample,
+		},
+		{
+			name: "not allowlisted",
+			p:    SamplingParameters{ParentContext: context.Background(), TraceID: other},
+			want: Drop,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sampler.ShouldSample(tc.p).Decision)
+		})
+	}
+	assert.Equal(t, "TraceIDAllowlistSampler{1,AlwaysOffSampler}", sampler.Description())
+
+	sampler.AddTraceIDs(other)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(SamplingParameters{TraceID: other}).Decision)
+
+	sampler.SetTraceIDs(nil)
+	assert.Equal(t, Drop, sampler.ShouldSample(SamplingParameters{TraceID: allowed}).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(SamplingParameters{TraceID: other}).Decision)
+}
+
+func TestTraceIDAllowlistSamplerConcurrentUpdates(t *testing.T) {
+	sampler := TraceIDAllowlistSampler(nil, NeverSample())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		id := trace.TraceID{byte(i)}
+		go func() {
+			defer wg.Done()
+			sampler.AddTraceIDs(id)
+			sampler.SetTraceIDs([]trace.TraceID{id})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = sampler.ShouldSample(SamplingParameters{TraceID: id})
+			_ = sampler.Description()
+		}()
+	}
+	wg.Wait()
+
+	sampler.SetTraceIDs([]trace.TraceID{{0xff}})
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(SamplingParameters{TraceID: trace.TraceID{0xff}}).Decision)
+}
@@ -16,12 +16,15 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/internal/env"
 	"go.opentelemetry.io/otel/trace"
@@ -67,6 +70,95 @@ type BatchSpanProcessorOptions struct {
 	// Blocking option should be used carefully as it can severely affect the performance of an
 	// application.
 	BlockOnQueueFull bool
+
+	// MaxSpanAge is the maximum duration a sampled span may remain started
+	// without being ended. Spans open for longer are ended by the processor
+	// with an Error status and the otel.span.timed_out attribute set to true,
+	// and are then exported like any other span. Open spans are checked
+	// every MaxSpanAge, so a span may remain open for up to twice this
+	// duration before it is ended.
+	// The default value of MaxSpanAge is 0, meaning spans are never ended by
+	// the processor.
+	MaxSpanAge time.Duration
+
+	// PartialSpanAge is the duration after which a sampled span that is
+	// still open is exported once as a partial span, a snapshot of the
+	// span with the otel.span.partial attribute set to true and ended at
+	// the time of the snapshot. The span is exported again when it ends. Open spans are checked every
+	// PartialSpanAge, so a span may remain open for up to twice this
+	// duration before its partial span is exported.
+	// The default value of PartialSpanAge is 0, meaning no partial spans
+	// are exported.
+	PartialSpanAge time.Duration
+
+	// ExportLatencyRecorder is called after every export with the duration
+	// of the ExportSpans call of the exporter and the error it returned.
+	// The default value of ExportLatencyRecorder is nil, meaning the
+	// export latency is not measured.
+	ExportLatencyRecorder func(ctx context.Context, latency time.Duration, err error)
+
+	// RecordQueueLatency determines if every exported span is given the
+	// otel.bsp.queue_latency_ms attribute, the milliseconds from when the
+	// span was ended and queued until it is exported.
+	// The default value of RecordQueueLatency is false.
+	RecordQueueLatency bool
+
+	// MaxConcurrentExports is the maximum number of batches exported
+	// concurrently. When it is greater than 1, each batch is exported in its
+	// own goroutine, batches may be exported out of order, and export errors
+	// are passed to the global error handler.
+	// The default value of MaxConcurrentExports is 1, batches are exported
+	// one at a time.
+	MaxConcurrentExports int
+
+	// InternalMetrics are the counters the processor records its own
+	// activity to.
+	// The default value of InternalMetrics has no counters, meaning no
+	// activity is recorded.
+	InternalMetrics BatchSpanProcessorMetrics
+
+	// DiskBufferPath is the path of the file queued spans are persisted to
+	// until they are exported. Spans persisted but not exported when the
+	// process exits are exported by the next BatchSpanProcessor using the
+	// same path.
+	// The default value of DiskBufferPath is empty, meaning spans are not
+	// persisted.
+	DiskBufferPath string
+
+	// DiskBufferMaxBytes is the maximum size of the file at DiskBufferPath.
+	// The oldest persisted spans are evicted from a full file to make room
+	// for new ones.
+	DiskBufferMaxBytes int64
+}
+
+// Int64Counter is a counter of int64 increments. It is implemented by the
+// syncint64.Counter of the go.opentelemetry.io/otel/metric module.
+type Int64Counter interface {
+	// Add records the increment incr with the attributes attrs.
+	Add(ctx context.Context, incr int64, attrs ...attribute.KeyValue)
+}
+
+// BatchSpanProcessorMetrics are the counters a BatchSpanProcessor records
+// its own activity to. Nil counters are not recorded to.
+type BatchSpanProcessorMetrics struct {
+	// SpansStarted counts the spans started.
+	SpansStarted Int64Counter
+	// SpansEnded counts the spans ended.
+	SpansEnded Int64Counter
+	// SpansDropped counts the sampled spans dropped because the queue was
+	// full.
+	SpansDropped Int64Counter
+	// SpansExported counts the spans successfully exported.
+	SpansExported Int64Counter
+	// SpansFailed counts the spans of exports that returned an error.
+	SpansFailed Int64Counter
+}
+
+// add adds incr to c if it is not nil.
+func (m BatchSpanProcessorMetrics) add(ctx context.Context, c Int64Counter, incr int64) {
+	if c != nil {
+		c.Add(ctx, incr)
+	}
 }
 
 // batchSpanProcessor is a SpanProcessor that batches asynchronously-received
@@ -84,6 +176,90 @@ type batchSpanProcessor struct {
 	stopWait   sync.WaitGroup
 	stopOnce   sync.Once
 	stopCh     chan struct{}
+
+	// reportedDropped is the number of dropped spans already reported, it
+	// is guarded by batchMutex.
+	reportedDropped uint32
+
+	// exportSem holds a value for every concurrent export in flight if
+	// MaxConcurrentExports is greater than 1, it is nil otherwise.
+	exportSem chan struct{}
+	// exportWaitMu serializes waiting for the concurrent exports.
+	exportWaitMu sync.Mutex
+
+	// openSpans holds the spans started but not yet ended if MaxSpanAge or
+	// PartialSpanAge is set.
+	openSpans   map[spanKey]ReadWriteSpan
+	openSpansMu sync.Mutex
+	// partialSpans holds the open spans whose partial span was exported. It
+	// is guarded by openSpansMu.
+	partialSpans map[spanKey]struct{}
+	// now returns the current time. It is used to determine the age of open
+	// spans, and the queue latency of spans. It is guarded by openSpansMu.
+	now func() time.Time
+	// timeSourceSet is closed once the time source of a TracerProvider is
+	// set, the schedule then follows it instead of the wall clock.
+	timeSourceSet     chan struct{}
+	timeSourceSetOnce sync.Once
+	// batchDeadline is when the BatchTimeout elapses on the time source
+	// once it is set. It is guarded by openSpansMu.
+	batchDeadline time.Time
+
+	// disk persists the queued spans if DiskBufferPath is set, it is nil
+	// otherwise.
+	disk *diskBuffer
+	// persistCh holds the queued spans to write to disk, persistDone is
+	// closed once they are all written after the processor is shut down.
+	persistCh   chan persistedSpan
+	persistDone chan struct{}
+	// notPersisted counts the spans not persisted because persistCh was
+	// full, reportedNotPersisted is the number of them already reported
+	// and is guarded by batchMutex.
+	notPersisted         uint32
+	reportedNotPersisted uint32
+}
+
+// spanKey identifies a span across its ReadWriteSpan and the ReadOnlySpan
+// snapshots taken of it.
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+func newSpanKey(sc trace.SpanContext) spanKey {
+	return spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+}
+
+// timedOutKey is the attribute key set on spans ended by a
+// batchSpanProcessor because they exceeded the MaxSpanAge.
+const timedOutKey = attribute.Key("otel.span.timed_out")
+
+// partialKey is the attribute key set on the snapshots of open spans
+// exported by a batchSpanProcessor because they exceeded the
+// PartialSpanAge.
+const partialKey = attribute.Key("otel.span.partial")
+
+// queueLatencyKey is the attribute key set on exported spans to the
+// milliseconds they were queued by a batchSpanProcessor if
+// RecordQueueLatency is set.
+const queueLatencyKey = attribute.Key("otel.bsp.queue_latency_ms")
+
+// queuedSpan is a span queued by a batchSpanProcessor that records its
+// queue latency.
+type queuedSpan struct {
+	ReadOnlySpan
+
+	// enqueued is when the span was queued.
+	enqueued time.Time
+	// latency is how long the span was queued. It is set when the span is
+	// exported.
+	latency time.Duration
+}
+
+// Attributes returns the attributes of the span and its queue latency.
+func (s queuedSpan) Attributes() []attribute.KeyValue {
+	attrs := s.ReadOnlySpan.Attributes()
+	return append(attrs[:len(attrs):len(attrs)], queueLatencyKey.Int64(s.latency.Milliseconds()))
 }
 
 var _ SpanProcessor = (*batchSpanProcessor)(nil)
@@ -120,6 +296,27 @@ func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorO
 		timer:  time.NewTimer(o.BatchTimeout),
 		queue:  make(chan ReadOnlySpan, o.MaxQueueSize),
 		stopCh: make(chan struct{}),
+		now:    time.Now,
+
+		timeSourceSet: make(chan struct{}),
+	}
+	if o.MaxConcurrentExports > 1 {
+		bsp.exportSem = make(chan struct{}, o.MaxConcurrentExports)
+	}
+
+	var replayed []persistedSpan
+	if exporter != nil && o.DiskBufferPath != "" && o.DiskBufferMaxBytes <= 0 {
+		otel.Handle(fmt.Errorf("spans are not persisted: invalid disk buffer size %d", o.DiskBufferMaxBytes))
+	} else if exporter != nil && o.DiskBufferPath != "" {
+		var err error
+		bsp.disk, replayed, err = openDiskBuffer(o.DiskBufferPath, o.DiskBufferMaxBytes)
+		if err != nil {
+			otel.Handle(fmt.Errorf("spans are not persisted: %w", err))
+		} else {
+			bsp.persistCh = make(chan persistedSpan, o.MaxQueueSize)
+			bsp.persistDone = make(chan struct{})
+			go bsp.persistSpans()
+		}
 	}
 
 	bsp.stopWait.Add(1)
@@ -127,21 +324,224 @@ func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorO
 		defer bsp.stopWait.Done()
 		bsp.processQueue()
 		bsp.drainQueue()
+		if bsp.disk != nil {
+			<-bsp.persistDone
+			if err := bsp.disk.close(); err != nil {
+				otel.Handle(err)
+			}
+		}
 	}()
 
+	if len(replayed) > 0 {
+		global.Info("replaying persisted spans", "count", len(replayed))
+		// Spans not queued before the processor is shut down stay
+		// persisted for the next processor.
+		go func() {
+			for _, s := range replayed {
+				if !bsp.enqueueBlockOnQueueFull(context.Background(), s) {
+					return
+				}
+			}
+		}()
+	}
+
+	if exporter != nil && (o.MaxSpanAge > 0 || o.PartialSpanAge > 0) {
+		bsp.openSpans = make(map[spanKey]ReadWriteSpan)
+		bsp.partialSpans = make(map[spanKey]struct{})
+		bsp.stopWait.Add(1)
+		go func() {
+			defer bsp.stopWait.Done()
+			bsp.watchOpenSpans()
+		}()
+	}
+
 	return bsp
 }
 
-// OnStart method does nothing.
-func (bsp *batchSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {}
+// OnStart method tracks s if a MaxSpanAge or PartialSpanAge is set,
+// otherwise it does nothing.
+func (bsp *batchSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	bsp.o.InternalMetrics.add(parent, bsp.o.InternalMetrics.SpansStarted, 1)
+	if bsp.openSpans == nil || !s.SpanContext().IsSampled() {
+		return
+	}
+	bsp.openSpansMu.Lock()
+	bsp.openSpans[newSpanKey(s.SpanContext())] = s
+	bsp.openSpansMu.Unlock()
+}
 
 // OnEnd method enqueues a ReadOnlySpan for later processing.
 func (bsp *batchSpanProcessor) OnEnd(s ReadOnlySpan) {
+	bsp.o.InternalMetrics.add(context.Background(), bsp.o.InternalMetrics.SpansEnded, 1)
 	// Do not enqueue spans if we are just going to drop them.
 	if bsp.e == nil {
 		return
 	}
-	bsp.enqueue(s)
+	if bsp.openSpans != nil {
+		key := newSpanKey(s.SpanContext())
+		bsp.openSpansMu.Lock()
+		delete(bsp.openSpans, key)
+		delete(bsp.partialSpans, key)
+		bsp.openSpansMu.Unlock()
+	}
+	if bsp.o.RecordQueueLatency && s.SpanContext().IsSampled() {
+		s = queuedSpan{ReadOnlySpan: s, enqueued: bsp.currentTime()}
+	}
+	bsp.enqueueEnded(s)
+}
+
+// currentTime returns the current time of the time source of bsp.
+func (bsp *batchSpanProcessor) currentTime() time.Time {
+	bsp.openSpansMu.Lock()
+	defer bsp.openSpansMu.Unlock()
+	return bsp.now()
+}
+
+// setTimeSource sets the source of the current time used to determine the
+// age of open spans, and to schedule the exports and the checks of the open
+// spans.
+func (bsp *batchSpanProcessor) setTimeSource(now func() time.Time) {
+	bsp.openSpansMu.Lock()
+	bsp.now = now
+	bsp.openSpansMu.Unlock()
+	bsp.timeSourceSetOnce.Do(func() { close(bsp.timeSourceSet) })
+}
+
+// timeSourcePollInterval is how often, on the wall clock, a
+// batchSpanProcessor polls the time source once it is set to find out if
+// the BatchTimeout or the period of the checks of open spans elapsed.
+const timeSourcePollInterval = 10 * time.Millisecond
+
+// resetBatchTimeout restarts the BatchTimeout, on the wall clock or on the
+// time source once it is set.
+func (bsp *batchSpanProcessor) resetBatchTimeout() {
+	select {
+	case <-bsp.timeSourceSet:
+		bsp.openSpansMu.Lock()
+		bsp.batchDeadline = bsp.now().Add(bsp.o.BatchTimeout)
+		bsp.openSpansMu.Unlock()
+	default:
+		bsp.timer.Reset(bsp.o.BatchTimeout)
+	}
+}
+
+// batchTimeoutElapsed returns true if the BatchTimeout elapsed on the time
+// source.
+func (bsp *batchSpanProcessor) batchTimeoutElapsed() bool {
+	bsp.openSpansMu.Lock()
+	defer bsp.openSpansMu.Unlock()
+	return !bsp.now().Before(bsp.batchDeadline)
+}
+
+// stopTimer stops the wall clock timer of the BatchTimeout. It must only be
+// called by the goroutine processing the queue, which receives from it.
+func (bsp *batchSpanProcessor) stopTimer() {
+	if !bsp.timer.Stop() {
+		select {
+		case <-bsp.timer.C:
+		default:
+		}
+	}
+}
+
+// watchOpenSpans exports the partial spans of the spans open for longer
+// than PartialSpanAge, and ends the spans open for longer than MaxSpanAge,
+// every time the shorter of the two elapses until the processor is shut
+// down.
+func (bsp *batchSpanProcessor) watchOpenSpans() {
+	period := bsp.o.MaxSpanAge
+	if p := bsp.o.PartialSpanAge; p > 0 && (period <= 0 || p < period) {
+		period = p
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	timeSourceSet := bsp.timeSourceSet
+	// next is when the period elapses on the time source once it is set.
+	var next time.Time
+	for {
+		select {
+		case <-bsp.stopCh:
+			return
+		case <-timeSourceSet:
+			timeSourceSet = nil
+			ticker.Reset(timeSourcePollInterval)
+			next = bsp.currentTime().Add(period)
+		case <-ticker.C:
+			if !next.IsZero() {
+				now := bsp.currentTime()
+				if now.Before(next) {
+					continue
+				}
+				next = now.Add(period)
+			}
+			if bsp.o.PartialSpanAge > 0 {
+				bsp.exportPartialSpans()
+			}
+			if bsp.o.MaxSpanAge > 0 {
+				bsp.endTimedOutSpans()
+			}
+		}
+	}
+}
+
+// partialSnapshot returns a snapshot of the open span s flagged as partial
+// and ended at now, the time of the snapshot.
+func partialSnapshot(s *recordingSpan, now time.Time) ReadOnlySpan {
+	snap := s.snapshot().(*snapshot)
+	snap.endTime = now
+	// The attributes are shared with s, do not append to them in place.
+	attrs := snap.attributes
+	snap.attributes = append(attrs[:len(attrs):len(attrs)], partialKey.Bool(true))
+	return snap
+}
+
+// exportPartialSpans queues a snapshot of every span open for longer than
+// PartialSpanAge whose partial span has not been exported yet.
+func (bsp *batchSpanProcessor) exportPartialSpans() {
+	var partial []ReadOnlySpan
+	bsp.openSpansMu.Lock()
+	now := bsp.now()
+	for k, s := range bsp.openSpans {
+		if _, ok := bsp.partialSpans[k]; ok {
+			continue
+		}
+		if now.Sub(s.StartTime()) <= bsp.o.PartialSpanAge {
+			continue
+		}
+		if rs, ok := s.(*recordingSpan); ok {
+			partial = append(partial, partialSnapshot(rs, now))
+			bsp.partialSpans[k] = struct{}{}
+		}
+	}
+	bsp.openSpansMu.Unlock()
+
+	for _, s := range partial {
+		bsp.enqueue(s)
+	}
+}
+
+// endTimedOutSpans ends all spans open for longer than MaxSpanAge, marking
+// them as timed out.
+func (bsp *batchSpanProcessor) endTimedOutSpans() {
+	var timedOut []ReadWriteSpan
+	bsp.openSpansMu.Lock()
+	now := bsp.now()
+	for k, s := range bsp.openSpans {
+		if now.Sub(s.StartTime()) > bsp.o.MaxSpanAge {
+			timedOut = append(timedOut, s)
+			delete(bsp.openSpans, k)
+			delete(bsp.partialSpans, k)
+		}
+	}
+	bsp.openSpansMu.Unlock()
+
+	// End the spans without holding the lock, OnEnd acquires it.
+	for _, s := range timedOut {
+		s.SetAttributes(timedOutKey.Bool(true))
+		s.SetStatus(codes.Error, "span exceeded the maximum age")
+		s.End(trace.WithTimestamp(now))
+	}
 }
 
 // Shutdown flushes the queue and waits until all spans are processed.
@@ -195,7 +595,9 @@ func (bsp *batchSpanProcessor) ForceFlush(ctx context.Context) error {
 
 		wait := make(chan error)
 		go func() {
-			wait <- bsp.exportSpans(ctx)
+			err := bsp.exportSpans(ctx)
+			bsp.waitExports()
+			wait <- err
 			close(wait)
 		}()
 		// Wait until the export is finished or the context is cancelled/timed out
@@ -242,6 +644,114 @@ func WithExportTimeout(timeout time.Duration) BatchSpanProcessorOption {
 	}
 }
 
+// WithMaxSpanAge returns a BatchSpanProcessorOption that configures a
+// BatchSpanProcessor to end, and export, sampled spans that have not ended
+// within age of their start. This surfaces leaked spans that would otherwise
+// never be exported.
+func WithMaxSpanAge(age time.Duration) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.MaxSpanAge = age
+	}
+}
+
+// WithPartialSpanExport returns a BatchSpanProcessorOption that configures
+// a BatchSpanProcessor to export a snapshot of sampled spans that have not
+// ended within age of their start, e.g. long streaming RPCs. This gives
+// backends an early signal of the spans. The snapshot is exported once,
+// with the otel.span.partial attribute set to true and the time of the
+// snapshot as its end time, and the span is exported as usual when it ends. Backends can reconcile the
+// two by their span ID.
+func WithPartialSpanExport(age time.Duration) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.PartialSpanAge = age
+	}
+}
+
+// WithExportLatencyRecorder returns a BatchSpanProcessorOption that
+// configures a BatchSpanProcessor to call record with the duration of every
+// export, and the error the export returned, if any. For example, record can
+// record the latency to a histogram of a metric Meter with an attribute
+// reporting if the export succeeded.
+//
+// The record function is called synchronously after each export, it should
+// not block.
+func WithExportLatencyRecorder(record func(ctx context.Context, latency time.Duration, err error)) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.ExportLatencyRecorder = record
+	}
+}
+
+// WithInternalMetrics returns a BatchSpanProcessorOption that configures the
+// counters the BatchSpanProcessor records the spans it processes to. This
+// allows the telemetry pipeline to be monitored, e.g. with the counters of a
+// metric.Meter:
+//
+//	started, _ := meter.SyncInt64().Counter("otel.sdk.bsp.spans.started")
+//	bsp := NewBatchSpanProcessor(exporter, WithInternalMetrics(BatchSpanProcessorMetrics{
+//		SpansStarted: started,
+//	}))
+func WithInternalMetrics(m BatchSpanProcessorMetrics) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.InternalMetrics = m
+	}
+}
+
+// WithQueueLatencyAttribute returns a BatchSpanProcessorOption that
+// configures a BatchSpanProcessor to set the otel.bsp.queue_latency_ms
+// attribute on every span it exports. Its value is the number of
+// milliseconds from when the span was ended, and queued, until it was
+// exported. This can be used to diagnose backpressure of the exporter.
+//
+// The attribute is added to the span passed to the exporter. It does not
+// count against the span limits of the span.
+func WithQueueLatencyAttribute() BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.RecordQueueLatency = true
+	}
+}
+
+// WithMaxConcurrentExports returns a BatchSpanProcessorOption that
+// configures a BatchSpanProcessor to export up to n batches concurrently.
+// This avoids a single slow export holding back all the spans, e.g. with
+// a high span volume and a distant backend. The exporter must be safe to
+// call concurrently.
+//
+// With n greater than 1, batches may be exported out of order, and export
+// errors are passed to the global error handler instead of being returned
+// by ForceFlush. ForceFlush and Shutdown still wait for all the exports in
+// flight to return.
+func WithMaxConcurrentExports(n int) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.MaxConcurrentExports = n
+	}
+}
+
+// WithDiskBuffer returns a BatchSpanProcessorOption that configures a
+// BatchSpanProcessor to persist the spans it queues to the file at path
+// until they are successfully exported. The spans left in the file when
+// the process exits, e.g. because it crashed, are exported by the next
+// BatchSpanProcessor created with the same path.
+//
+// Spans are written to the file by a dedicated goroutine as they are queued,
+// ending a span does not wait for the write and the writes do not wait for
+// the exports. Partial spans, see WithPartialSpanExport, are not persisted.
+//
+// The file holds at most maxBytes. Spans whose export fails stay in the file
+// and are exported again on the next start. When the file is full, e.g.
+// because the exports keep failing, the oldest spans are evicted from it so
+// new spans are still persisted. A single span larger than maxBytes is not
+// persisted.
+//
+// Spans are written to the file without syncing it, so they survive a crash
+// of the process but not of the operating system. The file must not be
+// shared by processors running concurrently.
+func WithDiskBuffer(path string, maxBytes int64) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.DiskBufferPath = path
+		o.DiskBufferMaxBytes = maxBytes
+	}
+}
+
 // WithBlocking returns a BatchSpanProcessorOption that configures a
 // BatchSpanProcessor to wait for enqueue operations to succeed instead of
 // dropping data when the queue is full.
@@ -253,20 +763,41 @@ func WithBlocking() BatchSpanProcessorOption {
 
 // exportSpans is a subroutine of processing and draining the queue.
 func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
-	bsp.timer.Reset(bsp.o.BatchTimeout)
+	bsp.resetBatchTimeout()
 
 	bsp.batchMutex.Lock()
 	defer bsp.batchMutex.Unlock()
 
-	if bsp.o.ExportTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, bsp.o.ExportTimeout)
-		defer cancel()
+	if dropped := atomic.LoadUint32(&bsp.dropped); dropped > bsp.reportedDropped {
+		global.Info("spans dropped because the queue is full", "count", dropped-bsp.reportedDropped, "total_dropped", dropped)
+		bsp.reportedDropped = dropped
+	}
+	if n := atomic.LoadUint32(&bsp.notPersisted); n > bsp.reportedNotPersisted {
+		global.Info("spans not persisted because the disk writes are behind", "count", n-bsp.reportedNotPersisted, "total_not_persisted", n)
+		bsp.reportedNotPersisted = n
 	}
 
 	if l := len(bsp.batch); l > 0 {
 		global.Debug("exporting spans", "count", len(bsp.batch), "total_dropped", atomic.LoadUint32(&bsp.dropped))
-		err := bsp.e.ExportSpans(ctx, bsp.batch)
+		if bsp.exportSem != nil {
+			// The batch is reused once this returns, export a copy.
+			batch := make([]ReadOnlySpan, l)
+			copy(batch, bsp.batch)
+			bsp.batch = bsp.batch[:0]
+
+			// Wait for a slot, this holds back the next batch while the
+			// maximum number of exports are in flight.
+			bsp.exportSem <- struct{}{}
+			go func() {
+				defer func() { <-bsp.exportSem }()
+				if err := bsp.export(ctx, batch); err != nil {
+					otel.Handle(err)
+				}
+			}()
+			return nil
+		}
+
+		err := bsp.export(ctx, bsp.batch)
 
 		// A new batch is always created after exporting, even if the batch failed to be exported.
 		//
@@ -281,6 +812,72 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 	return nil
 }
 
+// export exports batch with the exporter, applying the export timeout.
+func (bsp *batchSpanProcessor) export(ctx context.Context, batch []ReadOnlySpan) error {
+	if bsp.o.ExportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bsp.o.ExportTimeout)
+		defer cancel()
+	}
+
+	var refs []*diskRef
+	if bsp.disk != nil {
+		// Export the spans as they were queued.
+		for i, s := range batch {
+			if p, ok := s.(persistedSpan); ok {
+				refs = append(refs, p.ref)
+				batch[i] = p.ReadOnlySpan
+			}
+		}
+	}
+
+	if bsp.o.RecordQueueLatency {
+		now := bsp.currentTime()
+		for i, s := range batch {
+			if q, ok := s.(queuedSpan); ok {
+				q.latency = now.Sub(q.enqueued)
+				batch[i] = q
+			}
+		}
+	}
+	var start time.Time
+	if bsp.o.ExportLatencyRecorder != nil {
+		start = time.Now()
+	}
+	err := bsp.e.ExportSpans(ctx, batch)
+	if bsp.o.ExportLatencyRecorder != nil {
+		bsp.o.ExportLatencyRecorder(ctx, time.Since(start), err)
+	}
+	if err != nil {
+		bsp.o.InternalMetrics.add(ctx, bsp.o.InternalMetrics.SpansFailed, int64(len(batch)))
+	} else {
+		bsp.o.InternalMetrics.add(ctx, bsp.o.InternalMetrics.SpansExported, int64(len(batch)))
+		if bsp.disk != nil {
+			if rErr := bsp.disk.remove(refs); rErr != nil {
+				otel.Handle(rErr)
+			}
+		}
+	}
+	return err
+}
+
+// waitExports waits for all the concurrent exports in flight to return.
+func (bsp *batchSpanProcessor) waitExports() {
+	if bsp.exportSem == nil {
+		return
+	}
+	bsp.exportWaitMu.Lock()
+	defer bsp.exportWaitMu.Unlock()
+
+	// All the slots are only acquired once no export is in flight.
+	for i := 0; i < cap(bsp.exportSem); i++ {
+		bsp.exportSem <- struct{}{}
+	}
+	for i := 0; i < cap(bsp.exportSem); i++ {
+		<-bsp.exportSem
+	}
+}
+
 // processQueue removes spans from the `queue` channel until processor
 // is shut down. It calls the exporter in batches of up to MaxExportBatchSize
 // waiting up to BatchTimeout to form a batch.
@@ -289,10 +886,31 @@ func (bsp *batchSpanProcessor) processQueue() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	// Let the concurrent exports return before their context is canceled.
+	defer bsp.waitExports()
+
+	timeSourceSet := bsp.timeSourceSet
+	// poll receives every timeSourcePollInterval once the time source is
+	// set, the BatchTimeout then elapses on it.
+	var poll <-chan time.Time
 	for {
 		select {
 		case <-bsp.stopCh:
 			return
+		case <-timeSourceSet:
+			timeSourceSet = nil
+			bsp.stopTimer()
+			bsp.resetBatchTimeout()
+			ticker := time.NewTicker(timeSourcePollInterval)
+			defer ticker.Stop()
+			poll = ticker.C
+		case <-poll:
+			if !bsp.batchTimeoutElapsed() {
+				continue
+			}
+			if err := bsp.exportSpans(ctx); err != nil {
+				otel.Handle(err)
+			}
 		case <-bsp.timer.C:
 			if err := bsp.exportSpans(ctx); err != nil {
 				otel.Handle(err)
@@ -307,9 +925,7 @@ func (bsp *batchSpanProcessor) processQueue() {
 			shouldExport := len(bsp.batch) >= bsp.o.MaxExportBatchSize
 			bsp.batchMutex.Unlock()
 			if shouldExport {
-				if !bsp.timer.Stop() {
-					<-bsp.timer.C
-				}
+				bsp.stopTimer()
 				if err := bsp.exportSpans(ctx); err != nil {
 					otel.Handle(err)
 				}
@@ -323,6 +939,7 @@ func (bsp *batchSpanProcessor) processQueue() {
 func (bsp *batchSpanProcessor) drainQueue() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	defer bsp.waitExports()
 	for {
 		select {
 		case sd := <-bsp.queue:
@@ -349,13 +966,66 @@ func (bsp *batchSpanProcessor) drainQueue() {
 	}
 }
 
-func (bsp *batchSpanProcessor) enqueue(sd ReadOnlySpan) {
+// enqueueEnded queues the ended span sd and, if spans are persisted, sends
+// it to the goroutine writing them to disk. Spans are written independently
+// of the exports, so they are persisted while an export is blocked.
+func (bsp *batchSpanProcessor) enqueueEnded(sd ReadOnlySpan) {
+	if bsp.persistCh == nil {
+		bsp.enqueue(sd)
+		return
+	}
+
+	p := persistedSpan{ReadOnlySpan: sd, ref: &diskRef{}}
+	if !bsp.enqueue(p) {
+		return
+	}
+	select {
+	case bsp.persistCh <- p:
+	default:
+		atomic.AddUint32(&bsp.notPersisted, 1)
+	}
+}
+
+// persistSpans writes the spans sent to persistCh to the disk buffer until
+// the processor is shut down, and then the spans left in persistCh.
+func (bsp *batchSpanProcessor) persistSpans() {
+	defer close(bsp.persistDone)
+	for {
+		select {
+		case p := <-bsp.persistCh:
+			bsp.persist(p)
+		case <-bsp.stopCh:
+			for {
+				select {
+				case p := <-bsp.persistCh:
+					bsp.persist(p)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// persist writes p to the disk buffer unless it was exported already.
+func (bsp *batchSpanProcessor) persist(p persistedSpan) {
+	// Persist the span as it ended, the queue latency is recorded again
+	// when it is exported.
+	ended := p.ReadOnlySpan
+	if q, ok := ended.(queuedSpan); ok {
+		ended = q.ReadOnlySpan
+	}
+	if err := bsp.disk.add(p.ref, ended); err != nil {
+		global.Info("span not persisted", "error", err)
+	}
+}
+
+func (bsp *batchSpanProcessor) enqueue(sd ReadOnlySpan) bool {
 	ctx := context.TODO()
 	if bsp.o.BlockOnQueueFull {
-		bsp.enqueueBlockOnQueueFull(ctx, sd)
-	} else {
-		bsp.enqueueDrop(ctx, sd)
+		return bsp.enqueueBlockOnQueueFull(ctx, sd)
 	}
+	return bsp.enqueueDrop(ctx, sd)
 }
 
 func recoverSendOnClosedChan() {
@@ -414,6 +1084,7 @@ func (bsp *batchSpanProcessor) enqueueDrop(ctx context.Context, sd ReadOnlySpan)
 		return true
 	default:
 		atomic.AddUint32(&bsp.dropped, 1)
+		bsp.o.InternalMetrics.add(ctx, bsp.o.InternalMetrics.SpansDropped, 1)
 	}
 	return false
 }
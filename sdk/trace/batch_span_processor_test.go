@@ -15,11 +15,13 @@
 package trace_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +32,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/internal/env"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -530,6 +534,380 @@ func TestBatchSpanProcessorDropBatchIfFailed(t *testing.T) {
 	}
 }
 
+func TestBatchSpanProcessorExportLatencyRecorder(t *testing.T) {
+	exportErr := errors.New("fail to export")
+	te := testBatchExporter{errors: []error{exportErr}}
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		errs      []error
+	)
+	record := func(_ context.Context, latency time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		latencies = append(latencies, latency)
+		errs = append(errs, err)
+	}
+
+	tp := basicTracerProvider(t)
+	bsp := sdktrace.NewBatchSpanProcessor(&te, sdktrace.WithExportLatencyRecorder(record))
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorExportLatencyRecorder")
+
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+		_ = bsp.ForceFlush(context.Background())
+	}
+	// Nothing to export, no export latency to record.
+	require.NoError(t, bsp.ForceFlush(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, latencies, 3)
+	for _, l := range latencies {
+		assert.GreaterOrEqual(t, l, time.Duration(0))
+	}
+	assert.Equal(t, []error{exportErr, nil, nil}, errs)
+}
+
+// blockingExporter blocks all exports until release is closed, counting
+// the exports in flight.
+type blockingExporter struct {
+	release chan struct{}
+
+	mu          sync.Mutex
+	active      int
+	maxActive   int
+	exportCount int
+}
+
+func (e *blockingExporter) ExportSpans(ctx context.Context, _ []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	e.active++
+	if e.active > e.maxActive {
+		e.maxActive = e.active
+	}
+	e.mu.Unlock()
+
+	<-e.release
+
+	e.mu.Lock()
+	e.active--
+	e.exportCount++
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func (e *blockingExporter) counts() (active, maxActive, exportCount int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.active, e.maxActive, e.exportCount
+}
+
+func TestBatchSpanProcessorMaxConcurrentExports(t *testing.T) {
+	const n = 3
+	be := &blockingExporter{release: make(chan struct{})}
+	tp := basicTracerProvider(t)
+	bsp := sdktrace.NewBatchSpanProcessor(
+		be,
+		sdktrace.WithMaxConcurrentExports(n),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBatchTimeout(time.Hour),
+	)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorMaxConcurrentExports")
+
+	for i := 0; i < 2*n; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+
+	assert.Eventually(t, func() bool {
+		active, _, _ := be.counts()
+		return active == n
+	}, time.Second, time.Millisecond, "exports did not run concurrently")
+	// No more than n exports are started while they are blocked.
+	time.Sleep(10 * time.Millisecond)
+	_, maxActive, exportCount := be.counts()
+	assert.Equal(t, n, maxActive)
+	assert.Equal(t, 0, exportCount)
+
+	close(be.release)
+	require.NoError(t, bsp.ForceFlush(context.Background()))
+	active, maxActive, exportCount := be.counts()
+	assert.Equal(t, 0, active)
+	assert.Equal(t, n, maxActive)
+	assert.Equal(t, 2*n, exportCount)
+	require.NoError(t, bsp.Shutdown(context.Background()))
+}
+
+type recordingCounter struct {
+	mu  sync.Mutex
+	sum int64
+}
+
+func (c *recordingCounter) Add(_ context.Context, incr int64, _ ...attribute.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sum += incr
+}
+
+func (c *recordingCounter) value() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sum
+}
+
+func newTestBatchSpanProcessorMetrics() (sdktrace.BatchSpanProcessorMetrics, map[string]*recordingCounter) {
+	counters := map[string]*recordingCounter{
+		"started":  {},
+		"ended":    {},
+		"dropped":  {},
+		"exported": {},
+		"failed":   {},
+	}
+	return sdktrace.BatchSpanProcessorMetrics{
+		SpansStarted:  counters["started"],
+		SpansEnded:    counters["ended"],
+		SpansDropped:  counters["dropped"],
+		SpansExported: counters["exported"],
+		SpansFailed:   counters["failed"],
+	}, counters
+}
+
+func TestBatchSpanProcessorInternalMetrics(t *testing.T) {
+	exportErr := errors.New("fail to export")
+	te := testBatchExporter{errors: []error{exportErr}}
+	m, counters := newTestBatchSpanProcessorMetrics()
+
+	tp := basicTracerProvider(t)
+	bsp := sdktrace.NewBatchSpanProcessor(&te, sdktrace.WithInternalMetrics(m))
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorInternalMetrics")
+
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+		_ = bsp.ForceFlush(context.Background())
+	}
+	// Open spans are started but not ended.
+	_, open := tr.Start(context.Background(), "open")
+	defer open.End()
+
+	assert.Equal(t, int64(4), counters["started"].value())
+	assert.Equal(t, int64(3), counters["ended"].value())
+	assert.Equal(t, int64(0), counters["dropped"].value())
+	assert.Equal(t, int64(2), counters["exported"].value())
+	assert.Equal(t, int64(1), counters["failed"].value())
+}
+
+func TestBatchSpanProcessorInternalMetricsDropped(t *testing.T) {
+	const n = 5
+	be := &blockingExporter{release: make(chan struct{})}
+	m, counters := newTestBatchSpanProcessorMetrics()
+
+	tp := basicTracerProvider(t)
+	bsp := sdktrace.NewBatchSpanProcessor(
+		be,
+		sdktrace.WithInternalMetrics(m),
+		sdktrace.WithMaxQueueSize(1),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBatchTimeout(time.Hour),
+	)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorInternalMetricsDropped")
+
+	_, span := tr.Start(context.Background(), "span")
+	span.End()
+	require.Eventually(t, func() bool {
+		active, _, _ := be.counts()
+		return active == 1
+	}, time.Second, time.Millisecond, "export did not start")
+
+	// One span fits in the queue while the export is blocked, the rest are
+	// dropped.
+	for i := 1; i < n; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	close(be.release)
+	require.NoError(t, bsp.Shutdown(context.Background()))
+
+	assert.Equal(t, int64(n), counters["started"].value())
+	assert.Equal(t, int64(n), counters["ended"].value())
+	assert.Equal(t, int64(n-2), counters["dropped"].value())
+	assert.Equal(t, int64(2), counters["exported"].value())
+	assert.Equal(t, int64(0), counters["failed"].value())
+}
+
+func TestBatchSpanProcessorQueueLatencyAttribute(t *testing.T) {
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		mu  sync.Mutex
+		now = start
+	)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	exp := tracetest.NewInMemoryExporter()
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithQueueLatencyAttribute())
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(bsp),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithTimeSource(clock),
+	)
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	attr := attribute.String("key", "value")
+	_, span := tp.Tracer("BatchSpanProcessorQueueLatencyAttribute").Start(
+		context.Background(),
+		"span",
+		trace.WithAttributes(attr),
+	)
+	span.End()
+
+	mu.Lock()
+	now = start.Add(250 * time.Millisecond)
+	mu.Unlock()
+	require.NoError(t, bsp.ForceFlush(context.Background()))
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{
+		attr,
+		attribute.Int64("otel.bsp.queue_latency_ms", 250),
+	}, spans[0].Attributes)
+}
+
+func TestBatchSpanProcessorDiskBuffer(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spans")
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+	// The spans are persisted but the process crashes before they are
+	// exported.
+	rec := tracetest.NewSpanRecorder()
+	crashed := sdktrace.NewBatchSpanProcessor(
+		&testBatchExporter{},
+		sdktrace.WithBatchTimeout(time.Hour),
+		sdktrace.WithDiskBuffer(path, 1<<20),
+	)
+	t.Cleanup(func() { _ = crashed.Shutdown(ctx) })
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(crashed),
+		sdktrace.WithSpanProcessor(rec),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	tr := tp.Tracer("BatchSpanProcessorDiskBuffer", trace.WithInstrumentationVersion("v0.1.0"))
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(ctx, fmt.Sprint("span", i),
+			trace.WithTimestamp(start),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.Int("i", i),
+				attribute.Bool("bool", true),
+				attribute.Float64("float", 1.5),
+				attribute.StringSlice("strings", []string{"a", "b"}),
+			),
+			trace.WithLinks(trace.Link{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    trace.TraceID{0x01},
+				SpanID:     trace.SpanID{0x02},
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			})}),
+		)
+		span.AddEvent("event", trace.WithTimestamp(start), trace.WithAttributes(attribute.Int64Slice("ints", []int64{1, 2})))
+		span.SetStatus(codes.Error, "failed")
+		span.End(trace.WithTimestamp(start.Add(time.Second)))
+	}
+	// The spans are persisted as they are queued.
+	require.Eventually(t, func() bool {
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return bytes.Count(b, []byte("\n")) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	// The next process exports them.
+	exp := tracetest.NewInMemoryExporter()
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithDiskBuffer(path, 1<<20))
+	require.Eventually(t, func() bool {
+		require.NoError(t, bsp.ForceFlush(ctx))
+		return len(exp.GetSpans()) == 3
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, tracetest.SpanStubsFromReadOnlySpans(rec.Ended()), exp.GetSpans())
+	require.NoError(t, bsp.Shutdown(ctx))
+
+	// Exported spans are removed.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	exp.Reset()
+	bsp = sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithDiskBuffer(path, 1<<20))
+	require.NoError(t, bsp.ForceFlush(ctx))
+	require.NoError(t, bsp.Shutdown(ctx))
+	assert.Empty(t, exp.GetSpans())
+}
+
+func TestBatchSpanProcessorDiskBufferBlockedExport(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spans")
+
+	exp := &blockingExporter{release: make(chan struct{})}
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithDiskBuffer(path, 1<<20),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorDiskBufferBlockedExport")
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(ctx, fmt.Sprint("span", i))
+		span.End()
+	}
+
+	// The spans are persisted while the first export is blocked.
+	require.Eventually(t, func() bool {
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return bytes.Count(b, []byte("\n")) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	close(exp.release)
+	require.NoError(t, tp.Shutdown(ctx))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
+func TestBatchSpanProcessorDiskBufferFull(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spans")
+
+	// The spans do not fit in the buffer, they are exported but not
+	// persisted.
+	te := &testBatchExporter{}
+	bsp := sdktrace.NewBatchSpanProcessor(te, sdktrace.WithDiskBuffer(path, 10))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	_, span := tp.Tracer("BatchSpanProcessorDiskBufferFull").Start(ctx, "span")
+	span.End()
+	require.NoError(t, tp.Shutdown(ctx))
+	assert.Equal(t, 1, te.len())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
 func assertMaxSpanDiff(t *testing.T, want, got, maxDif int) {
 	spanDifference := want - got
 	if spanDifference < 0 {
@@ -17,6 +17,8 @@ package trace_test
 import (
 	"context"
 	"fmt"
+	"io"
+	rt "runtime/trace"
 	"testing"
 	"time"
 
@@ -143,6 +145,37 @@ func BenchmarkSpanSetAttributesOverCapacity(b *testing.B) {
 	}
 }
 
+func BenchmarkSetAttributesNotRecording(b *testing.B) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+	tracer := tp.Tracer("BenchmarkSetAttributesNotRecording")
+	ctx := context.Background()
+	attrs := func() []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("key1", fmt.Sprint("value", 1)),
+			attribute.Int("key2", 2),
+			attribute.StringSlice("key3", []string{"a", "b"}),
+		}
+	}
+
+	b.Run("SetAttributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, span := tracer.Start(ctx, "/foo")
+			span.SetAttributes(attrs()...)
+			span.End()
+		}
+	})
+
+	b.Run("SetAttributesIfRecording", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, span := tracer.Start(ctx, "/foo")
+			trace.SetAttributesIfRecording(span, attrs)
+			span.End()
+		}
+	})
+}
+
 func BenchmarkStartEndSpan(b *testing.B) {
 	traceBenchmark(b, "Benchmark StartEndSpan", func(b *testing.B, t trace.Tracer) {
 		ctx := context.Background()
@@ -154,6 +187,45 @@ func BenchmarkStartEndSpan(b *testing.B) {
 	})
 }
 
+func BenchmarkStartIfSampledEndSpan(b *testing.B) {
+	traceBenchmark(b, "Benchmark StartIfSampledEndSpan", func(b *testing.B, t trace.Tracer) {
+		ctx := context.Background()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, span, _ := sdktrace.StartIfSampled(ctx, t, "/foo")
+			span.End()
+		}
+	})
+}
+
+func BenchmarkStartEndSpanExecutionTracer(b *testing.B) {
+	if rt.IsEnabled() {
+		b.Skip("execution tracer already running")
+	}
+	if err := rt.Start(io.Discard); err != nil {
+		b.Fatal(err)
+	}
+	defer rt.Stop()
+
+	for _, enabled := range []bool{true, false} {
+		b.Run(fmt.Sprintf("Enabled=%t", enabled), func(b *testing.B) {
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithSampler(sdktrace.AlwaysSample()),
+				sdktrace.WithExecutionTracer(enabled),
+			)
+			tracer := tp.Tracer(b.Name())
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, span := tracer.Start(ctx, "/foo")
+				span.End()
+			}
+		})
+	}
+}
+
 func BenchmarkSpanWithAttributes_4(b *testing.B) {
 	traceBenchmark(b, "Benchmark Start With 4 Attributes", func(b *testing.B, t trace.Tracer) {
 		ctx := context.Background()
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Defaults for CardinalityOptions.
+const (
+	DefaultCardinalityWindow = time.Hour
+)
+
+// CardinalityOption configures a cardinality SpanProcessor.
+type CardinalityOption func(o *CardinalityOptions)
+
+// CardinalityOptions is configuration settings for a cardinality
+// SpanProcessor.
+type CardinalityOptions struct {
+	// Window is the duration over which the distinct values of each key
+	// are counted. The counts are reset, and keys can be warned about
+	// again, at the end of each window.
+	// The default value of Window is one hour.
+	Window time.Duration
+
+	// Keys are the attribute keys whose values are counted.
+	// The default value of Keys is nil, meaning the values of all keys are
+	// counted.
+	Keys []attribute.Key
+}
+
+// WithCardinalityWindow returns a CardinalityOption that sets the duration
+// over which the distinct values of each key are counted.
+func WithCardinalityWindow(window time.Duration) CardinalityOption {
+	return func(o *CardinalityOptions) {
+		o.Window = window
+	}
+}
+
+// WithCardinalityKeys returns a CardinalityOption that restricts the
+// attribute keys whose values are counted to keys.
+func WithCardinalityKeys(keys ...attribute.Key) CardinalityOption {
+	return func(o *CardinalityOptions) {
+		o.Keys = append(o.Keys, keys...)
+	}
+}
+
+// cardinalitySpanProcessor is a SpanProcessor that estimates the number of
+// distinct values of the attributes of ended spans.
+type cardinalitySpanProcessor struct {
+	threshold int
+	window    time.Duration
+	keys      map[attribute.Key]struct{}
+
+	mu          sync.Mutex
+	now         func() time.Time
+	windowStart time.Time
+	estimators  map[attribute.Key]*hyperLogLog
+	warned      map[attribute.Key]struct{}
+}
+
+var _ SpanProcessor = (*cardinalitySpanProcessor)(nil)
+
+// NewCardinalitySpanProcessor returns a new SpanProcessor that estimates the
+// number of distinct values of each attribute key of ended spans, and sends
+// an error to the global error handler when it exceeds threshold. Each key
+// is reported at most once per window.
+//
+// The estimation uses a HyperLogLog sketch of about 1KiB per key, it has a
+// relative error of about 3%. This is intended to detect attributes whose
+// values are unbounded, such as a user ID set as a route, before they reach
+// a backend. The spans themselves are not modified.
+func NewCardinalitySpanProcessor(threshold int, options ...CardinalityOption) SpanProcessor {
+	o := CardinalityOptions{
+		Window: DefaultCardinalityWindow,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.Window <= 0 {
+		o.Window = DefaultCardinalityWindow
+	}
+
+	csp := &cardinalitySpanProcessor{
+		threshold:  threshold,
+		window:     o.Window,
+		now:        time.Now,
+		estimators: make(map[attribute.Key]*hyperLogLog),
+		warned:     make(map[attribute.Key]struct{}),
+	}
+	if len(o.Keys) > 0 {
+		csp.keys = make(map[attribute.Key]struct{}, len(o.Keys))
+		for _, k := range o.Keys {
+			csp.keys[k] = struct{}{}
+		}
+	}
+	return csp
+}
+
+// OnStart does nothing.
+func (csp *cardinalitySpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnd adds the attribute values of s to the estimates.
+func (csp *cardinalitySpanProcessor) OnEnd(s ReadOnlySpan) {
+	attrs := s.Attributes()
+	if len(attrs) == 0 {
+		return
+	}
+
+	var exceeded []attribute.Key
+	csp.mu.Lock()
+	if now := csp.now(); csp.windowStart.IsZero() || now.Sub(csp.windowStart) >= csp.window {
+		csp.windowStart = now
+		csp.estimators = make(map[attribute.Key]*hyperLogLog)
+		csp.warned = make(map[attribute.Key]struct{})
+	}
+	for _, kv := range attrs {
+		if csp.keys != nil {
+			if _, ok := csp.keys[kv.Key]; !ok {
+				continue
+			}
+		}
+		if _, ok := csp.warned[kv.Key]; ok {
+			continue
+		}
+		hll, ok := csp.estimators[kv.Key]
+		if !ok {
+			hll = newHyperLogLog()
+			csp.estimators[kv.Key] = hll
+		}
+		if hll.add(kv.Value.Emit()) && hll.estimate() > float64(csp.threshold) {
+			csp.warned[kv.Key] = struct{}{}
+			// The estimator is no longer needed for this window.
+			delete(csp.estimators, kv.Key)
+			exceeded = append(exceeded, kv.Key)
+		}
+	}
+	window := csp.window
+	csp.mu.Unlock()
+
+	for _, k := range exceeded {
+		otel.Handle(fmt.Errorf("attribute %q has more than %d distinct values in %s", k, csp.threshold, window))
+	}
+}
+
+// Shutdown does nothing as there is no data to flush.
+func (csp *cardinalitySpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing as there is no data to flush.
+func (csp *cardinalitySpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// setTimeSource sets the source of the current time used to determine the
+// windows.
+func (csp *cardinalitySpanProcessor) setTimeSource(now func() time.Time) {
+	csp.mu.Lock()
+	csp.now = now
+	csp.mu.Unlock()
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Span Processor.
+func (csp *cardinalitySpanProcessor) MarshalLog() interface{} {
+	return struct {
+		Type      string
+		Threshold int
+		Window    time.Duration
+	}{
+		Type:      "CardinalitySpanProcessor",
+		Threshold: csp.threshold,
+		Window:    csp.window,
+	}
+}
+
+// hyperLogLogPrecision is the number of hash bits used to select a register.
+const hyperLogLogPrecision = 10
+
+// hyperLogLog estimates the number of distinct strings added to it.
+type hyperLogLog struct {
+	registers [1 << hyperLogLogPrecision]uint8
+	// sum is the sum of 2^-register over all registers, and zeros the
+	// number of registers still zero. They are kept up to date so the
+	// estimate is computed in constant time.
+	sum   float64
+	zeros int
+}
+
+func newHyperLogLog() *hyperLogLog {
+	const m = 1 << hyperLogLogPrecision
+	return &hyperLogLog{sum: m, zeros: m}
+}
+
+// add adds s to h and returns if the estimate changed.
+func (h *hyperLogLog) add(s string) bool {
+	x := hashString(s)
+	idx := x >> (64 - hyperLogLogPrecision)
+	w := x<<hyperLogLogPrecision | 1<<(hyperLogLogPrecision-1)
+	rank := uint8(bits.LeadingZeros64(w) + 1)
+
+	old := h.registers[idx]
+	if rank <= old {
+		return false
+	}
+	h.registers[idx] = rank
+	h.sum += math.Ldexp(1, -int(rank)) - math.Ldexp(1, -int(old))
+	if old == 0 {
+		h.zeros--
+	}
+	return true
+}
+
+// estimate returns the estimated number of distinct strings added to h.
+func (h *hyperLogLog) estimate() float64 {
+	const m = float64(1 << hyperLogLogPrecision)
+	alpha := 0.7213 / (1 + 1.079/m)
+	e := alpha * m * m / h.sum
+	if e <= 2.5*m && h.zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		return m * math.Log(m/float64(h.zeros))
+	}
+	return e
+}
+
+// hashString returns the 64-bit FNV-1a hash of s, finalized to spread its
+// entropy to all bits.
+func hashString(s string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	// The finalizer of MurmurHash3.
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCardinalitySpanProcessor(t *testing.T) {
+	handler.Reset()
+	defer handler.Reset()
+
+	now := time.Unix(0, 0)
+	csp := NewCardinalitySpanProcessor(100, WithCardinalityWindow(time.Minute))
+	tp := NewTracerProvider(
+		WithSpanProcessor(csp),
+		WithTimeSource(func() time.Time { return now }),
+	)
+	tr := tp.Tracer("Cardinality")
+
+	start := func(route string) {
+		_, span := tr.Start(context.Background(), "span", trace.WithAttributes(
+			attribute.String("http.route", route),
+			attribute.String("http.method", "GET"),
+		))
+		span.End()
+	}
+
+	// The estimate is approximate, stay clear of the threshold.
+	for i := 0; i < 80; i++ {
+		start(fmt.Sprintf("/users/%d", i))
+	}
+	assert.Empty(t, handler.errs, "below the threshold")
+
+	for i := 80; i < 1000; i++ {
+		start(fmt.Sprintf("/users/%d", i))
+	}
+	require.Len(t, handler.errs, 1)
+	assert.EqualError(t, handler.errs[0], `attribute "http.route" has more than 100 distinct values in 1m0s`)
+
+	// Keys are warned about again in the next window only.
+	handler.Reset()
+	for i := 0; i < 1000; i++ {
+		start(fmt.Sprintf("/orders/%d", i))
+	}
+	assert.Empty(t, handler.errs)
+
+	now = now.Add(time.Minute)
+	for i := 0; i < 1000; i++ {
+		start(fmt.Sprintf("/orders/%d", i))
+	}
+	assert.Len(t, handler.errs, 1)
+}
+
+func TestCardinalitySpanProcessorKeys(t *testing.T) {
+	handler.Reset()
+	defer handler.Reset()
+
+	csp := NewCardinalitySpanProcessor(10, WithCardinalityKeys("http.route"))
+	tr := NewTracerProvider(WithSpanProcessor(csp)).Tracer("Cardinality")
+	for i := 0; i < 100; i++ {
+		_, span := tr.Start(context.Background(), "span", trace.WithAttributes(
+			attribute.Int("user.id", i),
+		))
+		span.End()
+	}
+	assert.Empty(t, handler.errs)
+}
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		h := newHyperLogLog()
+		for i := 0; i < n; i++ {
+			h.add(fmt.Sprint("value-", i))
+			// Duplicates are not counted.
+			h.add(fmt.Sprint("value-", i))
+		}
+		assert.InEpsilon(t, n, h.estimate(), 0.1, "%d distinct values", n)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"sync"
+)
+
+// ChannelFullBehavior defines what a channel SpanProcessor does with an
+// ended span when its channel is full.
+type ChannelFullBehavior int
+
+const (
+	// DropOnFullChannel drops the ended span when the channel is full.
+	DropOnFullChannel ChannelFullBehavior = iota
+	// BlockOnFullChannel blocks the ending of the span until the channel is
+	// no longer full, or the SpanProcessor is shut down.
+	BlockOnFullChannel
+)
+
+// channelSpanProcessor is a SpanProcessor that sends all completed Spans to
+// a channel.
+type channelSpanProcessor struct {
+	// chMu guards the closing of ch against sends to it.
+	chMu   sync.RWMutex
+	ch     chan<- ReadOnlySpan
+	onFull ChannelFullBehavior
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+var _ SpanProcessor = (*channelSpanProcessor)(nil)
+
+// NewChannelSpanProcessor returns a new SpanProcessor that sends completed
+// sampled spans to ch. When ch is full, a completed span is either dropped
+// or waited on to be sent according to onFull.
+//
+// The SpanProcessor closes ch when it is shut down, ch must not be closed by
+// anything else. Spans completed after the SpanProcessor is shut down are
+// dropped.
+func NewChannelSpanProcessor(ch chan<- ReadOnlySpan, onFull ChannelFullBehavior) SpanProcessor {
+	return &channelSpanProcessor{
+		ch:     ch,
+		onFull: onFull,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// OnStart does nothing.
+func (csp *channelSpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnd sends a ReadOnlySpan to the channel.
+func (csp *channelSpanProcessor) OnEnd(s ReadOnlySpan) {
+	if !s.SpanContext().TraceFlags().IsSampled() {
+		return
+	}
+
+	csp.chMu.RLock()
+	defer csp.chMu.RUnlock()
+
+	select {
+	case <-csp.stopCh:
+		return
+	default:
+	}
+
+	if csp.onFull == BlockOnFullChannel {
+		select {
+		case csp.ch <- s:
+		case <-csp.stopCh:
+		}
+		return
+	}
+
+	select {
+	case csp.ch <- s:
+	default:
+	}
+}
+
+// Shutdown closes the channel spans are sent to. Sends blocked on a full
+// channel are abandoned.
+func (csp *channelSpanProcessor) Shutdown(context.Context) error {
+	csp.stopOnce.Do(func() {
+		// Unblock all senders before waiting for them to return.
+		close(csp.stopCh)
+
+		csp.chMu.Lock()
+		close(csp.ch)
+		csp.chMu.Unlock()
+	})
+	return nil
+}
+
+// ForceFlush does nothing as there is no data to flush.
+func (csp *channelSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Span Processor.
+func (csp *channelSpanProcessor) MarshalLog() interface{} {
+	return struct {
+		Type   string
+		OnFull ChannelFullBehavior
+	}{
+		Type:   "ChannelSpanProcessor",
+		OnFull: csp.onFull,
+	}
+}
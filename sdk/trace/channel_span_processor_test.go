@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestChannelSpanProcessorDrop(t *testing.T) {
+	ch := make(chan sdktrace.ReadOnlySpan, 1)
+	csp := sdktrace.NewChannelSpanProcessor(ch, sdktrace.DropOnFullChannel)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(csp)
+	tr := tp.Tracer("ChannelSpanProcessorDrop")
+
+	for _, name := range []string{"span0", "span1", "span2"} {
+		_, span := tr.Start(context.Background(), name)
+		span.End()
+	}
+
+	require.NoError(t, csp.Shutdown(context.Background()))
+	var got []string
+	for s := range ch {
+		got = append(got, s.Name())
+	}
+	assert.Equal(t, []string{"span0"}, got)
+}
+
+func TestChannelSpanProcessorBlock(t *testing.T) {
+	ch := make(chan sdktrace.ReadOnlySpan)
+	csp := sdktrace.NewChannelSpanProcessor(ch, sdktrace.BlockOnFullChannel)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(csp)
+	tr := tp.Tracer("ChannelSpanProcessorBlock")
+
+	ended := make(chan struct{})
+	go func() {
+		_, span := tr.Start(context.Background(), "span0")
+		span.End()
+		close(ended)
+	}()
+
+	select {
+	case <-ended:
+		t.Fatal("span ended without its channel being read")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s := <-ch
+	assert.Equal(t, "span0", s.Name())
+	<-ended
+
+	// A blocked span is released by the shut down.
+	blocked := make(chan struct{})
+	go func() {
+		_, span := tr.Start(context.Background(), "span1")
+		span.End()
+		close(blocked)
+	}()
+	require.NoError(t, csp.Shutdown(context.Background()))
+	<-blocked
+	_, ok := <-ch
+	assert.False(t, ok, "channel not closed")
+}
+
+func TestChannelSpanProcessorPostShutdown(t *testing.T) {
+	ch := make(chan sdktrace.ReadOnlySpan, 1)
+	csp := sdktrace.NewChannelSpanProcessor(ch, sdktrace.BlockOnFullChannel)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(csp)
+	tr := tp.Tracer("ChannelSpanProcessorPostShutdown")
+
+	require.NoError(t, csp.Shutdown(context.Background()))
+	require.NoError(t, csp.Shutdown(context.Background()))
+
+	assert.NotPanics(t, func() {
+		_, span := tr.Start(context.Background(), "span0")
+		span.End()
+	})
+	_, ok := <-ch
+	assert.False(t, ok, "span sent after shut down")
+}
+
+func TestChannelSpanProcessorNotSampled(t *testing.T) {
+	ch := make(chan sdktrace.ReadOnlySpan, 1)
+	csp := sdktrace.NewChannelSpanProcessor(ch, sdktrace.DropOnFullChannel)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+		sdktrace.WithSpanProcessor(csp),
+	)
+
+	_, span := tp.Tracer("ChannelSpanProcessorNotSampled").Start(context.Background(), "span0")
+	span.End()
+
+	assert.Len(t, ch, 0)
+}
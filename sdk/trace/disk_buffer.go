@@ -0,0 +1,615 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errDiskBufferFull is returned when a span does not fit in a disk buffer.
+var errDiskBufferFull = errors.New("disk buffer full")
+
+// diskBuffer is a write-ahead log of the spans queued by a
+// batchSpanProcessor. Each span is appended to the file when it is queued,
+// and its removal is appended once it is exported. The spans not removed
+// are replayed when the file is opened again, e.g. after a crash.
+type diskBuffer struct {
+	path     string
+	maxBytes int64
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+	// pending holds the encoded entries of the spans not removed yet by
+	// their sequence number.
+	pending      map[uint64][]byte
+	pendingBytes int64
+	nextSeq      uint64
+}
+
+// diskEntry is a line of the file of a diskBuffer. It either holds a span
+// or the sequence numbers of removed spans.
+type diskEntry struct {
+	Seq     uint64    `json:"seq,omitempty"`
+	Span    *diskSpan `json:"span,omitempty"`
+	Removed []uint64  `json:"removed,omitempty"`
+}
+
+// persistedSpan is a span queued by a batchSpanProcessor and persisted to
+// its diskBuffer.
+type persistedSpan struct {
+	ReadOnlySpan
+
+	// ref is the state of the span in the diskBuffer.
+	ref *diskRef
+}
+
+// diskRef is the state of a span in a diskBuffer. It is guarded by the mutex
+// of the diskBuffer.
+type diskRef struct {
+	// seq is the sequence number of the span, it is set once the span is
+	// written.
+	seq     uint64
+	written bool
+	// removed is set if the span is removed before it is written, it is
+	// then never written.
+	removed bool
+}
+
+// openDiskBuffer opens the disk buffer at path, creating it if it does not
+// exist, and returns it along with the spans it holds that were never
+// removed.
+func openDiskBuffer(path string, maxBytes int64) (*diskBuffer, []persistedSpan, error) {
+	d := &diskBuffer{
+		path:     path,
+		maxBytes: maxBytes,
+		pending:  make(map[uint64][]byte),
+		nextSeq:  1,
+	}
+
+	var replayed []persistedSpan
+	if f, err := os.Open(path); err == nil {
+		replayed = d.load(f)
+		_ = f.Close()
+	} else if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	// Rewrite the file with the pending spans only.
+	if err := d.compact(); err != nil {
+		return nil, nil, err
+	}
+	return d, replayed, nil
+}
+
+// load reads the entries of f and returns the spans not removed. Entries
+// that cannot be decoded, e.g. the last one written when the process
+// crashed, are skipped.
+func (d *diskBuffer) load(f *os.File) []persistedSpan {
+	spans := make(map[uint64]ReadOnlySpan)
+	// A line holding a span is never longer than maxBytes.
+	maxLine := int64(bufio.MaxScanTokenSize)
+	if d.maxBytes+1 > maxLine {
+		maxLine = d.maxBytes + 1
+	}
+	if maxLine > math.MaxInt32 {
+		maxLine = math.MaxInt32
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, int(maxLine))
+	for scanner.Scan() {
+		var e diskEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		for _, seq := range e.Removed {
+			delete(spans, seq)
+			d.forget(seq)
+		}
+		if e.Span == nil {
+			continue
+		}
+		s, err := e.Span.snapshot()
+		if err != nil {
+			continue
+		}
+		spans[e.Seq] = s
+		line := append([]byte(nil), scanner.Bytes()...)
+		d.pending[e.Seq] = append(line, '\n')
+		d.pendingBytes += int64(len(line) + 1)
+		if e.Seq >= d.nextSeq {
+			d.nextSeq = e.Seq + 1
+		}
+	}
+
+	replayed := make([]persistedSpan, 0, len(spans))
+	for seq, s := range spans {
+		replayed = append(replayed, persistedSpan{
+			ReadOnlySpan: s,
+			ref:          &diskRef{seq: seq, written: true},
+		})
+	}
+	sort.Slice(replayed, func(i, j int) bool { return replayed[i].ref.seq < replayed[j].ref.seq })
+	return replayed
+}
+
+// add appends s to the file and records it in ref, unless ref was removed
+// already. The errDiskBufferFull error is returned if s does not fit within
+// maxBytes.
+func (d *diskBuffer) add(ref *diskRef, s ReadOnlySpan) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ref.removed {
+		return nil
+	}
+	seq := d.nextSeq
+	line, err := json.Marshal(diskEntry{Seq: seq, Span: newDiskSpan(s)})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if err := d.ensureRoom(int64(len(line))); err != nil {
+		return err
+	}
+	if _, err := d.f.Write(line); err != nil {
+		return err
+	}
+	d.size += int64(len(line))
+	d.nextSeq++
+	d.pending[seq] = line
+	d.pendingBytes += int64(len(line))
+	ref.seq, ref.written = seq, true
+	return nil
+}
+
+// remove removes the spans of refs. The spans not written yet are never
+// written. The file is truncated once no span is left.
+func (d *diskBuffer) remove(refs []*diskRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var seqs []uint64
+	for _, ref := range refs {
+		if !ref.written {
+			ref.removed = true
+			continue
+		}
+		// The span may have been evicted already.
+		if _, ok := d.pending[ref.seq]; ok {
+			d.forget(ref.seq)
+			seqs = append(seqs, ref.seq)
+		}
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+	if len(d.pending) == 0 {
+		if err := d.f.Truncate(0); err != nil {
+			return err
+		}
+		d.size = 0
+		_, err := d.f.Seek(0, 0)
+		return err
+	}
+
+	line, err := json.Marshal(diskEntry{Removed: seqs})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if err := d.ensureRoom(int64(len(line))); err != nil {
+		return err
+	}
+	if _, err := d.f.Write(line); err != nil {
+		return err
+	}
+	d.size += int64(len(line))
+	return nil
+}
+
+// forget drops the pending entry of seq, if any.
+func (d *diskBuffer) forget(seq uint64) {
+	if line, ok := d.pending[seq]; ok {
+		d.pendingBytes -= int64(len(line))
+		delete(d.pending, seq)
+	}
+}
+
+// ensureRoom makes room for n more bytes in the file, compacting it if
+// needed. If the pending spans fill the file, e.g. because their exports
+// failed, the oldest ones are evicted until a quarter of the file is free, so
+// a full file is not rewritten for every span.
+func (d *diskBuffer) ensureRoom(n int64) error {
+	if d.size+n <= d.maxBytes {
+		return nil
+	}
+	if n > d.maxBytes {
+		return errDiskBufferFull
+	}
+	if d.pendingBytes+n > d.maxBytes {
+		seqs := make([]uint64, 0, len(d.pending))
+		for seq := range d.pending {
+			seqs = append(seqs, seq)
+		}
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+		limit := d.maxBytes - d.maxBytes/4
+		var evicted int
+		for _, seq := range seqs {
+			if d.pendingBytes+n <= limit {
+				break
+			}
+			d.forget(seq)
+			evicted++
+		}
+		global.Info("persisted spans evicted from the full disk buffer", "count", evicted)
+	}
+	return d.compact()
+}
+
+// compact replaces the file with one holding the pending entries only.
+func (d *diskBuffer) compact() error {
+	seqs := make([]uint64, 0, len(d.pending))
+	for seq := range d.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	tmp := d.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, seq := range seqs {
+		if _, err := w.Write(d.pending[seq]); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return err
+	}
+
+	if d.f != nil {
+		_ = d.f.Close()
+	}
+	d.f, err = os.OpenFile(d.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	d.size = d.pendingBytes
+	return nil
+}
+
+// close closes the file. The spans not removed are kept in it.
+func (d *diskBuffer) close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.f.Close()
+}
+
+// diskSpan is the JSON representation of a ReadOnlySpan in a diskBuffer.
+type diskSpan struct {
+	Name                  string          `json:"name"`
+	SpanContext           diskSpanContext `json:"span_context"`
+	Parent                diskSpanContext `json:"parent"`
+	SpanKind              trace.SpanKind  `json:"span_kind"`
+	StartTime             time.Time       `json:"start_time"`
+	EndTime               time.Time       `json:"end_time"`
+	Attributes            []diskAttribute `json:"attributes,omitempty"`
+	Events                []diskEvent     `json:"events,omitempty"`
+	Links                 []diskLink      `json:"links,omitempty"`
+	StatusCode            codes.Code      `json:"status_code"`
+	StatusDescription     string          `json:"status_description,omitempty"`
+	ChildSpanCount        int             `json:"child_span_count,omitempty"`
+	DroppedAttributeCount int             `json:"dropped_attribute_count,omitempty"`
+	DroppedEventCount     int             `json:"dropped_event_count,omitempty"`
+	DroppedLinkCount      int             `json:"dropped_link_count,omitempty"`
+	Resource              *diskResource   `json:"resource,omitempty"`
+	Scope                 diskScope       `json:"scope"`
+}
+
+type diskSpanContext struct {
+	TraceID    string `json:"trace_id,omitempty"`
+	SpanID     string `json:"span_id,omitempty"`
+	TraceFlags byte   `json:"trace_flags,omitempty"`
+	TraceState string `json:"trace_state,omitempty"`
+	Remote     bool   `json:"remote,omitempty"`
+}
+
+type diskAttribute struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type diskEvent struct {
+	Name                  string          `json:"name"`
+	Attributes            []diskAttribute `json:"attributes,omitempty"`
+	DroppedAttributeCount int             `json:"dropped_attribute_count,omitempty"`
+	Time                  time.Time       `json:"time"`
+}
+
+type diskLink struct {
+	SpanContext           diskSpanContext `json:"span_context"`
+	Attributes            []diskAttribute `json:"attributes,omitempty"`
+	DroppedAttributeCount int             `json:"dropped_attribute_count,omitempty"`
+}
+
+type diskResource struct {
+	SchemaURL  string          `json:"schema_url,omitempty"`
+	Attributes []diskAttribute `json:"attributes,omitempty"`
+}
+
+type diskScope struct {
+	Name      string `json:"name,omitempty"`
+	Version   string `json:"version,omitempty"`
+	SchemaURL string `json:"schema_url,omitempty"`
+}
+
+func newDiskSpan(s ReadOnlySpan) *diskSpan {
+	ds := &diskSpan{
+		Name:                  s.Name(),
+		SpanContext:           newDiskSpanContext(s.SpanContext()),
+		Parent:                newDiskSpanContext(s.Parent()),
+		SpanKind:              s.SpanKind(),
+		StartTime:             s.StartTime(),
+		EndTime:               s.EndTime(),
+		Attributes:            newDiskAttributes(s.Attributes()),
+		StatusCode:            s.Status().Code,
+		StatusDescription:     s.Status().Description,
+		ChildSpanCount:        s.ChildSpanCount(),
+		DroppedAttributeCount: s.DroppedAttributes(),
+		DroppedEventCount:     s.DroppedEvents(),
+		DroppedLinkCount:      s.DroppedLinks(),
+		Scope: diskScope{
+			Name:      s.InstrumentationScope().Name,
+			Version:   s.InstrumentationScope().Version,
+			SchemaURL: s.InstrumentationScope().SchemaURL,
+		},
+	}
+	for _, e := range s.Events() {
+		ds.Events = append(ds.Events, diskEvent{
+			Name:                  e.Name,
+			Attributes:            newDiskAttributes(e.Attributes),
+			DroppedAttributeCount: e.DroppedAttributeCount,
+			Time:                  e.Time,
+		})
+	}
+	for _, l := range s.Links() {
+		ds.Links = append(ds.Links, diskLink{
+			SpanContext:           newDiskSpanContext(l.SpanContext),
+			Attributes:            newDiskAttributes(l.Attributes),
+			DroppedAttributeCount: l.DroppedAttributeCount,
+		})
+	}
+	if r := s.Resource(); r != nil {
+		ds.Resource = &diskResource{
+			SchemaURL:  r.SchemaURL(),
+			Attributes: newDiskAttributes(r.Attributes()),
+		}
+	}
+	return ds
+}
+
+// snapshot returns the ReadOnlySpan ds represents.
+func (ds *diskSpan) snapshot() (snapshot, error) {
+	sc, err := ds.SpanContext.spanContext()
+	if err != nil {
+		return snapshot{}, err
+	}
+	parent, err := ds.Parent.spanContext()
+	if err != nil {
+		return snapshot{}, err
+	}
+	attrs, err := diskAttributes(ds.Attributes)
+	if err != nil {
+		return snapshot{}, err
+	}
+
+	s := snapshot{
+		name:                  ds.Name,
+		spanContext:           sc,
+		parent:                parent,
+		spanKind:              ds.SpanKind,
+		startTime:             ds.StartTime,
+		endTime:               ds.EndTime,
+		attributes:            attrs,
+		status:                Status{Code: ds.StatusCode, Description: ds.StatusDescription},
+		childSpanCount:        ds.ChildSpanCount,
+		droppedAttributeCount: ds.DroppedAttributeCount,
+		droppedEventCount:     ds.DroppedEventCount,
+		droppedLinkCount:      ds.DroppedLinkCount,
+		instrumentationScope: instrumentation.Scope{
+			Name:      ds.Scope.Name,
+			Version:   ds.Scope.Version,
+			SchemaURL: ds.Scope.SchemaURL,
+		},
+	}
+	for _, de := range ds.Events {
+		attrs, err := diskAttributes(de.Attributes)
+		if err != nil {
+			return snapshot{}, err
+		}
+		s.events = append(s.events, Event{
+			Name:                  de.Name,
+			Attributes:            attrs,
+			DroppedAttributeCount: de.DroppedAttributeCount,
+			Time:                  de.Time,
+		})
+	}
+	for _, dl := range ds.Links {
+		sc, err := dl.SpanContext.spanContext()
+		if err != nil {
+			return snapshot{}, err
+		}
+		attrs, err := diskAttributes(dl.Attributes)
+		if err != nil {
+			return snapshot{}, err
+		}
+		s.links = append(s.links, Link{
+			SpanContext:           sc,
+			Attributes:            attrs,
+			DroppedAttributeCount: dl.DroppedAttributeCount,
+		})
+	}
+	if ds.Resource != nil {
+		attrs, err := diskAttributes(ds.Resource.Attributes)
+		if err != nil {
+			return snapshot{}, err
+		}
+		s.resource = resource.NewWithAttributes(ds.Resource.SchemaURL, attrs...)
+	}
+	return s, nil
+}
+
+func newDiskSpanContext(sc trace.SpanContext) diskSpanContext {
+	dsc := diskSpanContext{
+		TraceFlags: byte(sc.TraceFlags()),
+		TraceState: sc.TraceState().String(),
+		Remote:     sc.IsRemote(),
+	}
+	if sc.HasTraceID() {
+		dsc.TraceID = sc.TraceID().String()
+	}
+	if sc.HasSpanID() {
+		dsc.SpanID = sc.SpanID().String()
+	}
+	return dsc
+}
+
+func (dsc diskSpanContext) spanContext() (trace.SpanContext, error) {
+	var scc trace.SpanContextConfig
+	var err error
+	if dsc.TraceID != "" {
+		if scc.TraceID, err = trace.TraceIDFromHex(dsc.TraceID); err != nil {
+			return trace.SpanContext{}, err
+		}
+	}
+	if dsc.SpanID != "" {
+		if scc.SpanID, err = trace.SpanIDFromHex(dsc.SpanID); err != nil {
+			return trace.SpanContext{}, err
+		}
+	}
+	if scc.TraceState, err = trace.ParseTraceState(dsc.TraceState); err != nil {
+		return trace.SpanContext{}, err
+	}
+	scc.TraceFlags = trace.TraceFlags(dsc.TraceFlags)
+	scc.Remote = dsc.Remote
+	return trace.NewSpanContext(scc), nil
+}
+
+func newDiskAttributes(attrs []attribute.KeyValue) []diskAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]diskAttribute, 0, len(attrs))
+	for _, kv := range attrs {
+		raw, err := json.Marshal(kv.Value.AsInterface())
+		if err != nil {
+			// Non-finite floats cannot be encoded, keep the rest.
+			continue
+		}
+		out = append(out, diskAttribute{
+			Key:   string(kv.Key),
+			Type:  kv.Value.Type().String(),
+			Value: raw,
+		})
+	}
+	return out
+}
+
+func diskAttributes(das []diskAttribute) ([]attribute.KeyValue, error) {
+	if len(das) == 0 {
+		return nil, nil
+	}
+	attrs := make([]attribute.KeyValue, 0, len(das))
+	for _, da := range das {
+		v, err := da.value()
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: attribute.Key(da.Key), Value: v})
+	}
+	return attrs, nil
+}
+
+func (da diskAttribute) value() (attribute.Value, error) {
+	var err error
+	switch da.Type {
+	case attribute.BOOL.String():
+		var v bool
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.BoolValue(v), err
+	case attribute.INT64.String():
+		var v int64
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.Int64Value(v), err
+	case attribute.FLOAT64.String():
+		var v float64
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.Float64Value(v), err
+	case attribute.STRING.String():
+		var v string
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.StringValue(v), err
+	case attribute.BOOLSLICE.String():
+		var v []bool
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.BoolSliceValue(v), err
+	case attribute.INT64SLICE.String():
+		var v []int64
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.Int64SliceValue(v), err
+	case attribute.FLOAT64SLICE.String():
+		var v []float64
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.Float64SliceValue(v), err
+	case attribute.STRINGSLICE.String():
+		var v []string
+		err = json.Unmarshal(da.Value, &v)
+		return attribute.StringSliceValue(v), err
+	default:
+		return attribute.Value{}, fmt.Errorf("unknown attribute type %q", da.Type)
+	}
+}
@@ -15,6 +15,7 @@
 package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
+	"encoding/json"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -35,3 +36,24 @@ type Event struct {
 	// Time at which this event was recorded.
 	Time time.Time
 }
+
+// MarshalJSON returns the JSON encoding of the Event.
+//
+// The encoding is a JSON object with the members "Name" (string),
+// "Attributes" (array of {"Key": string, "Value": object}),
+// "DroppedAttributeCount" (number), and "Time" (RFC 3339 string with
+// nanoseconds), in this order. This shape is stable, new members will only
+// ever be appended.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name                  string
+		Attributes            []attribute.KeyValue
+		DroppedAttributeCount int
+		Time                  time.Time
+	}{
+		Name:                  e.Name,
+		Attributes:            e.Attributes,
+		DroppedAttributeCount: e.DroppedAttributeCount,
+		Time:                  e.Time,
+	})
+}
@@ -68,6 +68,18 @@ func (gen *randomIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.
 	return tid, sid
 }
 
+// NewSeededIDGenerator returns an IDGenerator that generates a deterministic
+// sequence of trace and span IDs from seed. Generators created with the same
+// seed return the same IDs when called in the same order. It is safe for
+// concurrent use, but the order of concurrent calls, and therefore the IDs
+// each call returns, is not deterministic.
+//
+// This is intended for benchmarks and tests that need reproducible IDs, the
+// returned IDs are not suitable for production use.
+func NewSeededIDGenerator(seed int64) IDGenerator {
+	return &randomIDGenerator{randSource: rand.New(rand.NewSource(seed))}
+}
+
 func defaultIDGenerator() IDGenerator {
 	gen := &randomIDGenerator{}
 	var rngSeed int64
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSeededIDGenerator(t *testing.T) {
+	ctx := context.Background()
+	gen0, gen1 := NewSeededIDGenerator(42), NewSeededIDGenerator(42)
+	for i := 0; i < 10; i++ {
+		tid0, sid0 := gen0.NewIDs(ctx)
+		tid1, sid1 := gen1.NewIDs(ctx)
+		assert.True(t, tid0.IsValid())
+		assert.True(t, sid0.IsValid())
+		assert.Equal(t, tid0, tid1)
+		assert.Equal(t, sid0, sid1)
+		assert.Equal(t, gen0.NewSpanID(ctx, tid0), gen1.NewSpanID(ctx, tid1))
+	}
+
+	other, _ := NewSeededIDGenerator(43).NewIDs(ctx)
+	tid, _ := NewSeededIDGenerator(42).NewIDs(ctx)
+	assert.NotEqual(t, tid, other)
+}
+
+func TestNewSeededIDGeneratorConcurrentSafe(t *testing.T) {
+	ctx := context.Background()
+	gen := NewSeededIDGenerator(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				gen.NewSpanID(ctx, trace.TraceID{})
+				gen.NewIDs(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+}
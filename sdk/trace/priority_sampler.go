@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type priorityKeyType int
+
+const priorityKey priorityKeyType = 0
+
+// ContextWithPriority returns a copy of parent with priority as the sampling
+// priority of the request it belongs to. The priority is used by a
+// PrioritySampler to decide if root spans started with the returned context
+// are sampled.
+func ContextWithPriority(parent context.Context, priority int) context.Context {
+	return context.WithValue(parent, priorityKey, priority)
+}
+
+// PriorityFromContext returns the sampling priority of ctx set with
+// ContextWithPriority, and if one was set.
+func PriorityFromContext(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	p, ok := ctx.Value(priorityKey).(int)
+	return p, ok
+}
+
+type prioritySampler struct {
+	threshold int
+	delegate  Sampler
+}
+
+// PrioritySampler returns a Sampler that samples every root span started with
+// a context that has a sampling priority, set with ContextWithPriority, of at
+// least threshold. This can be used to always sample requests of a certain
+// kind, e.g. those of internal load tests, regardless of the sampling ratio
+// used for the rest of the requests.
+//
+// The sampling decision of all other spans is made by delegate. This
+// includes spans with a parent, even if they have a high enough priority, so
+// the decision of the root span is kept for the whole trace.
+func PrioritySampler(threshold int, delegate Sampler) Sampler {
+	return prioritySampler{
+		threshold: threshold,
+		delegate:  delegate,
+	}
+}
+
+func (ps prioritySampler) ShouldSample(p SamplingParameters) SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	if !psc.IsValid() {
+		if priority, ok := PriorityFromContext(p.ParentContext); ok && priority >= ps.threshold {
+			return SamplingResult{
+				Decision:   RecordAndSample,
+				Tracestate: psc.TraceState(),
+			}
+		}
+	}
+	return ps.delegate.ShouldSample(p)
+}
+
+func (ps prioritySampler) Description() string {
+	return fmt.Sprintf("PrioritySampler{%d,%s}", ps.threshold, ps.delegate.Description())
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPrioritySampler(t *testing.T) {
+	sampler := PrioritySampler(5, NeverSample())
+	parent := trace.ContextWithSpanContext(
+		context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x01},
+		}),
+	)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want SamplingDecision
+	}{
+		{
+			name: "no priority",
+			ctx:  context.Background(),
+			want: Drop,
+		},
+		{
+			name: "below threshold",
+			ctx:  ContextWithPriority(context.Background(), 4),
+			want: Drop,
+		},
+		{
+			name: "at threshold",
+			ctx:  ContextWithPriority(context.Background(), 5),
+			want: RecordAndSample,
+		},
+		{
+			name: "above threshold",
+			ctx:  ContextWithPriority(context.Background(), 10),
+			want: RecordAndSample,
+		},
+		{
+			name: "above threshold with parent",
+			ctx:  ContextWithPriority(parent, 10),
+			want: Drop,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sampler.ShouldSample(SamplingParameters{ParentContext: tc.ctx})
+			assert.Equal(t, tc.want, got.Decision)
+		})
+	}
+}
+
+func TestPrioritySamplerDelegates(t *testing.T) {
+	sampler := PrioritySampler(5, AlwaysSample())
+	got := sampler.ShouldSample(SamplingParameters{ParentContext: context.Background()})
+	assert.Equal(t, RecordAndSample, got.Decision)
+	assert.Equal(t, "PrioritySampler{5,AlwaysOnSampler}", sampler.Description())
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	_, ok := PriorityFromContext(context.Background())
+	assert.False(t, ok)
+
+	p, ok := PriorityFromContext(ContextWithPriority(context.Background(), 3))
+	assert.True(t, ok)
+	assert.Equal(t, 3, p)
+}
@@ -19,10 +19,13 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -51,6 +54,42 @@ type tracerProviderConfig struct {
 
 	// resource contains attributes representing an entity that produces telemetry.
 	resource *resource.Resource
+
+	// noDefaultResource determines if only the environment Resource is used
+	// instead of resource.Default() when no resource is configured.
+	noDefaultResource bool
+
+	// executionTracer determines if a "runtime/trace".Task is created for
+	// each recording span when the Go execution tracer is enabled.
+	executionTracer bool
+
+	// timeSource returns the current time. If nil, time.Now is used.
+	timeSource func() time.Time
+
+	// spanNameNormalizer rewrites the names of Spans. If nil, names are
+	// used as given.
+	spanNameNormalizer func(string) string
+
+	// defaultSpanAttributes are set on every recording Span when it is
+	// started.
+	defaultSpanAttributes []attribute.KeyValue
+
+	// sanitizeUTF8 determines if invalid UTF-8 in the string attribute
+	// values of ended Spans is replaced.
+	sanitizeUTF8 bool
+
+	// countAttributes determines if the link and event counts of ended
+	// Spans are added as attributes.
+	countAttributes bool
+
+	// emptySpanName replaces empty span names if rejectEmptySpanNames is
+	// true.
+	emptySpanName        string
+	rejectEmptySpanNames bool
+
+	// samplerAttributes determines if the sampler description and
+	// decision are added as attributes of recording Spans.
+	samplerAttributes bool
 }
 
 // MarshalLog is the marshaling function used by the logging system to represent this exporter.
@@ -61,12 +100,14 @@ func (cfg tracerProviderConfig) MarshalLog() interface{} {
 		IDGeneratorType string
 		SpanLimits      SpanLimits
 		Resource        *resource.Resource
+		ExecutionTracer bool
 	}{
 		SpanProcessors:  cfg.processors,
 		SamplerType:     fmt.Sprintf("%T", cfg.sampler),
 		IDGeneratorType: fmt.Sprintf("%T", cfg.idGenerator),
 		SpanLimits:      cfg.spanLimits,
 		Resource:        cfg.resource,
+		ExecutionTracer: cfg.executionTracer,
 	}
 }
 
@@ -76,13 +117,30 @@ type TracerProvider struct {
 	mu             sync.Mutex
 	namedTracer    map[instrumentation.Scope]*tracer
 	spanProcessors atomic.Value
+	// sampler holds the samplerHolder of the Sampler used for new Spans.
+	sampler atomic.Value
 
 	// These fields are not protected by the lock mu. They are assumed to be
 	// immutable after creation of the TracerProvider.
-	sampler     Sampler
 	idGenerator IDGenerator
 	spanLimits  SpanLimits
 	resource    *resource.Resource
+
+	executionTracer       bool
+	timeSource            func() time.Time
+	spanNameNormalizer    func(string) string
+	defaultSpanAttributes []attribute.KeyValue
+	sanitizeUTF8          bool
+	countAttributes       bool
+	emptySpanName         string
+	rejectEmptySpanNames  bool
+	samplerAttributes     bool
+}
+
+// timeSourceSetter is implemented by SpanProcessors that use the time source
+// of the TracerProvider they are registered with.
+type timeSourceSetter interface {
+	setTimeSource(now func() time.Time)
 }
 
 var _ trace.TracerProvider = &TracerProvider{}
@@ -94,12 +152,15 @@ var _ trace.TracerProvider = &TracerProvider{}
 //  - a random number IDGenerator
 //  - the resource.Default() Resource
 //  - the default SpanLimits.
+//  - the execution tracer integration enabled.
+//  - time.Now as the time source.
 //
 // The passed opts are used to override these default values and configure the
 // returned TracerProvider appropriately.
 func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 	o := tracerProviderConfig{
-		spanLimits: NewSpanLimits(),
+		spanLimits:      NewSpanLimits(),
+		executionTracer: true,
 	}
 	o = applyTracerProviderEnvConfigs(o)
 
@@ -111,12 +172,23 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 
 	tp := &TracerProvider{
 		namedTracer: make(map[instrumentation.Scope]*tracer),
-		sampler:     o.sampler,
 		idGenerator: o.idGenerator,
 		spanLimits:  o.spanLimits,
 		resource:    o.resource,
+
+		executionTracer:       o.executionTracer,
+		timeSource:            o.timeSource,
+		spanNameNormalizer:    o.spanNameNormalizer,
+		defaultSpanAttributes: o.defaultSpanAttributes,
+		sanitizeUTF8:          o.sanitizeUTF8,
+		countAttributes:       o.countAttributes,
+		emptySpanName:         o.emptySpanName,
+		rejectEmptySpanNames:  o.rejectEmptySpanNames,
+		samplerAttributes:     o.samplerAttributes,
 	}
 
+	tp.sampler.Store(samplerHolder{o.sampler})
+
 	global.Info("TracerProvider created", "config", o)
 
 	for _, sp := range o.processors {
@@ -160,6 +232,10 @@ func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.T
 
 // RegisterSpanProcessor adds the given SpanProcessor to the list of SpanProcessors.
 func (p *TracerProvider) RegisterSpanProcessor(s SpanProcessor) {
+	if ts, ok := s.(timeSourceSetter); ok && p.timeSource != nil {
+		ts.setTimeSource(p.timeSource)
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	new := spanProcessorStates{}
@@ -174,6 +250,30 @@ func (p *TracerProvider) RegisterSpanProcessor(s SpanProcessor) {
 	p.spanProcessors.Store(new)
 }
 
+// SetSampler replaces the Sampler used to make the sampling decisions of the
+// Spans created after it returns. Spans already started are not affected. If
+// s is nil, the Sampler is not replaced.
+//
+// SetSampler can be called concurrently with the creation of Spans, which
+// use either the previous or the new Sampler.
+func (p *TracerProvider) SetSampler(s Sampler) {
+	if s == nil {
+		return
+	}
+	p.sampler.Store(samplerHolder{s})
+}
+
+// samplerHolder wraps a Sampler so Samplers of different types can be stored
+// in an atomic.Value.
+type samplerHolder struct {
+	Sampler
+}
+
+// loadSampler returns the Sampler used for new Spans.
+func (p *TracerProvider) loadSampler() Sampler {
+	return p.sampler.Load().(samplerHolder).Sampler
+}
+
 // UnregisterSpanProcessor removes the given SpanProcessor from the list of SpanProcessors.
 func (p *TracerProvider) UnregisterSpanProcessor(s SpanProcessor) {
 	p.mu.Lock()
@@ -306,7 +406,8 @@ func WithSpanProcessor(sp SpanProcessor) TracerProviderOption {
 // entity producing telemetry.
 //
 // If this option is not used, the TracerProvider will use the
-// resource.Default() Resource by default.
+// resource.Default() Resource by default, unless WithoutDefaultResource is
+// used.
 func WithResource(r *resource.Resource) TracerProviderOption {
 	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
 		var err error
@@ -318,6 +419,24 @@ func WithResource(r *resource.Resource) TracerProviderOption {
 	})
 }
 
+// WithoutDefaultResource returns a TracerProviderOption that configures a
+// TracerProvider to only use the Resource described by the
+// OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment variables,
+// instead of resource.Default(), when no Resource is configured with
+// WithResource. The telemetry.sdk.* attributes and the default service.name
+// of resource.Default() are then not added to any span. The Resource is
+// empty if those environment variables are not set.
+//
+// This option does not change a Resource configured with WithResource,
+// regardless of the order the options are passed. Such a Resource, including
+// one created with detectors using resource.New, is still used as is.
+func WithoutDefaultResource() TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.noDefaultResource = true
+		return cfg
+	})
+}
+
 // WithIDGenerator returns a TracerProviderOption that will configure the
 // IDGenerator g as a TracerProvider's IDGenerator. The configured IDGenerator
 // is used by the Tracers the TracerProvider creates to generate new Span and
@@ -387,6 +506,9 @@ func WithSpanLimits(sl SpanLimits) TracerProviderOption {
 	if sl.AttributePerLinkCountLimit <= 0 {
 		sl.AttributePerLinkCountLimit = DefaultAttributePerLinkCountLimit
 	}
+	if sl.TotalAttributeBytesLimit <= 0 {
+		sl.TotalAttributeBytesLimit = DefaultTotalAttributeBytesLimit
+	}
 	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
 		cfg.spanLimits = sl
 		return cfg
@@ -415,6 +537,184 @@ func WithRawSpanLimits(limits SpanLimits) TracerProviderOption {
 	})
 }
 
+// WithExecutionTracer returns a TracerProviderOption that configures if a
+// TracerProvider integrates with the Go execution tracer.
+//
+// When enabled, a "runtime/trace".Task is created for every recording Span
+// while the execution tracer is running, and it is ended when the Span ends.
+// Disabling this removes that per-span overhead for applications that run
+// the execution tracer but do not need Spans to appear in it.
+//
+// If this option is not used, the TracerProvider will integrate with the
+// execution tracer by default.
+func WithExecutionTracer(enabled bool) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.executionTracer = enabled
+		return cfg
+	})
+}
+
+// WithTimeSource returns a TracerProviderOption that configures now as the
+// source of the current time of a TracerProvider. It is used for the start
+// and end times of Spans, and the times of their events, not given
+// explicitly with trace.WithTimestamp. The span processors of the
+// TracerProvider that measure time, e.g. the age of open spans in a
+// BatchSpanProcessor with a MaxSpanAge, use it too. This allows replaying
+// data with timestamps driven by a logical clock.
+//
+// The time source also drives the schedule of the BatchSpanProcessors of the
+// TracerProvider: their BatchTimeout, and how often they check the age of
+// open spans, elapse on it instead of the wall clock. They poll the time
+// source every 10 milliseconds to find out. A full batch is still exported
+// right away, and ForceFlush and Shutdown still export the queued spans.
+//
+// If this option is not used, or now is nil, the TracerProvider will use
+// time.Now by default.
+func WithTimeSource(now func() time.Time) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		if now != nil {
+			cfg.timeSource = now
+		}
+		return cfg
+	})
+}
+
+// WithSpanNameNormalizer returns a TracerProviderOption that configures fn
+// to rewrite the name of every Span a TracerProvider creates. The name passed
+// to Start, and any name later passed to SetName, is replaced with the result
+// of fn. That normalized name is the one samplers see, and the one stored and
+// exported. This can be used to keep the cardinality of span names low, e.g.
+// by collapsing "/users/123" to "/users/{id}".
+//
+// The fn is called synchronously for every Span started and renamed, it
+// needs to be fast and safe to call concurrently.
+//
+// If this option is not used, or fn is nil, span names are not changed.
+func WithSpanNameNormalizer(fn func(name string) string) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.spanNameNormalizer = fn
+		return cfg
+	})
+}
+
+// WithDefaultSpanAttributes returns a TracerProviderOption that configures
+// attrs to be set on every recording Span a TracerProvider starts. This can
+// be used for attributes every span needs to carry itself, e.g. for backends
+// that do not index the attributes of the Resource of spans.
+//
+// The attrs are set when a Span is started, before the attributes of the
+// sampling decision and those passed with trace.WithAttributes. These later
+// attributes, and attributes set after the Span is started, override attrs
+// with the same key. The attrs count against the AttributeCountLimit of the
+// Span like all other attributes.
+//
+// If this option is used multiple times, attrs of all uses are set.
+func WithDefaultSpanAttributes(attrs ...attribute.KeyValue) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.defaultSpanAttributes = append(cfg.defaultSpanAttributes, attrs...)
+		return cfg
+	})
+}
+
+// WithUTF8Sanitization returns a TracerProviderOption that configures a
+// TracerProvider to replace invalid UTF-8 in the string attribute values of
+// ended Spans, including the attributes of their events and links, with the
+// Unicode replacement character. Valid strings are left untouched. This
+// protects exporters that require valid UTF-8, e.g. for protobuf or JSON,
+// from instrumentation that records raw bytes as strings.
+//
+// The attributes are only sanitized in the ReadOnlySpan passed to the span
+// processors, the Span itself is not modified.
+//
+// If this option is not used, attribute values are not checked.
+func WithUTF8Sanitization() TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.sanitizeUTF8 = true
+		return cfg
+	})
+}
+
+// WithCountAttributes returns a TracerProviderOption that configures a
+// TracerProvider to add the LinkCountKey and EventCountKey attributes to
+// ended Spans. Their values are the number of links and events added to the
+// Span, including the ones dropped because of the SpanLimits. This is
+// intended for backends that can query attributes but not links or events.
+//
+// The attributes are only added to the ReadOnlySpan passed to the span
+// processors, the Span itself is not modified, and they are not subject to
+// the attribute count limit.
+//
+// If this option is not used, the counts are not added as attributes.
+func WithCountAttributes() TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.countAttributes = true
+		return cfg
+	})
+}
+
+// WithRejectEmptySpanNames returns a TracerProviderOption that configures a
+// TracerProvider to replace empty span names with replacement. An empty name
+// passed to Start, or later passed to SetName, is replaced and an error is
+// sent to the global error handler. Names are checked after they are
+// rewritten by the span name normalizer, if any.
+//
+// If this option is not used, empty span names are kept.
+func WithRejectEmptySpanNames(replacement string) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.emptySpanName = replacement
+		cfg.rejectEmptySpanNames = true
+		return cfg
+	})
+}
+
+// WithSamplerAttributes returns a TracerProviderOption that configures a
+// TracerProvider to add the SamplerKey and SamplerResultKey attributes to
+// recording Spans when they are started. They hold the description of the
+// Sampler that made the sampling decision, the delegate chosen by a
+// ParentBased Sampler rather than the ParentBased Sampler itself, and the
+// decision it made. This is intended to diagnose sampling configurations.
+//
+// The attributes are subject to the attribute count limit like the ones
+// returned by the Sampler.
+//
+// If this option is not used, the attributes are not added.
+func WithSamplerAttributes() TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.samplerAttributes = true
+		return cfg
+	})
+}
+
+// normalizeSpanName returns name rewritten by the span name normalizer of p,
+// and replaced if it is empty and p rejects empty names.
+func (p *TracerProvider) normalizeSpanName(name string) string {
+	if p.spanNameNormalizer != nil {
+		name = p.spanNameNormalizer(name)
+	}
+	if name == "" && p.rejectEmptySpanNames {
+		otel.Handle(fmt.Errorf("empty span name replaced with %q", p.emptySpanName))
+		return p.emptySpanName
+	}
+	return name
+}
+
+// now returns the current time of the time source of p.
+func (p *TracerProvider) now() time.Time {
+	if p.timeSource != nil {
+		return p.timeSource()
+	}
+	return time.Now()
+}
+
+// endTime returns the end time of a span started at start ending now.
+func (p *TracerProvider) endTime(start time.Time) time.Time {
+	if p.timeSource != nil {
+		return p.timeSource()
+	}
+	// Use the monotonic clock reading of start to compute the duration.
+	return internal.MonotonicEndTime(start)
+}
+
 func applyTracerProviderEnvConfigs(cfg tracerProviderConfig) tracerProviderConfig {
 	for _, opt := range tracerProviderOptionsFromEnv() {
 		cfg = opt.apply(cfg)
@@ -447,7 +747,11 @@ func ensureValidTracerProviderConfig(cfg tracerProviderConfig) tracerProviderCon
 		cfg.idGenerator = defaultIDGenerator()
 	}
 	if cfg.resource == nil {
-		cfg.resource = resource.Default()
+		if cfg.noDefaultResource {
+			cfg.resource = resource.Environment()
+		} else {
+			cfg.resource = resource.Default()
+		}
 	}
 	return cfg
 }
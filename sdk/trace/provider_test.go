@@ -19,12 +19,16 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -99,6 +103,49 @@ func TestSchemaURL(t *testing.T) {
 	assert.EqualValues(t, schemaURL, tracerStruct.instrumentationScope.SchemaURL)
 }
 
+func TestSetSampler(t *testing.T) {
+	tp := NewTracerProvider(WithSampler(NeverSample()))
+	tr := tp.Tracer("SetSampler")
+
+	_, before := tr.Start(context.Background(), "before")
+	assert.False(t, before.SpanContext().IsSampled())
+
+	tp.SetSampler(AlwaysSample())
+	_, after := tr.Start(context.Background(), "after")
+	assert.True(t, after.SpanContext().IsSampled())
+	assert.True(t, after.IsRecording())
+	// Spans already started are not affected.
+	assert.False(t, before.IsRecording())
+
+	tp.SetSampler(nil)
+	assert.Equal(t, AlwaysSample().Description(), tp.loadSampler().Description())
+}
+
+func TestSetSamplerConcurrentStart(t *testing.T) {
+	tp := NewTracerProvider(WithSampler(NeverSample()))
+	tr := tp.Tracer("SetSampler")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, span := tr.Start(context.Background(), "span")
+				span.End()
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			tp.SetSampler(AlwaysSample())
+		} else {
+			tp.SetSampler(TraceIDRatioBased(0.5))
+		}
+	}
+	wg.Wait()
+}
+
 func TestTracerProviderSamplerConfigFromEnv(t *testing.T) {
 	type testCase struct {
 		sampler             string
@@ -206,7 +253,7 @@ func TestTracerProviderSamplerConfigFromEnv(t *testing.T) {
 			})
 
 			stp := NewTracerProvider(WithSyncer(NewTestExporter()))
-			assert.Equal(t, test.description, stp.sampler.Description())
+			assert.Equal(t, test.description, stp.loadSampler().Description())
 			if test.errorType != nil {
 				testStoredError(t, test.errorType)
 			} else {
@@ -229,7 +276,7 @@ func TestTracerProviderSamplerConfigFromEnv(t *testing.T) {
 					t.Cleanup(func() {
 						require.NoError(t, stp.Shutdown(context.Background()))
 					})
-					assert.Equal(t, test.description, stp.sampler.Description())
+					assert.Equal(t, test.description, stp.loadSampler().Description())
 
 					if test.invalidArgErrorType != nil {
 						testStoredError(t, test.invalidArgErrorType)
@@ -259,3 +306,19 @@ func testStoredError(t *testing.T, target interface{}) {
 		assert.ErrorAs(t, err, target)
 	}
 }
+
+func TestWithoutDefaultResourceEnvironment(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		"OTEL_RESOURCE_ATTRIBUTES": "key=value",
+		"OTEL_SERVICE_NAME":        "env-service",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, envStore.Restore()) })
+
+	tp := NewTracerProvider(WithoutDefaultResource())
+	want := resource.NewSchemaless(
+		attribute.String("key", "value"),
+		semconv.ServiceNameKey.String("env-service"),
+	)
+	assert.Equal(t, want.Equivalent(), tp.resource.Equivalent())
+}
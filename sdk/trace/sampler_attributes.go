@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// SamplerKey is the attribute key of the description of the Sampler
+	// that made the sampling decision for a Span. It is set when the
+	// WithSamplerAttributes option is used.
+	SamplerKey = attribute.Key("otel.sampler")
+
+	// SamplerResultKey is the attribute key of the sampling decision made
+	// for a Span, either "RECORD_ONLY" or "RECORD_AND_SAMPLE". It is set
+	// when the WithSamplerAttributes option is used.
+	SamplerResultKey = attribute.Key("otel.sampler.result")
+)
+
+// effectiveSampler returns the Sampler making the sampling decision for p
+// when s is asked to. This is the delegate chosen by a ParentBased Sampler,
+// or s itself otherwise.
+func effectiveSampler(s Sampler, p SamplingParameters) Sampler {
+	for {
+		pb, ok := s.(parentBased)
+		if !ok {
+			return s
+		}
+		s = pb.delegate(trace.SpanContextFromContext(p.ParentContext))
+	}
+}
+
+// decisionString returns the name of d used as SamplerResultKey value.
+func decisionString(d SamplingDecision) string {
+	switch d {
+	case RecordOnly:
+		return "RECORD_ONLY"
+	case RecordAndSample:
+		return "RECORD_AND_SAMPLE"
+	default:
+		return "DROP"
+	}
+}
+
+// withSamplerAttributes returns the attributes of sr with the SamplerKey and
+// SamplerResultKey attributes for the decision made by s added.
+func withSamplerAttributes(sr SamplingResult, s Sampler, p SamplingParameters) []attribute.KeyValue {
+	// The attributes of the result may be shared by the Sampler, copy them
+	// instead of appending to them.
+	attrs := make([]attribute.KeyValue, 0, len(sr.Attributes)+2)
+	attrs = append(attrs, sr.Attributes...)
+	return append(attrs,
+		SamplerKey.String(effectiveSampler(s, p).Description()),
+		SamplerResultKey.String(decisionString(sr.Decision)),
+	)
+}
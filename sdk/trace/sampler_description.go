@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	descAlwaysOn               = "AlwaysOnSampler"
+	descAlwaysOff              = "AlwaysOffSampler"
+	descTraceIDRatio           = "TraceIDRatioBased"
+	descTraceIDRatioRecordOnly = "TraceIDRatioBasedRecordOnly"
+	descHash                   = "HashSampler"
+	descParentBased            = "ParentBased"
+
+	descRoot                   = "root"
+	descRemoteParentSampled    = "remoteParentSampled"
+	descRemoteParentNotSampled = "remoteParentNotSampled"
+	descLocalParentSampled     = "localParentSampled"
+	descLocalParentNotSampled  = "localParentNotSampled"
+)
+
+type errInvalidSamplerDescription string
+
+func (e errInvalidSamplerDescription) Error() string {
+	return fmt.Sprintf("invalid sampler description: %s", string(e))
+}
+
+// ParseSamplerDescription returns the built-in Sampler described by desc.
+// It reconstructs any Sampler returned by AlwaysSample, NeverSample,
+// TraceIDRatioBased, TraceIDRatioBasedRecordOnly, HashSampler, and
+// ParentBased, when composed only of these samplers, from its Description.
+// These descriptions have the following formats:
+//
+//	AlwaysOnSampler
+//	AlwaysOffSampler
+//	TraceIDRatioBased{<fraction>}
+//	TraceIDRatioBasedRecordOnly{<fraction>}
+//	HashSampler{<fraction>}
+//	ParentBased{root:<sampler>,remoteParentSampled:<sampler>,remoteParentNotSampled:<sampler>,localParentSampled:<sampler>,localParentNotSampled:<sampler>}
+//
+// The root of a ParentBased description is required, all other delegate
+// samplers default to the same samplers ParentBased uses if they are
+// omitted.
+//
+// An error is returned if desc does not describe a built-in Sampler.
+func ParseSamplerDescription(desc string) (Sampler, error) {
+	desc = strings.TrimSpace(desc)
+	switch {
+	case desc == descAlwaysOn:
+		return AlwaysSample(), nil
+	case desc == descAlwaysOff:
+		return NeverSample(), nil
+	}
+
+	name, args, ok := splitSamplerDescription(desc)
+	if !ok {
+		return nil, errInvalidSamplerDescription(desc)
+	}
+	switch name {
+	case descTraceIDRatio:
+		s, err := parseTraceIDRatio(args)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidSamplerDescription(desc), err)
+		}
+		return s, nil
+	case descTraceIDRatioRecordOnly:
+		v, err := strconv.ParseFloat(args, 64)
+		if err != nil || v < 0 || v > 1 {
+			return nil, errInvalidSamplerDescription(desc)
+		}
+		return TraceIDRatioBasedRecordOnly(v), nil
+	case descHash:
+		v, err := strconv.ParseFloat(args, 64)
+		if err != nil || v < 0 || v > 1 {
+			return nil, errInvalidSamplerDescription(desc)
+		}
+		return HashSampler(v), nil
+	case descParentBased:
+		return parseParentBasedDescription(desc, args)
+	}
+	return nil, errInvalidSamplerDescription(desc)
+}
+
+// splitSamplerDescription splits desc of the form "name{args}" into its name
+// and args.
+func splitSamplerDescription(desc string) (name, args string, ok bool) {
+	i := strings.IndexByte(desc, '{')
+	if i < 0 || !strings.HasSuffix(desc, "}") {
+		return "", "", false
+	}
+	return desc[:i], desc[i+1 : len(desc)-1], true
+}
+
+// parseParentBasedDescription returns the ParentBased Sampler described by
+// args, the comma separated delegate samplers of the ParentBased description
+// desc.
+func parseParentBasedDescription(desc, args string) (Sampler, error) {
+	var (
+		root Sampler
+		opts []ParentBasedSamplerOption
+		seen = make(map[string]bool)
+	)
+	for _, field := range splitTopLevel(args) {
+		i := strings.IndexByte(field, ':')
+		if i < 0 || seen[field[:i]] {
+			return nil, errInvalidSamplerDescription(desc)
+		}
+		key, value := field[:i], field[i+1:]
+		seen[key] = true
+
+		s, err := ParseSamplerDescription(value)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case descRoot:
+			root = s
+		case descRemoteParentSampled:
+			opts = append(opts, WithRemoteParentSampled(s))
+		case descRemoteParentNotSampled:
+			opts = append(opts, WithRemoteParentNotSampled(s))
+		case descLocalParentSampled:
+			opts = append(opts, WithLocalParentSampled(s))
+		case descLocalParentNotSampled:
+			opts = append(opts, WithLocalParentNotSampled(s))
+		default:
+			return nil, errInvalidSamplerDescription(desc)
+		}
+	}
+	if root == nil {
+		return nil, errInvalidSamplerDescription(desc)
+	}
+	return ParentBased(root, opts...), nil
+}
+
+// splitTopLevel splits s at every comma not enclosed in braces.
+func splitTopLevel(s string) []string {
+	var (
+		fields []string
+		depth  int
+		start  int
+	)
+	for i, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				fields = append(fields, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(fields, s[start:])
+}
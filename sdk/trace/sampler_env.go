@@ -57,6 +57,28 @@ func (e samplerArgParseError) Unwrap() error {
 	return e.parseErr
 }
 
+// SamplerFromEnv returns the Sampler configured with the OTEL_TRACES_SAMPLER
+// and OTEL_TRACES_SAMPLER_ARG environment variables. The supported
+// OTEL_TRACES_SAMPLER values are "always_on", "always_off", "traceidratio",
+// "parentbased_always_on", "parentbased_always_off", and
+// "parentbased_traceidratio". The ratio of the trace ID ratio based samplers
+// is read from OTEL_TRACES_SAMPLER_ARG and defaults to 1.0.
+//
+// If OTEL_TRACES_SAMPLER is not set, ParentBased(AlwaysSample()) is
+// returned. It is the same Sampler a TracerProvider uses by default.
+//
+// If either environment variable holds an invalid value, an error describing
+// it is returned along with the Sampler to fall back to: the default Sampler
+// for an unsupported sampler name, or the configured sampler with a ratio of
+// 1.0 for an invalid ratio.
+func SamplerFromEnv() (Sampler, error) {
+	sampler, err := samplerFromEnv()
+	if sampler == nil {
+		sampler = ParentBased(AlwaysSample())
+	}
+	return sampler, err
+}
+
 func samplerFromEnv() (Sampler, error) {
 	sampler, ok := os.LookupEnv(tracesSamplerKey)
 	if !ok {
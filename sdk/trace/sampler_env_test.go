@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+)
+
+func TestSamplerFromEnvDefault(t *testing.T) {
+	envStore := ottest.NewEnvStore()
+	envStore.Record(tracesSamplerKey)
+	envStore.Record(tracesSamplerArgKey)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+	require.NoError(t, os.Unsetenv(tracesSamplerKey))
+	require.NoError(t, os.Unsetenv(tracesSamplerArgKey))
+
+	sampler, err := SamplerFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, ParentBased(AlwaysSample()), sampler)
+}
+
+func TestSamplerFromEnv(t *testing.T) {
+	testCases := []struct {
+		name    string
+		env     map[string]string
+		want    Sampler
+		wantErr error
+	}{
+		{
+			name: "always_on",
+			env:  map[string]string{tracesSamplerKey: "always_on"},
+			want: AlwaysSample(),
+		},
+		{
+			name: "always_off",
+			env:  map[string]string{tracesSamplerKey: "always_off"},
+			want: NeverSample(),
+		},
+		{
+			name: "traceidratio",
+			env: map[string]string{
+				tracesSamplerKey:    "traceidratio",
+				tracesSamplerArgKey: "0.25",
+			},
+			want: TraceIDRatioBased(0.25),
+		},
+		{
+			name: "traceidratio without arg",
+			env:  map[string]string{tracesSamplerKey: "traceidratio"},
+			want: TraceIDRatioBased(1.0),
+		},
+		{
+			name: "parentbased_always_on",
+			env:  map[string]string{tracesSamplerKey: "parentbased_always_on"},
+			want: ParentBased(AlwaysSample()),
+		},
+		{
+			name: "parentbased_always_off",
+			env:  map[string]string{tracesSamplerKey: "parentbased_always_off"},
+			want: ParentBased(NeverSample()),
+		},
+		{
+			name: "parentbased_traceidratio",
+			env: map[string]string{
+				tracesSamplerKey:    "parentbased_traceidratio",
+				tracesSamplerArgKey: "0.5",
+			},
+			want: ParentBased(TraceIDRatioBased(0.5)),
+		},
+		{
+			name: "case and space insensitive",
+			env: map[string]string{
+				tracesSamplerKey:    " TraceIDRatio ",
+				tracesSamplerArgKey: " 0.5 ",
+			},
+			want: TraceIDRatioBased(0.5),
+		},
+		{
+			name:    "unsupported sampler",
+			env:     map[string]string{tracesSamplerKey: "invalid-sampler"},
+			want:    ParentBased(AlwaysSample()),
+			wantErr: errUnsupportedSampler("invalid-sampler"),
+		},
+		{
+			name: "negative ratio",
+			env: map[string]string{
+				tracesSamplerKey:    "traceidratio",
+				tracesSamplerArgKey: "-0.5",
+			},
+			want:    TraceIDRatioBased(1.0),
+			wantErr: errNegativeTraceIDRatio,
+		},
+		{
+			name: "ratio greater than one",
+			env: map[string]string{
+				tracesSamplerKey:    "parentbased_traceidratio",
+				tracesSamplerArgKey: "1.5",
+			},
+			want:    ParentBased(TraceIDRatioBased(1.0)),
+			wantErr: errGreaterThanOneTraceIDRatio,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			envStore := ottest.NewEnvStore()
+			envStore.Record(tracesSamplerArgKey)
+			require.NoError(t, os.Unsetenv(tracesSamplerArgKey))
+			setStore, err := ottest.SetEnvVariables(tc.env)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, setStore.Restore())
+				require.NoError(t, envStore.Restore())
+			}()
+
+			sampler, err := SamplerFromEnv()
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, sampler)
+		})
+	}
+}
+
+func TestSamplerFromEnvInvalidArg(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		tracesSamplerKey:    "traceidratio",
+		tracesSamplerArgKey: "half",
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+
+	sampler, err := SamplerFromEnv()
+	assert.ErrorAs(t, err, new(samplerArgParseError))
+	assert.Equal(t, TraceIDRatioBased(1.0), sampler)
+}
@@ -41,6 +41,10 @@ type Sampler interface {
 }
 
 // SamplingParameters contains the values passed to a Sampler.
+//
+// Attributes are the attributes passed with trace.WithAttributes when the
+// span is started. They are available to the Sampler before the span is
+// created, so they can be used to make the sampling decision.
 type SamplingParameters struct {
 	ParentContext context.Context
 	TraceID       trace.TraceID
@@ -77,6 +81,8 @@ type SamplingResult struct {
 type traceIDRatioSampler struct {
 	traceIDUpperBound uint64
 	description       string
+	// unsampled is the decision for the traces not sampled.
+	unsampled SamplingDecision
 }
 
 func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult {
@@ -89,7 +95,7 @@ func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult
 		}
 	}
 	return SamplingResult{
-		Decision:   Drop,
+		Decision:   ts.unsampled,
 		Tracestate: psc.TraceState(),
 	}
 }
@@ -114,7 +120,121 @@ func TraceIDRatioBased(fraction float64) Sampler {
 
 	return &traceIDRatioSampler{
 		traceIDUpperBound: uint64(fraction * (1 << 63)),
-		description:       fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
+		description:       fmt.Sprintf("%s{%g}", descTraceIDRatio, fraction),
+	}
+}
+
+// TraceIDRatioBasedRecordOnly is like TraceIDRatioBased, but records the
+// traces it does not sample instead of dropping them. This makes a three-way
+// decision across the three SamplingDecisions:
+//
+//   - the fraction of traces TraceIDRatioBased samples are RecordAndSample,
+//     they are recorded and exported,
+//   - the rest are RecordOnly, they are recorded and passed to the span
+//     processors, e.g. for tail sampling, but not marked as sampled and not
+//     exported by the exporting span processors,
+//   - no trace is Drop.
+//
+// The same traces are sampled as by TraceIDRatioBased with the same
+// fraction. Fractions >= 1 will always sample. Fractions < 0 are treated as
+// zero, all traces are then RecordOnly. To respect the parent trace's
+// `SampledFlag`, the `TraceIDRatioBasedRecordOnly` sampler should be used as
+// a delegate of a `Parent` sampler.
+//
+// The children of a RecordOnly span have a local parent that is not sampled,
+// which a `Parent` sampler drops by default. For them to be recorded as
+// well, the sampler for local parents that are not sampled must be set to
+// one that records them:
+//
+//	ParentBased(
+//		TraceIDRatioBasedRecordOnly(fraction),
+//		WithLocalParentNotSampled(TraceIDRatioBasedRecordOnly(0)),
+//	)
+func TraceIDRatioBasedRecordOnly(fraction float64) Sampler {
+	if fraction >= 1 {
+		return AlwaysSample()
+	}
+
+	if fraction <= 0 {
+		fraction = 0
+	}
+
+	return &traceIDRatioSampler{
+		traceIDUpperBound: uint64(fraction * (1 << 63)),
+		description:       fmt.Sprintf("%s{%g}", descTraceIDRatioRecordOnly, fraction),
+		unsampled:         RecordOnly,
+	}
+}
+
+type hashSampler struct {
+	hashUpperBound uint64
+	description    string
+}
+
+func (hs hashSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	if hashTraceID(p.TraceID)>>1 < hs.hashUpperBound {
+		return SamplingResult{
+			Decision:   RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return SamplingResult{
+		Decision:   Drop,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (hs hashSampler) Description() string {
+	return hs.description
+}
+
+// hashTraceID returns the hash of tid used by HashSampler.
+func hashTraceID(tid trace.TraceID) uint64 {
+	h := fmix64(binary.BigEndian.Uint64(tid[0:8]))
+	return fmix64(h ^ binary.BigEndian.Uint64(tid[8:16]))
+}
+
+// fmix64 is the 64-bit finalizer of MurmurHash3.
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+// HashSampler samples a given fraction of traces based on a hash of their
+// trace ID. Fractions >= 1 will always sample. Fractions < 0 are treated as
+// zero. To respect the parent trace's `SampledFlag`, the `HashSampler`
+// sampler should be used as a delegate of a `Parent` sampler.
+//
+// Unlike TraceIDRatioBased, which compares the first 8 bytes of the trace ID
+// to a threshold and so relies on the IDGenerator producing uniformly
+// random bytes there, HashSampler compares a hash of all 16 bytes of the
+// trace ID. Trace IDs that are not random, like sequential IDs used in
+// tests, are still sampled at the given fraction.
+//
+// The decision only depends on the trace ID and the fraction. With hi and lo
+// the first and last 8 bytes of the trace ID read as big-endian integers,
+// and fmix64 the 64-bit finalizer of MurmurHash3, the hash is
+// fmix64(fmix64(hi) XOR lo). A trace is sampled if the hash, shifted right
+// by one bit, is less than fraction * 2^63. Every service using this
+// algorithm with the same fraction makes the same decision for a trace, and
+// a trace sampled at a fraction is sampled at every greater fraction.
+func HashSampler(fraction float64) Sampler {
+	if fraction >= 1 {
+		return AlwaysSample()
+	}
+
+	if fraction <= 0 {
+		fraction = 0
+	}
+
+	return &hashSampler{
+		hashUpperBound: uint64(fraction * (1 << 63)),
+		description:    fmt.Sprintf("%s{%g}", descHash, fraction),
 	}
 }
 
@@ -128,7 +248,7 @@ func (as alwaysOnSampler) ShouldSample(p SamplingParameters) SamplingResult {
 }
 
 func (as alwaysOnSampler) Description() string {
-	return "AlwaysOnSampler"
+	return descAlwaysOn
 }
 
 // AlwaysSample returns a Sampler that samples every trace.
@@ -149,7 +269,7 @@ func (as alwaysOffSampler) ShouldSample(p SamplingParameters) SamplingResult {
 }
 
 func (as alwaysOffSampler) Description() string {
-	return "AlwaysOffSampler"
+	return descAlwaysOff
 }
 
 // NeverSample returns a Sampler that samples no traces.
@@ -233,6 +353,24 @@ func (o remoteParentNotSampledOption) apply(config samplerConfig) samplerConfig
 	return config
 }
 
+// WithRemoteParentIgnored sets the sampler for the case of remote parent
+// regardless of whether it is sampled or not. This is useful when the sampled
+// flag of remote parents cannot be trusted. It overrides, and is overridden
+// by, WithRemoteParentSampled and WithRemoteParentNotSampled.
+func WithRemoteParentIgnored(s Sampler) ParentBasedSamplerOption {
+	return remoteParentIgnoredOption{s}
+}
+
+type remoteParentIgnoredOption struct {
+	s Sampler
+}
+
+func (o remoteParentIgnoredOption) apply(config samplerConfig) samplerConfig {
+	config.remoteParentSampled = o.s
+	config.remoteParentNotSampled = o.s
+	return config
+}
+
 // WithLocalParentSampled sets the sampler for the case of sampled local parent.
 func WithLocalParentSampled(s Sampler) ParentBasedSamplerOption {
 	return localParentSampledOption{s}
@@ -263,30 +401,34 @@ func (o localParentNotSampledOption) apply(config samplerConfig) samplerConfig {
 }
 
 func (pb parentBased) ShouldSample(p SamplingParameters) SamplingResult {
-	psc := trace.SpanContextFromContext(p.ParentContext)
+	return pb.delegate(trace.SpanContextFromContext(p.ParentContext)).ShouldSample(p)
+}
+
+// delegate returns the Sampler pb delegates to for a Span with parent psc.
+func (pb parentBased) delegate(psc trace.SpanContext) Sampler {
 	if psc.IsValid() {
 		if psc.IsRemote() {
 			if psc.IsSampled() {
-				return pb.config.remoteParentSampled.ShouldSample(p)
+				return pb.config.remoteParentSampled
 			}
-			return pb.config.remoteParentNotSampled.ShouldSample(p)
+			return pb.config.remoteParentNotSampled
 		}
 
 		if psc.IsSampled() {
-			return pb.config.localParentSampled.ShouldSample(p)
+			return pb.config.localParentSampled
 		}
-		return pb.config.localParentNotSampled.ShouldSample(p)
+		return pb.config.localParentNotSampled
 	}
-	return pb.root.ShouldSample(p)
+	return pb.root
 }
 
 func (pb parentBased) Description() string {
-	return fmt.Sprintf("ParentBased{root:%s,remoteParentSampled:%s,"+
-		"remoteParentNotSampled:%s,localParentSampled:%s,localParentNotSampled:%s}",
-		pb.root.Description(),
-		pb.config.remoteParentSampled.Description(),
-		pb.config.remoteParentNotSampled.Description(),
-		pb.config.localParentSampled.Description(),
-		pb.config.localParentNotSampled.Description(),
+	return fmt.Sprintf("%s{%s:%s,%s:%s,%s:%s,%s:%s,%s:%s}",
+		descParentBased,
+		descRoot, pb.root.Description(),
+		descRemoteParentSampled, pb.config.remoteParentSampled.Description(),
+		descRemoteParentNotSampled, pb.config.remoteParentNotSampled.Description(),
+		descLocalParentSampled, pb.config.localParentSampled.Description(),
+		descLocalParentNotSampled, pb.config.localParentNotSampled.Description(),
 	)
 }
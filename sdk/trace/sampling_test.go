@@ -16,6 +16,7 @@ package trace
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -108,6 +109,27 @@ func TestParentBasedWithSamplerOptions(t *testing.T) {
 			false,
 			RecordAndSample,
 		},
+		{
+			"remoteParentIgnoredSampled",
+			WithRemoteParentIgnored(NeverSample()),
+			true,
+			true,
+			Drop,
+		},
+		{
+			"remoteParentIgnoredNotSampled",
+			WithRemoteParentIgnored(AlwaysSample()),
+			true,
+			false,
+			RecordAndSample,
+		},
+		{
+			"remoteParentIgnoredLocalParent",
+			WithRemoteParentIgnored(NeverSample()),
+			false,
+			true,
+			RecordAndSample,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -209,6 +231,117 @@ func TestTraceIdRatioSamplesInclusively(t *testing.T) {
 	}
 }
 
+func TestTraceIDRatioBasedRecordOnly(t *testing.T) {
+	const (
+		numTraces = 10000
+		fraction  = 0.25
+	)
+	idg := defaultIDGenerator()
+	sampler := TraceIDRatioBasedRecordOnly(fraction)
+	ratio := TraceIDRatioBased(fraction)
+
+	counts := map[SamplingDecision]int{}
+	for i := 0; i < numTraces; i++ {
+		traceID, _ := idg.NewIDs(context.Background())
+		params := SamplingParameters{TraceID: traceID}
+		decision := sampler.ShouldSample(params).Decision
+		counts[decision]++
+
+		// The same traces are sampled as by TraceIDRatioBased.
+		assert.Equal(t, ratio.ShouldSample(params).Decision == RecordAndSample, decision == RecordAndSample)
+	}
+
+	assert.Zero(t, counts[Drop])
+	assert.InDelta(t, fraction*numTraces, counts[RecordAndSample], numTraces/20)
+	assert.InDelta(t, (1-fraction)*numTraces, counts[RecordOnly], numTraces/20)
+	assert.Equal(t, "TraceIDRatioBasedRecordOnly{0.25}", sampler.Description())
+
+	assert.Equal(t, AlwaysSample(), TraceIDRatioBasedRecordOnly(1))
+	params := SamplingParameters{TraceID: trace.TraceID{}}
+	assert.Equal(t, RecordOnly, TraceIDRatioBasedRecordOnly(-1).ShouldSample(params).Decision)
+}
+
+func TestTraceIDRatioBasedRecordOnlyChildren(t *testing.T) {
+	sampler := ParentBased(
+		TraceIDRatioBasedRecordOnly(0),
+		WithLocalParentNotSampled(TraceIDRatioBasedRecordOnly(0)),
+	)
+	tr := NewTracerProvider(WithSampler(sampler)).Tracer("RecordOnlyChildren")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	require.True(t, root.IsRecording())
+	require.False(t, root.SpanContext().IsSampled())
+
+	_, child := tr.Start(ctx, "child")
+	assert.True(t, child.IsRecording(), "child of a RecordOnly root not recorded")
+	assert.False(t, child.SpanContext().IsSampled())
+
+	// Without WithLocalParentNotSampled, the children are dropped.
+	tr = NewTracerProvider(WithSampler(ParentBased(TraceIDRatioBasedRecordOnly(0)))).Tracer("RecordOnlyChildren")
+	ctx, root = tr.Start(context.Background(), "root")
+	require.True(t, root.IsRecording())
+	_, child = tr.Start(ctx, "child")
+	assert.False(t, child.IsRecording())
+}
+
+func TestHashSamplerSamplesInclusively(t *testing.T) {
+	const (
+		numSamplers = 1000
+		numTraces   = 100
+	)
+	idg := defaultIDGenerator()
+
+	for i := 0; i < numSamplers; i++ {
+		ratioLo, ratioHi := rand.Float64(), rand.Float64()
+		if ratioHi < ratioLo {
+			ratioLo, ratioHi = ratioHi, ratioLo
+		}
+		samplerHi := HashSampler(ratioHi)
+		samplerLo := HashSampler(ratioLo)
+		for j := 0; j < numTraces; j++ {
+			traceID, _ := idg.NewIDs(context.Background())
+
+			params := SamplingParameters{TraceID: traceID}
+			if samplerLo.ShouldSample(params).Decision == RecordAndSample {
+				require.Equal(t, RecordAndSample, samplerHi.ShouldSample(params).Decision,
+					"%s sampled but %s did not", samplerLo.Description(), samplerHi.Description())
+			}
+		}
+	}
+}
+
+func TestHashSamplerDeterministic(t *testing.T) {
+	// The hash of the trace ID ending in 0x01 is 0xb456bcfc34c2cb2c and of
+	// the one ending in 0x02 is 0x3abf2a20650683e7.
+	one := SamplingParameters{TraceID: trace.TraceID{15: 1}}
+	two := SamplingParameters{TraceID: trace.TraceID{15: 2}}
+
+	s := HashSampler(0.5)
+	assert.Equal(t, Drop, s.ShouldSample(one).Decision)
+	assert.Equal(t, RecordAndSample, s.ShouldSample(two).Decision)
+	assert.Equal(t, "HashSampler{0.5}", s.Description())
+
+	assert.Equal(t, AlwaysSample(), HashSampler(1))
+	assert.Equal(t, Drop, HashSampler(-1).ShouldSample(two).Decision)
+}
+
+func TestHashSamplerSequentialIDs(t *testing.T) {
+	const numTraces = 10000
+	s := HashSampler(0.25)
+
+	var sampled int
+	for i := 0; i < numTraces; i++ {
+		var tid trace.TraceID
+		binary.BigEndian.PutUint64(tid[8:], uint64(i))
+		if s.ShouldSample(SamplingParameters{TraceID: tid}).Decision == RecordAndSample {
+			sampled++
+		}
+	}
+	// Sequential IDs only differ in their last bytes, they would all be
+	// dropped by a sampler using the first bytes as a random value.
+	assert.InDelta(t, numTraces/4, sampled, numTraces/20)
+}
+
 func TestTracestateIsPassed(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -256,3 +389,83 @@ func TestTracestateIsPassed(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSamplerDescriptionRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sampler Sampler
+	}{
+		{"AlwaysSample", AlwaysSample()},
+		{"NeverSample", NeverSample()},
+		{"TraceIDRatioBased", TraceIDRatioBased(0.25)},
+		{"TraceIDRatioBasedSmall", TraceIDRatioBased(1e-7)},
+		{"TraceIDRatioBasedZero", TraceIDRatioBased(0)},
+		{"TraceIDRatioBasedRecordOnly", TraceIDRatioBasedRecordOnly(0.25)},
+		{"HashSampler", HashSampler(0.25)},
+		{"ParentBased", ParentBased(AlwaysSample())},
+		{"ParentBasedTraceIDRatio", ParentBased(TraceIDRatioBased(0.5))},
+		{
+			"ParentBasedAllDelegates",
+			ParentBased(
+				TraceIDRatioBased(0.1),
+				WithRemoteParentSampled(TraceIDRatioBased(0.2)),
+				WithRemoteParentNotSampled(AlwaysSample()),
+				WithLocalParentSampled(NeverSample()),
+				WithLocalParentNotSampled(TraceIDRatioBased(0.3)),
+			),
+		},
+		{
+			"ParentBasedNested",
+			ParentBased(
+				ParentBased(TraceIDRatioBased(0.5)),
+				WithRemoteParentIgnored(ParentBased(NeverSample())),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			desc := tc.sampler.Description()
+			got, err := ParseSamplerDescription(desc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.sampler, got)
+			assert.Equal(t, desc, got.Description())
+		})
+	}
+}
+
+func TestParseSamplerDescriptionDefaults(t *testing.T) {
+	got, err := ParseSamplerDescription("ParentBased{root:TraceIDRatioBased{0.5}}")
+	require.NoError(t, err)
+	assert.Equal(t, ParentBased(TraceIDRatioBased(0.5)), got)
+}
+
+func TestParseSamplerDescriptionInvalid(t *testing.T) {
+	for _, desc := range []string{
+		"",
+		"AlwaysOn",
+		"Unknown{}",
+		"TraceIDRatioBased",
+		"TraceIDRatioBased{}",
+		"TraceIDRatioBased{half}",
+		"TraceIDRatioBased{-0.5}",
+		"TraceIDRatioBased{1.5}",
+		"TraceIDRatioBased{0.5",
+		"TraceIDRatioBasedRecordOnly{}",
+		"TraceIDRatioBasedRecordOnly{1.5}",
+		"HashSampler{}",
+		"HashSampler{1.5}",
+		"ParentBased{}",
+		"ParentBased{remoteParentSampled:AlwaysOnSampler}",
+		"ParentBased{root:AlwaysOnSampler,root:AlwaysOffSampler}",
+		"ParentBased{root:AlwaysOnSampler,unknown:AlwaysOffSampler}",
+		"ParentBased{root:AlwaysOnSampler,localParentSampled}",
+		"ParentBased{root:Unknown}",
+	} {
+		t.Run(desc, func(t *testing.T) {
+			s, err := ParseSamplerDescription(desc)
+			assert.Error(t, err)
+			assert.Nil(t, s)
+		})
+	}
+}
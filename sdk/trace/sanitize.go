@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// sanitizeUTF8 replaces invalid UTF-8 in the string attribute values of s,
+// and of its events and links, with the Unicode replacement character.
+func (s *snapshot) sanitizeUTF8() {
+	s.attributes = sanitizeAttributes(s.attributes)
+	// The events and links of a snapshot are copies, only their attributes
+	// are shared with the span.
+	for i := range s.events {
+		s.events[i].Attributes = sanitizeAttributes(s.events[i].Attributes)
+	}
+	for i := range s.links {
+		s.links[i].Attributes = sanitizeAttributes(s.links[i].Attributes)
+	}
+}
+
+// sanitizeAttributes returns attrs with invalid UTF-8 in string values
+// replaced with the Unicode replacement character. If all values are valid,
+// attrs is returned, otherwise a sanitized copy of attrs is returned.
+func sanitizeAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	var sanitized []attribute.KeyValue
+	for i, kv := range attrs {
+		v, ok := sanitizeValue(kv.Value)
+		if ok {
+			continue
+		}
+		if sanitized == nil {
+			sanitized = make([]attribute.KeyValue, len(attrs))
+			copy(sanitized, attrs)
+		}
+		sanitized[i].Value = v
+	}
+	if sanitized == nil {
+		return attrs
+	}
+	return sanitized
+}
+
+// sanitizeValue returns v with invalid UTF-8 replaced, and whether v was
+// valid.
+func sanitizeValue(v attribute.Value) (attribute.Value, bool) {
+	switch v.Type() {
+	case attribute.STRING:
+		if s := v.AsString(); !utf8.ValidString(s) {
+			return attribute.StringValue(toValidUTF8(s)), false
+		}
+	case attribute.STRINGSLICE:
+		var sanitized []string
+		for i, s := range v.AsStringSlice() {
+			if utf8.ValidString(s) {
+				continue
+			}
+			if sanitized == nil {
+				// The slice is shared with v, it must not be modified.
+				sanitized = append([]string(nil), v.AsStringSlice()...)
+			}
+			sanitized[i] = toValidUTF8(s)
+		}
+		if sanitized != nil {
+			return attribute.StringSliceValue(sanitized), false
+		}
+	}
+	return v, true
+}
+
+func toValidUTF8(s string) string {
+	return strings.ToValidUTF8(s, string(utf8.RuneError))
+}
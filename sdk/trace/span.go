@@ -23,10 +23,10 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"go.opentelemetry.io/otel/trace"
@@ -143,6 +143,10 @@ type recordingSpan struct {
 	attributes        []attribute.KeyValue
 	droppedAttributes int
 
+	// attributeBytes is the total size of attributes. It is only tracked
+	// if the size of attributes is limited.
+	attributeBytes int
+
 	// events are stored in FIFO queue capped by configured limit.
 	events evictedQueue
 
@@ -180,9 +184,11 @@ func (s *recordingSpan) IsRecording() bool {
 }
 
 // SetStatus sets the status of the Span in the form of a code and a
-// description, overriding previous values set. The description is only
-// included in the set status when the code is for an error. If this span is
-// not being recorded than this method does nothing.
+// description, overriding previous values set with the same or a less severe
+// code. Codes only ever increase in severity, from Unset to Ok to Error, so
+// an Error status is never overridden by an Ok or Unset one. The description
+// is only included in the set status when the code is for an error. If this
+// span is not being recorded than this method does nothing.
 func (s *recordingSpan) SetStatus(code codes.Code, description string) {
 	if !s.IsRecording() {
 		return
@@ -194,10 +200,25 @@ func (s *recordingSpan) SetStatus(code codes.Code, description string) {
 	}
 
 	s.mu.Lock()
-	s.status = status
+	if statusSeverity(code) >= statusSeverity(s.status.Code) {
+		s.status = status
+	}
 	s.mu.Unlock()
 }
 
+// statusSeverity returns the rank of code in the order a Span status is
+// allowed to change: Unset, Ok, then Error.
+func statusSeverity(code codes.Code) int {
+	switch code {
+	case codes.Ok:
+		return 1
+	case codes.Error:
+		return 2
+	default:
+		return 0
+	}
+}
+
 // SetAttributes sets attributes of this span.
 //
 // If a key from attributes already exists the value associated with that key
@@ -225,7 +246,9 @@ func (s *recordingSpan) SetAttributes(attributes ...attribute.KeyValue) {
 
 	// If adding these attributes could exceed the capacity of s perform a
 	// de-duplication and truncation while adding to avoid over allocation.
-	if limit > 0 && len(s.attributes)+len(attributes) > limit {
+	// The same is done if the size of the attributes is limited, it can only
+	// be tracked for de-duplicated attributes.
+	if (limit > 0 && len(s.attributes)+len(attributes) > limit) || s.tracer.provider.spanLimits.TotalAttributeBytesLimit > 0 {
 		s.addOverCapAttrs(limit, attributes)
 		return
 	}
@@ -245,17 +268,19 @@ func (s *recordingSpan) SetAttributes(attributes ...attribute.KeyValue) {
 
 // addOverCapAttrs adds the attributes attrs to the span s while
 // de-duplicating the attributes of s and attrs and dropping attributes that
-// exceed the limit.
+// exceed the limit, or the TotalAttributeBytesLimit of s.
 //
 // This method assumes s.mu.Lock is held by the caller.
 //
 // This method should only be called when there is a possibility that adding
-// attrs to s will exceed the limit. Otherwise, attrs should be added to s
-// without checking for duplicates and all retrieval methods of the attributes
-// for s will de-duplicate as needed.
+// attrs to s will exceed the limit, or when the size of the attributes is
+// limited. Otherwise, attrs should be added to s without checking for
+// duplicates and all retrieval methods of the attributes for s will
+// de-duplicate as needed.
 //
-// This method assumes limit is a value > 0. The argument should be validated
-// by the caller.
+// This method assumes limit is a non-zero value, a negative limit means the
+// number of attributes is not limited. The argument should be validated by
+// the caller.
 func (s *recordingSpan) addOverCapAttrs(limit int, attrs []attribute.KeyValue) {
 	// In order to not allocate more capacity to s.attributes than needed,
 	// prune and truncate this addition of attributes while adding.
@@ -265,6 +290,8 @@ func (s *recordingSpan) addOverCapAttrs(limit int, attrs []attribute.KeyValue) {
 	exists := make(map[attribute.Key]int)
 	s.dedupeAttrsFromRecord(&exists)
 
+	byteLimit := s.tracer.provider.spanLimits.TotalAttributeBytesLimit
+
 	// Now that s.attributes is deduplicated, adding unique attributes up to
 	// the capacity of s will not over allocate s.attributes.
 	for _, a := range attrs {
@@ -273,25 +300,64 @@ func (s *recordingSpan) addOverCapAttrs(limit int, attrs []attribute.KeyValue) {
 			s.droppedAttributes++
 			continue
 		}
+		a = truncateAttr(s.tracer.provider.spanLimits.AttributeValueLengthLimit, a)
+
+		var size int
+		if byteLimit > 0 {
+			size = attrSize(a)
+		}
 
 		if idx, ok := exists[a.Key]; ok {
 			// Perform all updates before dropping, even when at capacity.
+			if byteLimit > 0 {
+				bytes := s.attributeBytes - attrSize(s.attributes[idx]) + size
+				if bytes > byteLimit {
+					s.droppedAttributes++
+					continue
+				}
+				s.attributeBytes = bytes
+			}
 			s.attributes[idx] = a
 			continue
 		}
 
-		if len(s.attributes) >= limit {
+		if (limit > 0 && len(s.attributes) >= limit) || (byteLimit > 0 && s.attributeBytes+size > byteLimit) {
 			// Do not just drop all of the remaining attributes, make sure
 			// updates are checked and performed.
 			s.droppedAttributes++
 		} else {
-			a = truncateAttr(s.tracer.provider.spanLimits.AttributeValueLengthLimit, a)
 			s.attributes = append(s.attributes, a)
+			s.attributeBytes += size
 			exists[a.Key] = len(s.attributes) - 1
 		}
 	}
 }
 
+// attrSize returns the size of attr counted against the
+// TotalAttributeBytesLimit of a span.
+func attrSize(attr attribute.KeyValue) int {
+	size := len(attr.Key)
+	switch attr.Value.Type() {
+	case attribute.BOOL:
+		size++
+	case attribute.INT64, attribute.FLOAT64:
+		size += 8
+	case attribute.STRING:
+		size += len(attr.Value.AsString())
+	case attribute.BOOLSLICE:
+		size += len(attr.Value.AsBoolSlice())
+	case attribute.INT64SLICE:
+		size += 8 * len(attr.Value.AsInt64Slice())
+	case attribute.FLOAT64SLICE:
+		size += 8 * len(attr.Value.AsFloat64Slice())
+	case attribute.STRINGSLICE:
+		for _, v := range attr.Value.AsStringSlice() {
+			size += len(v)
+		}
+	}
+	return size
+}
+
 // truncateAttr returns a truncated version of attr. Only string and string
 // slice attribute values are truncated. String values are truncated to at
 // most a length of limit. Each string slice value is truncated in this fasion
@@ -336,7 +402,9 @@ func truncateAttr(limit int, attr attribute.KeyValue) attribute.KeyValue {
 // is not being recorded.
 //
 // The only SpanOption currently supported is WithTimestamp which will set the
-// end time for a Span's life-cycle.
+// end time for a Span's life-cycle. An end time before the start time of the
+// Span is replaced with the start time, and reported to the global error
+// handler if it was set with WithTimestamp.
 //
 // If this method is called while panicking an error event is added to the
 // Span before ending it and the panic is continued.
@@ -349,7 +417,7 @@ func (s *recordingSpan) End(options ...trace.SpanEndOption) {
 
 	// Store the end time as soon as possible to avoid artificially increasing
 	// the span's duration in case some operation below takes a while.
-	et := internal.MonotonicEndTime(s.startTime)
+	et := s.tracer.provider.endTime(s.startTime)
 
 	// Do relative expensive check now that we have an end time and see if we
 	// need to do any more processing.
@@ -381,6 +449,7 @@ func (s *recordingSpan) End(options ...trace.SpanEndOption) {
 		s.executionTracerTaskEnd()
 	}
 
+	var invalidEnd error
 	s.mu.Lock()
 	// Setting endTime to non-zero marks the span as ended and not recording.
 	if config.Timestamp().IsZero() {
@@ -388,8 +457,23 @@ func (s *recordingSpan) End(options ...trace.SpanEndOption) {
 	} else {
 		s.endTime = config.Timestamp()
 	}
+	// A span never ends before it starts, e.g. because the time source
+	// stepped backward or the end timestamp is wrong.
+	if s.endTime.Before(s.startTime) {
+		if !config.Timestamp().IsZero() {
+			invalidEnd = fmt.Errorf(
+				"span %q end timestamp %v is before its start time %v, it ends at its start time",
+				s.name, s.endTime, s.startTime,
+			)
+		}
+		s.endTime = s.startTime
+	}
 	s.mu.Unlock()
 
+	if invalidEnd != nil {
+		otel.Handle(invalidEnd)
+	}
+
 	if sps, ok := s.tracer.provider.spanProcessors.Load().(spanProcessorStates); ok {
 		if len(sps) == 0 {
 			return
@@ -451,24 +535,71 @@ func (s *recordingSpan) AddEvent(name string, o ...trace.EventOption) {
 }
 
 func (s *recordingSpan) addEvent(name string, o ...trace.EventOption) {
+	if s.tracer.provider.timeSource != nil {
+		// Timestamp the event with the time source unless o sets one.
+		o = append([]trace.EventOption{trace.WithTimestamp(s.tracer.provider.now())}, o...)
+	}
 	c := trace.NewEventConfig(o...)
-	e := Event{Name: name, Attributes: c.Attributes(), Time: c.Timestamp()}
+	e := s.limitEvent(Event{Name: name, Attributes: c.Attributes(), Time: c.Timestamp()})
 
-	// Discard attributes over limit.
+	s.mu.Lock()
+	s.events.add(e)
+	s.mu.Unlock()
+}
+
+// limitEvent returns e with its attributes over the AttributePerEventCountLimit
+// of s discarded.
+func (s *recordingSpan) limitEvent(e Event) Event {
 	limit := s.tracer.provider.spanLimits.AttributePerEventCountLimit
 	if limit == 0 {
 		// Drop all attributes.
-		e.DroppedAttributeCount = len(e.Attributes)
+		e.DroppedAttributeCount += len(e.Attributes)
 		e.Attributes = nil
 	} else if limit > 0 && len(e.Attributes) > limit {
 		// Drop over capacity.
-		e.DroppedAttributeCount = len(e.Attributes) - limit
+		e.DroppedAttributeCount += len(e.Attributes) - limit
 		e.Attributes = e.Attributes[:limit]
 	}
+	return e
+}
+
+// AddEvents adds events to span with their timestamps, like a call to
+// span.AddEvent with trace.WithTimestamp and trace.WithAttributes for each of
+// them would. Events with a zero Time are timestamped with the current time,
+// of the time source of the TracerProvider if span was created by this SDK.
+// The DroppedAttributeCount of events is kept and added to.
+//
+// When span was created by this SDK, all events are added at once, with the
+// EventCountLimit and AttributePerEventCountLimit of its TracerProvider
+// applied the same way. If events hold more events than the EventCountLimit,
+// only the last ones are kept and the others are counted as dropped. This
+// avoids synchronizing for each event when replaying many of them.
+func AddEvents(span trace.Span, events ...Event) {
+	s, ok := span.(*recordingSpan)
+	if !ok {
+		for _, e := range events {
+			span.AddEvent(e.Name, trace.WithTimestamp(e.Time), trace.WithAttributes(e.Attributes...))
+		}
+		return
+	}
+	if !s.IsRecording() || len(events) == 0 {
+		return
+	}
+
+	now := s.tracer.provider.now()
+	limited := make([]Event, len(events))
+	for i, e := range events {
+		if e.Time.IsZero() {
+			e.Time = now
+		}
+		limited[i] = s.limitEvent(e)
+	}
 
 	s.mu.Lock()
-	s.events.add(e)
-	s.mu.Unlock()
+	defer s.mu.Unlock()
+	for _, e := range limited {
+		s.events.add(e)
+	}
 }
 
 // SetName sets the name of this span. If this span is not being recorded than
@@ -478,6 +609,8 @@ func (s *recordingSpan) SetName(name string) {
 		return
 	}
 
+	name = s.tracer.provider.normalizeSpanName(name)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.name = name
@@ -703,6 +836,12 @@ func (s *recordingSpan) snapshot() ReadOnlySpan {
 		sd.links = s.interfaceArrayToLinksArray()
 		sd.droppedLinkCount = s.links.droppedCount
 	}
+	if s.tracer.provider.sanitizeUTF8 {
+		sd.sanitizeUTF8()
+	}
+	if s.tracer.provider.countAttributes {
+		sd.addCountAttributes()
+	}
 	return &sd
 }
 
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "go.opentelemetry.io/otel/attribute"
+
+const (
+	// LinkCountKey is the attribute key of the number of links added to a
+	// Span. It is set when the WithCountAttributes option is used.
+	LinkCountKey = attribute.Key("otel.span.link_count")
+
+	// EventCountKey is the attribute key of the number of events added to
+	// a Span. It is set when the WithCountAttributes option is used.
+	EventCountKey = attribute.Key("otel.span.event_count")
+)
+
+// addCountAttributes adds the number of links and events, including the
+// dropped ones, to the attributes of s.
+func (s *snapshot) addCountAttributes() {
+	// The attributes of a snapshot are shared with the span, copy them
+	// instead of appending to them.
+	attrs := make([]attribute.KeyValue, 0, len(s.attributes)+2)
+	attrs = append(attrs, s.attributes...)
+	s.attributes = append(attrs,
+		LinkCountKey.Int(len(s.links)+s.droppedLinkCount),
+		EventCountKey.Int(len(s.events)+s.droppedEventCount),
+	)
+}
@@ -40,6 +40,10 @@ const (
 	// DefaultAttributePerLinkCountLimit is the default maximum number of
 	// attributes a span link can have.
 	DefaultAttributePerLinkCountLimit = 128
+
+	// DefaultTotalAttributeBytesLimit is the default maximum size of all
+	// attributes of a span, unlimited.
+	DefaultTotalAttributeBytesLimit = -1
 )
 
 // SpanLimits represents the limits of a span.
@@ -95,6 +99,19 @@ type SpanLimits struct {
 	//
 	// Setting this to a negative value means no limit is applied.
 	AttributePerLinkCountLimit int
+
+	// TotalAttributeBytesLimit is the maximum size in bytes of all
+	// attributes of a span combined. Any attribute that would make the
+	// attributes of a span exceed this size once added will be dropped. This
+	// protects against many small attributes adding up to a large span.
+	//
+	// The size of an attribute is the length of its key plus the size of its
+	// value: the length of a string, 1 byte for a bool, 8 bytes for a number,
+	// and the sum of the sizes of the elements of a slice. It is measured
+	// after the value is truncated to the AttributeValueLengthLimit.
+	//
+	// Setting this to zero or a negative value means no limit is applied.
+	TotalAttributeBytesLimit int
 }
 
 // NewSpanLimits returns a SpanLimits with all limits set to the value their
@@ -113,6 +130,8 @@ type SpanLimits struct {
 // • LinkCountLimit: OTEL_SPAN_LINK_COUNT_LIMIT (default: 128)
 //
 // • AttributePerLinkCountLimit: OTEL_LINK_ATTRIBUTE_COUNT_LIMIT (default: 128)
+//
+// • TotalAttributeBytesLimit: (default: unlimited)
 func NewSpanLimits() SpanLimits {
 	return SpanLimits{
 		AttributeValueLengthLimit:   env.SpanAttributeValueLength(DefaultAttributeValueLengthLimit),
@@ -121,5 +140,6 @@ func NewSpanLimits() SpanLimits {
 		LinkCountLimit:              env.SpanLinkCount(DefaultLinkCountLimit),
 		AttributePerEventCountLimit: env.SpanEventAttributeCount(DefaultAttributePerEventCountLimit),
 		AttributePerLinkCountLimit:  env.SpanLinkAttributeCount(DefaultAttributePerLinkCountLimit),
+		TotalAttributeBytesLimit:    DefaultTotalAttributeBytesLimit,
 	}
 }
@@ -213,6 +213,56 @@ func TestSpanLimits(t *testing.T) {
 		assert.Len(t, testSpanLimits(t, limits).Attributes(), 0)
 	})
 
+	t.Run("TotalAttributeBytesLimit", func(t *testing.T) {
+		limits := NewSpanLimits()
+		// Unlimited.
+		limits.TotalAttributeBytesLimit = -1
+		span := testSpanLimits(t, limits)
+		assert.Len(t, span.Attributes(), 2)
+		assert.Equal(t, 0, span.DroppedAttributes())
+
+		// "string" is 9 bytes and "stringSlice" is 17 bytes.
+		limits.TotalAttributeBytesLimit = 26
+		span = testSpanLimits(t, limits)
+		assert.Len(t, span.Attributes(), 2)
+		assert.Equal(t, 0, span.DroppedAttributes())
+
+		limits.TotalAttributeBytesLimit = 25
+		span = testSpanLimits(t, limits)
+		assert.Equal(t, []attribute.KeyValue{attribute.String("string", "abc")}, span.Attributes())
+		assert.Equal(t, 1, span.DroppedAttributes())
+
+		// The size is measured after truncation.
+		limits.AttributeValueLengthLimit = 1
+		limits.TotalAttributeBytesLimit = 20
+		span = testSpanLimits(t, limits)
+		assert.Len(t, span.Attributes(), 2)
+		assert.Equal(t, 0, span.DroppedAttributes())
+	})
+
+	t.Run("TotalAttributeBytesLimitAtStart", func(t *testing.T) {
+		limits := NewSpanLimits()
+		limits.TotalAttributeBytesLimit = 10
+		rec := new(recorder)
+		tp := NewTracerProvider(WithRawSpanLimits(limits), WithSpanProcessor(rec))
+
+		_, span := tp.Tracer("testSpanLimits").Start(
+			context.Background(),
+			"span-name",
+			trace.WithAttributes(
+				attribute.Int64("a", 1),
+				attribute.Int64("b", 2),
+			),
+		)
+		// Updating an attribute to a value of the same size still fits.
+		span.SetAttributes(attribute.Int64("a", 3), attribute.Bool("c", true))
+		span.End()
+
+		require.Len(t, *rec, 1, "exported spans")
+		assert.Equal(t, []attribute.KeyValue{attribute.Int64("a", 3)}, (*rec)[0].Attributes())
+		assert.Equal(t, 2, (*rec)[0].DroppedAttributes())
+	})
+
 	t.Run("EventCountLimit", func(t *testing.T) {
 		limits := NewSpanLimits()
 		// Unlimited.
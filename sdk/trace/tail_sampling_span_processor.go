@@ -0,0 +1,409 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Defaults for TailSamplingOptions.
+const (
+	DefaultTailSamplingMaxTraces    = 1000
+	DefaultTailSamplingTraceTimeout = 30 * time.Second
+)
+
+// TailSamplingPolicy decides, once a trace is complete, whether its ended
+// spans are passed on. It returns true to keep the spans and false to drop
+// them. It is called while the SpanProcessor is locked and should return
+// quickly.
+type TailSamplingPolicy func(spans []ReadOnlySpan) bool
+
+// TailSamplingOption configures a tail sampling SpanProcessor.
+type TailSamplingOption func(o *TailSamplingOptions)
+
+// TailSamplingOptions is configuration settings for a tail sampling
+// SpanProcessor.
+type TailSamplingOptions struct {
+	// MaxTraces is the maximum number of traces buffered while waiting for
+	// a decision. When it is reached, the least recently active trace, the
+	// one whose last span ended the earliest, is decided early to make room
+	// for a new one. The decisions of as many traces are remembered to
+	// apply them to spans ended after their trace is decided.
+	// The default value of MaxTraces is 1000.
+	MaxTraces int
+
+	// TraceTimeout is the maximum duration a trace is buffered for. A trace
+	// whose local root span has not ended after this duration is decided
+	// with the spans ended so far.
+	// The default value of TraceTimeout is 30 seconds.
+	TraceTimeout time.Duration
+
+	// MaxBufferedSpans is the maximum number of spans buffered across all
+	// traces waiting for a decision. When it is exceeded, the least
+	// recently active traces are evicted and their spans dropped without
+	// a decision until the limit is met again. Spans of an evicted trace
+	// ended later are dropped as well. This bounds the memory used when
+	// spans are leaked and traces never complete.
+	// The default value of MaxBufferedSpans is 0, meaning the number of
+	// buffered spans is only bounded by MaxTraces.
+	MaxBufferedSpans int
+
+	// DroppedSpans counts the buffered spans dropped when their trace is
+	// evicted to meet MaxBufferedSpans.
+	// The default value of DroppedSpans is nil, meaning dropped spans are
+	// not counted.
+	DroppedSpans Int64Counter
+}
+
+// WithMaxTraces returns a TailSamplingOption that sets the maximum number of
+// traces buffered while waiting for a decision.
+func WithMaxTraces(n int) TailSamplingOption {
+	return func(o *TailSamplingOptions) {
+		o.MaxTraces = n
+	}
+}
+
+// WithTraceTimeout returns a TailSamplingOption that sets the maximum
+// duration a trace is buffered for.
+func WithTraceTimeout(timeout time.Duration) TailSamplingOption {
+	return func(o *TailSamplingOptions) {
+		o.TraceTimeout = timeout
+	}
+}
+
+// WithMaxBufferedSpans returns a TailSamplingOption that sets the maximum
+// number of spans buffered across all traces waiting for a decision.
+func WithMaxBufferedSpans(n int) TailSamplingOption {
+	return func(o *TailSamplingOptions) {
+		o.MaxBufferedSpans = n
+	}
+}
+
+// WithDroppedSpansCounter returns a TailSamplingOption that sets the counter
+// of the buffered spans dropped when their trace is evicted to meet the
+// maximum number of buffered spans.
+func WithDroppedSpansCounter(c Int64Counter) TailSamplingOption {
+	return func(o *TailSamplingOptions) {
+		o.DroppedSpans = c
+	}
+}
+
+// pendingTrace is a trace waiting for a decision.
+type pendingTrace struct {
+	id    trace.TraceID
+	spans []ReadOnlySpan
+	start time.Time
+	// elem is the element of the trace in the recency list.
+	elem *list.Element
+}
+
+// decidedTrace is a trace the policy has decided on.
+type decidedTrace struct {
+	spans []ReadOnlySpan
+	keep  bool
+}
+
+// tailSamplingSpanProcessor is a SpanProcessor that buffers the ended spans
+// of a trace and passes them on only if a policy keeps the trace.
+type tailSamplingSpanProcessor struct {
+	next   SpanProcessor
+	policy TailSamplingPolicy
+	o      TailSamplingOptions
+
+	mu      sync.Mutex
+	stopped bool
+	pending map[trace.TraceID]*pendingTrace
+	// recency holds the pending traces, the one a span was last appended to
+	// at the front.
+	recency *list.List
+	// buffered is the number of spans of the pending traces.
+	buffered int
+	// decided holds the decisions of the last decided traces, in the order
+	// of decidedIDs.
+	decided    map[trace.TraceID]bool
+	decidedIDs []trace.TraceID
+
+	stopCh   chan struct{}
+	stopWait sync.WaitGroup
+	stopOnce sync.Once
+}
+
+var _ SpanProcessor = (*tailSamplingSpanProcessor)(nil)
+
+// NewTailSamplingSpanProcessor returns a new SpanProcessor that buffers the
+// ended spans of each trace, and passes them on to next only if policy
+// keeps the trace. The trace is decided when its local root span ends, or
+// when it has been buffered for longer than the trace timeout. Spans of a
+// trace ended after it is decided follow the same decision.
+//
+// Spans are passed on to next unaltered, so next still honors their head
+// sampling decision. Use a Sampler that samples all spans, e.g. AlwaysSample,
+// for the policy to decide on all traces.
+func NewTailSamplingSpanProcessor(next SpanProcessor, policy TailSamplingPolicy, options ...TailSamplingOption) SpanProcessor {
+	o := TailSamplingOptions{
+		MaxTraces:    DefaultTailSamplingMaxTraces,
+		TraceTimeout: DefaultTailSamplingTraceTimeout,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.MaxTraces < 1 {
+		o.MaxTraces = 1
+	}
+	if o.TraceTimeout <= 0 {
+		o.TraceTimeout = DefaultTailSamplingTraceTimeout
+	}
+
+	tsp := &tailSamplingSpanProcessor{
+		next:    next,
+		policy:  policy,
+		o:       o,
+		pending: make(map[trace.TraceID]*pendingTrace),
+		recency: list.New(),
+		decided: make(map[trace.TraceID]bool),
+		stopCh:  make(chan struct{}),
+	}
+
+	tsp.stopWait.Add(1)
+	go func() {
+		defer tsp.stopWait.Done()
+		tsp.expireTraces()
+	}()
+
+	return tsp
+}
+
+// OnStart passes s on to the next SpanProcessor.
+func (tsp *tailSamplingSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	tsp.next.OnStart(parent, s)
+}
+
+// OnEnd buffers s until its trace is decided.
+func (tsp *tailSamplingSpanProcessor) OnEnd(s ReadOnlySpan) {
+	var ready []decidedTrace
+
+	tsp.mu.Lock()
+	if tsp.stopped {
+		tsp.mu.Unlock()
+		return
+	}
+	tid := s.SpanContext().TraceID()
+	if keep, ok := tsp.decided[tid]; ok {
+		tsp.mu.Unlock()
+		if keep {
+			tsp.next.OnEnd(s)
+		}
+		return
+	}
+
+	pt, ok := tsp.pending[tid]
+	if !ok {
+		if len(tsp.pending) >= tsp.o.MaxTraces {
+			ready = append(ready, tsp.removeLeastRecent())
+		}
+		pt = &pendingTrace{id: tid, start: time.Now()}
+		pt.elem = tsp.recency.PushFront(pt)
+		tsp.pending[tid] = pt
+	} else {
+		tsp.recency.MoveToFront(pt.elem)
+	}
+	pt.spans = append(pt.spans, s)
+	tsp.buffered++
+	if p := s.Parent(); !p.IsValid() || p.IsRemote() {
+		// The local root span ended, the trace is complete.
+		ready = append(ready, tsp.remove(tid))
+	}
+	dropped := tsp.evict()
+	tsp.mu.Unlock()
+
+	if dropped > 0 {
+		global.Warn("spans dropped because the tail sampling buffer is full", "count", dropped)
+		if tsp.o.DroppedSpans != nil {
+			tsp.o.DroppedSpans.Add(context.Background(), int64(dropped))
+		}
+	}
+	for _, d := range ready {
+		tsp.export(d)
+	}
+}
+
+// Shutdown decides all buffered traces and shuts the next SpanProcessor
+// down.
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	tsp.stopOnce.Do(func() {
+		close(tsp.stopCh)
+		tsp.stopWait.Wait()
+
+		tsp.mu.Lock()
+		ready := tsp.removeAll()
+		tsp.stopped = true
+		tsp.mu.Unlock()
+
+		for _, d := range ready {
+			tsp.export(d)
+		}
+		err = tsp.next.Shutdown(ctx)
+	})
+	return err
+}
+
+// ForceFlush decides all buffered traces and flushes the next
+// SpanProcessor.
+func (tsp *tailSamplingSpanProcessor) ForceFlush(ctx context.Context) error {
+	tsp.mu.Lock()
+	ready := tsp.removeAll()
+	tsp.mu.Unlock()
+
+	for _, d := range ready {
+		tsp.export(d)
+	}
+	return tsp.next.ForceFlush(ctx)
+}
+
+// minExpireInterval is the shortest interval at which buffered traces are
+// checked for expiry, used when half of the trace timeout is shorter.
+const minExpireInterval = time.Millisecond
+
+// expireTraces decides the traces buffered for longer than the trace
+// timeout until the SpanProcessor is shut down.
+func (tsp *tailSamplingSpanProcessor) expireTraces() {
+	interval := tsp.o.TraceTimeout / 2
+	if interval < minExpireInterval {
+		interval = minExpireInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-tsp.stopCh:
+			return
+		case now := <-ticker.C:
+			var ready []decidedTrace
+			tsp.mu.Lock()
+			for tid, pt := range tsp.pending {
+				if now.Sub(pt.start) >= tsp.o.TraceTimeout {
+					ready = append(ready, tsp.remove(tid))
+				}
+			}
+			tsp.mu.Unlock()
+
+			for _, d := range ready {
+				tsp.export(d)
+			}
+		}
+	}
+}
+
+// export passes the spans of d on to the next SpanProcessor if the policy
+// keeps them.
+func (tsp *tailSamplingSpanProcessor) export(d decidedTrace) {
+	if !d.keep {
+		return
+	}
+	for _, s := range d.spans {
+		tsp.next.OnEnd(s)
+	}
+}
+
+// remove removes the trace tid from the pending traces, decides it, and
+// records the decision. It must be called with mu held.
+func (tsp *tailSamplingSpanProcessor) remove(tid trace.TraceID) decidedTrace {
+	pt := tsp.pending[tid]
+	delete(tsp.pending, tid)
+	tsp.recency.Remove(pt.elem)
+	tsp.buffered -= len(pt.spans)
+
+	d := decidedTrace{spans: pt.spans, keep: tsp.policy(pt.spans)}
+	tsp.recordDecision(tid, d.keep)
+	return d
+}
+
+// recordDecision remembers the decision keep for the trace tid. It must be
+// called with mu held.
+func (tsp *tailSamplingSpanProcessor) recordDecision(tid trace.TraceID, keep bool) {
+	if len(tsp.decidedIDs) >= tsp.o.MaxTraces {
+		delete(tsp.decided, tsp.decidedIDs[0])
+		tsp.decidedIDs = tsp.decidedIDs[1:]
+	}
+	tsp.decided[tid] = keep
+	tsp.decidedIDs = append(tsp.decidedIDs, tid)
+}
+
+// leastRecent returns the pending trace a span was appended to the longest
+// ago. It must be called with mu held and at least one pending trace.
+func (tsp *tailSamplingSpanProcessor) leastRecent() *pendingTrace {
+	return tsp.recency.Back().Value.(*pendingTrace)
+}
+
+// removeLeastRecent removes the least recently active pending trace and
+// decides it. It must be called with mu held.
+func (tsp *tailSamplingSpanProcessor) removeLeastRecent() decidedTrace {
+	return tsp.remove(tsp.leastRecent().id)
+}
+
+// evict drops the least recently active pending traces, without deciding
+// them, until no more than MaxBufferedSpans spans are buffered. It returns
+// the number of spans dropped. It must be called with mu held.
+func (tsp *tailSamplingSpanProcessor) evict() int {
+	if tsp.o.MaxBufferedSpans <= 0 {
+		return 0
+	}
+	var dropped int
+	for tsp.buffered > tsp.o.MaxBufferedSpans {
+		pt := tsp.leastRecent()
+		n := len(pt.spans)
+		delete(tsp.pending, pt.id)
+		tsp.recency.Remove(pt.elem)
+		tsp.buffered -= n
+		dropped += n
+		// Drop the spans of the trace ended later as well, the trace is
+		// incomplete.
+		tsp.recordDecision(pt.id, false)
+	}
+	return dropped
+}
+
+// removeAll removes all the pending traces and decides them. It must be
+// called with mu held.
+func (tsp *tailSamplingSpanProcessor) removeAll() []decidedTrace {
+	ready := make([]decidedTrace, 0, len(tsp.pending))
+	for tid := range tsp.pending {
+		ready = append(ready, tsp.remove(tid))
+	}
+	return ready
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Span Processor.
+func (tsp *tailSamplingSpanProcessor) MarshalLog() interface{} {
+	return struct {
+		Type             string
+		MaxTraces        int
+		TraceTimeout     time.Duration
+		MaxBufferedSpans int
+	}{
+		Type:             "TailSamplingSpanProcessor",
+		MaxTraces:        tsp.o.MaxTraces,
+		TraceTimeout:     tsp.o.TraceTimeout,
+		MaxBufferedSpans: tsp.o.MaxBufferedSpans,
+	}
+}
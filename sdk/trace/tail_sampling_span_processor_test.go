@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// hasError is a TailSamplingPolicy keeping traces with an error span.
+func hasError(spans []sdktrace.ReadOnlySpan) bool {
+	for _, s := range spans {
+		if s.Status().Code == codes.Error {
+			return true
+		}
+	}
+	return false
+}
+
+func keepAll([]sdktrace.ReadOnlySpan) bool { return true }
+
+func endedNames(sr *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, s := range sr.Ended() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestTailSamplingSpanProcessorPolicy(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp := sdktrace.NewTailSamplingSpanProcessor(sr, hasError)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorPolicy")
+
+	ctx, root := tr.Start(context.Background(), "errored root")
+	_, child := tr.Start(ctx, "errored child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	_, late := tr.Start(ctx, "errored late child")
+	assert.Empty(t, sr.Ended(), "spans passed on before the trace completed")
+	root.End()
+	late.End()
+
+	ctx, root = tr.Start(context.Background(), "ok root")
+	_, child = tr.Start(ctx, "ok child")
+	child.End()
+	root.End()
+
+	assert.Equal(t, []string{"errored child", "errored root", "errored late child"}, endedNames(sr))
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestTailSamplingSpanProcessorTimeout(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp := sdktrace.NewTailSamplingSpanProcessor(sr, keepAll, sdktrace.WithTraceTimeout(10*time.Millisecond))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorTimeout")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	defer root.End()
+	_, child := tr.Start(ctx, "child")
+	child.End()
+
+	assert.Eventually(t, func() bool {
+		return len(sr.Ended()) == 1
+	}, time.Second, 5*time.Millisecond, "trace not decided after timeout")
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestTailSamplingSpanProcessorNanosecondTimeout(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp := sdktrace.NewTailSamplingSpanProcessor(sr, keepAll, sdktrace.WithTraceTimeout(time.Nanosecond))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorNanosecondTimeout")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	defer root.End()
+	_, child := tr.Start(ctx, "child")
+	child.End()
+
+	assert.Eventually(t, func() bool {
+		return len(sr.Ended()) == 1
+	}, time.Second, 5*time.Millisecond, "trace not decided after timeout")
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestTailSamplingSpanProcessorMaxTraces(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp := sdktrace.NewTailSamplingSpanProcessor(sr, keepAll, sdktrace.WithMaxTraces(1))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorMaxTraces")
+
+	ctx, root0 := tr.Start(context.Background(), "root0")
+	_, child0 := tr.Start(ctx, "child0")
+	child0.End()
+	ctx, root1 := tr.Start(context.Background(), "root1")
+	_, child1 := tr.Start(ctx, "child1")
+	child1.End()
+
+	// The first trace is decided early to buffer the second one.
+	assert.Equal(t, []string{"child0"}, endedNames(sr))
+
+	require.NoError(t, tsp.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"child0", "child1"}, endedNames(sr))
+
+	root0.End()
+	root1.End()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child0", "child1", "root0", "root1"}, endedNames(sr))
+}
+
+func TestTailSamplingSpanProcessorMaxBufferedSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	dropped := &recordingCounter{}
+	tsp := sdktrace.NewTailSamplingSpanProcessor(
+		sr,
+		keepAll,
+		sdktrace.WithMaxBufferedSpans(3),
+		sdktrace.WithDroppedSpansCounter(dropped),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorMaxBufferedSpans")
+
+	ctx0, root0 := tr.Start(context.Background(), "root0")
+	for _, name := range []string{"child0a", "child0b"} {
+		_, child := tr.Start(ctx0, name)
+		child.End()
+	}
+	ctx1, root1 := tr.Start(context.Background(), "root1")
+	_, child := tr.Start(ctx1, "child1a")
+	child.End()
+	assert.Equal(t, int64(0), dropped.value())
+
+	// The oldest trace is evicted to buffer a fourth span.
+	_, child = tr.Start(ctx1, "child1b")
+	child.End()
+	assert.Equal(t, int64(2), dropped.value())
+
+	// Spans of the evicted trace ended later are dropped as well.
+	root0.End()
+	root1.End()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child1a", "child1b", "root1"}, endedNames(sr))
+	assert.Equal(t, int64(2), dropped.value())
+}
+
+func TestTailSamplingSpanProcessorEvictsLeastRecent(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	dropped := &recordingCounter{}
+	tsp := sdktrace.NewTailSamplingSpanProcessor(
+		sr,
+		keepAll,
+		sdktrace.WithMaxBufferedSpans(3),
+		sdktrace.WithDroppedSpansCounter(dropped),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorEvictsLeastRecent")
+
+	ctx0, root0 := tr.Start(context.Background(), "root0")
+	_, child := tr.Start(ctx0, "child0a")
+	child.End()
+	ctx1, root1 := tr.Start(context.Background(), "root1")
+	_, child = tr.Start(ctx1, "child1a")
+	child.End()
+	// The first trace buffered is the most recently active one.
+	_, child = tr.Start(ctx0, "child0b")
+	child.End()
+
+	// The second trace is evicted to buffer a fourth span.
+	ctx2, root2 := tr.Start(context.Background(), "root2")
+	_, child = tr.Start(ctx2, "child2a")
+	child.End()
+	assert.Equal(t, int64(1), dropped.value())
+
+	root0.End()
+	root1.End()
+	root2.End()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child0a", "child0b", "root0", "child2a", "root2"}, endedNames(sr))
+	assert.Equal(t, int64(1), dropped.value())
+}
+
+func TestTailSamplingSpanProcessorShutdown(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp := sdktrace.NewTailSamplingSpanProcessor(sr, keepAll)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("TailSamplingSpanProcessorShutdown")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, child := tr.Start(ctx, "child")
+	child.End()
+
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child"}, endedNames(sr))
+
+	// Spans ended after shutdown are dropped.
+	root.End()
+	assert.Equal(t, []string{"child"}, endedNames(sr))
+}
@@ -16,9 +16,14 @@ package trace
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"os"
+	"path/filepath"
+	rt "runtime/trace"
 	"strconv"
 	"strings"
 	"sync"
@@ -387,6 +392,37 @@ func TestStartSpanNewRootNotSampled(t *testing.T) {
 	}
 }
 
+func TestSetSpanAttributesIfRecording(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "SetAttributesIfRecording")
+	var calls int
+	trace.SetAttributesIfRecording(span, func() []attribute.KeyValue {
+		calls++
+		return []attribute.KeyValue{attribute.String("key1", "value1")}
+	})
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("SetAttributesIfRecording called fn %d times, want 1", calls)
+	}
+	want := []attribute.KeyValue{attribute.String("key1", "value1")}
+	if diff := cmpDiff(got.Attributes(), want); diff != "" {
+		t.Errorf("SetAttributesIfRecording: -got +want %s", diff)
+	}
+
+	// A non-recording span does not call fn.
+	tp = NewTracerProvider(WithSampler(NeverSample()))
+	_, span = tp.Tracer("SetAttributesIfRecording").Start(context.Background(), "span")
+	trace.SetAttributesIfRecording(span, func() []attribute.KeyValue {
+		t.Error("SetAttributesIfRecording called fn of a non-recording span")
+		return nil
+	})
+	span.End()
+}
+
 func TestSetSpanAttributesOnStart(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -441,6 +477,41 @@ func TestSamplerAttributesLocalChildSpan(t *testing.T) {
 	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 1)}, gotSpan1.Attributes())
 }
 
+type routeSampler struct {
+	route string
+}
+
+func (s routeSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	for _, kv := range p.Attributes {
+		if kv.Key == semconv.HTTPTargetKey && kv.Value.AsString() == s.route {
+			return SamplingResult{Decision: RecordAndSample}
+		}
+	}
+	return SamplingResult{Decision: Drop}
+}
+
+func (s routeSampler) Description() string {
+	return "routeSampler"
+}
+
+func TestSamplerStartAttributes(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSampler(routeSampler{route: "/sampled"}), WithSyncer(te))
+	tr := tp.Tracer("TestSamplerStartAttributes")
+
+	_, span := tr.Start(context.Background(), "sampled", trace.WithAttributes(semconv.HTTPTargetKey.String("/sampled")))
+	assert.True(t, span.SpanContext().IsSampled())
+	span.End()
+
+	_, span = tr.Start(context.Background(), "dropped", trace.WithAttributes(semconv.HTTPTargetKey.String("/health")))
+	assert.False(t, span.SpanContext().IsSampled())
+	span.End()
+
+	got := te.Spans()
+	require.Len(t, got, 1)
+	assert.Equal(t, "sampled", got[0].Name())
+}
+
 func TestSpanSetAttributes(t *testing.T) {
 	attrs := [...]attribute.KeyValue{
 		attribute.String("key1", "value1"),
@@ -726,6 +797,48 @@ func TestEventsOverLimit(t *testing.T) {
 	}
 }
 
+func TestAddEventsOverLimit(t *testing.T) {
+	te := NewTestExporter()
+	sl := NewSpanLimits()
+	sl.EventCountLimit = 2
+	sl.AttributePerEventCountLimit = 1
+	tp := NewTracerProvider(WithSpanLimits(sl), WithSyncer(te), WithResource(resource.Empty()))
+
+	span := startSpan(tp, "AddEventsOverLimit")
+	k1v1 := attribute.String("key1", "value1")
+	k2v2 := attribute.Bool("key2", false)
+	t1 := time.Unix(100, 0)
+	t2 := time.Unix(200, 0)
+
+	AddEvents(span,
+		Event{Name: "fooDrop", Time: t1},
+		Event{Name: "barDrop", Time: t1},
+		Event{Name: "foo", Attributes: []attribute.KeyValue{k1v1}, Time: t1},
+		Event{Name: "bar", Attributes: []attribute.KeyValue{k1v1, k2v2}, DroppedAttributeCount: 1, Time: t2},
+	)
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		events: []Event{
+			{Name: "foo", Attributes: []attribute.KeyValue{k1v1}, Time: t1},
+			{Name: "bar", Attributes: []attribute.KeyValue{k1v1}, DroppedAttributeCount: 2, Time: t2},
+		},
+		droppedEventCount:    2,
+		spanKind:             trace.SpanKindInternal,
+		instrumentationScope: instrumentation.Scope{Name: "AddEventsOverLimit"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("AddEvents over limit: -got +want %s", diff)
+	}
+}
+
 func TestLinks(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -838,6 +951,302 @@ func TestSetSpanName(t *testing.T) {
 	}
 }
 
+func TestStartFromRemote(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+
+	// A local span in ctx is ignored in favor of the remote parent.
+	ctx, local := tp.Tracer("StartFromRemote").Start(context.Background(), "local")
+	_, span := trace.StartFromRemote(ctx, tp.Tracer("StartFromRemote"), sc, "span")
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, sc.WithRemote(true), got.Parent())
+	assert.True(t, got.Parent().IsRemote())
+	assert.Equal(t, sc.TraceID(), got.SpanContext().TraceID())
+	assert.NotEqual(t, local.SpanContext().TraceID(), got.SpanContext().TraceID())
+}
+
+func TestWithSpanNameNormalizer(t *testing.T) {
+	te := NewTestExporter()
+	normalize := func(name string) string {
+		if strings.HasPrefix(name, "/users/") {
+			return "/users/{id}"
+		}
+		return name
+	}
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+		WithSpanNameNormalizer(normalize),
+	)
+	tr := tp.Tracer("SpanNameNormalizer")
+
+	_, span := tr.Start(context.Background(), "/users/123")
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, "/users/{id}", got.Name())
+
+	te.Reset()
+	_, span = tr.Start(context.Background(), "/")
+	span.SetName("/users/456")
+	got, err = endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, "/users/{id}", got.Name())
+}
+
+func TestWithRejectEmptySpanNames(t *testing.T) {
+	handler.Reset()
+	defer handler.Reset()
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithRejectEmptySpanNames("unnamed"))
+	tr := tp.Tracer("RejectEmptySpanNames")
+
+	_, span := tr.Start(context.Background(), "")
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, "unnamed", got.Name())
+	require.Len(t, handler.errs, 1)
+	assert.EqualError(t, handler.errs[0], `empty span name replaced with "unnamed"`)
+
+	te.Reset()
+	_, span = tr.Start(context.Background(), "named")
+	span.SetName("")
+	got, err = endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, "unnamed", got.Name())
+	assert.Len(t, handler.errs, 2)
+
+	// Empty names are kept by default.
+	te.Reset()
+	_, span = NewTracerProvider(WithSyncer(te)).Tracer("RejectEmptySpanNames").Start(context.Background(), "")
+	got, err = endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, "", got.Name())
+	assert.Len(t, handler.errs, 2)
+}
+
+func TestWithSamplerAttributes(t *testing.T) {
+	remote := TraceIDRatioBasedRecordOnly(0)
+	sampler := ParentBased(AlwaysSample(), WithRemoteParentSampled(remote))
+	tp := NewTracerProvider(WithSampler(sampler), WithSamplerAttributes())
+	tr := tp.Tracer("SamplerAttributes")
+
+	_, span := tr.Start(context.Background(), "root")
+	assert.Equal(t, []attribute.KeyValue{
+		SamplerKey.String(AlwaysSample().Description()),
+		SamplerResultKey.String("RECORD_AND_SAMPLE"),
+	}, span.(ReadOnlySpan).Attributes())
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+	_, span = tr.Start(ctx, "child", trace.WithAttributes(attribute.String("key", "value")))
+	assert.Equal(t, []attribute.KeyValue{
+		SamplerKey.String(remote.Description()),
+		SamplerResultKey.String("RECORD_ONLY"),
+		attribute.String("key", "value"),
+	}, span.(ReadOnlySpan).Attributes())
+
+	// The attributes are not added by default.
+	_, span = NewTracerProvider(WithSampler(sampler)).Tracer("SamplerAttributes").Start(context.Background(), "root")
+	assert.Empty(t, span.(ReadOnlySpan).Attributes())
+}
+
+func TestWithDefaultSpanAttributes(t *testing.T) {
+	env := attribute.String("deployment.environment", "production")
+	version := attribute.String("service.version", "1.0.0")
+
+	t.Run("Defaults", func(t *testing.T) {
+		te := NewTestExporter()
+		tp := NewTracerProvider(WithSyncer(te), WithDefaultSpanAttributes(env, version))
+		_, span := tp.Tracer("DefaultSpanAttributes").Start(context.Background(), "span")
+		got, err := endSpan(te, span)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []attribute.KeyValue{env, version}, got.Attributes())
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		te := NewTestExporter()
+		tp := NewTracerProvider(WithSyncer(te), WithDefaultSpanAttributes(env, version))
+		staging := attribute.String("deployment.environment", "staging")
+		_, span := tp.Tracer("DefaultSpanAttributes").Start(
+			context.Background(),
+			"span",
+			trace.WithAttributes(staging),
+		)
+		got, err := endSpan(te, span)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []attribute.KeyValue{staging, version}, got.Attributes())
+	})
+
+	t.Run("CountLimit", func(t *testing.T) {
+		te := NewTestExporter()
+		sl := NewSpanLimits()
+		sl.AttributeCountLimit = 2
+		tp := NewTracerProvider(
+			WithSyncer(te),
+			WithSpanLimits(sl),
+			WithDefaultSpanAttributes(env, version),
+		)
+		_, span := tp.Tracer("DefaultSpanAttributes").Start(
+			context.Background(),
+			"span",
+			trace.WithAttributes(attribute.String("key", "value")),
+		)
+		got, err := endSpan(te, span)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []attribute.KeyValue{env, version}, got.Attributes())
+		assert.Equal(t, 1, got.DroppedAttributes())
+	})
+}
+
+func TestWithUTF8Sanitization(t *testing.T) {
+	invalid := "a\xffb"
+	newAttrs := func() []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("invalid", invalid),
+			attribute.StringSlice("slice", []string{"valid", invalid}),
+			attribute.String("valid", "héllo"),
+			attribute.Int("int", 1),
+		}
+	}
+	attrs := newAttrs()
+	want := []attribute.KeyValue{
+		attribute.String("invalid", "a\uFFFDb"),
+		attribute.StringSlice("slice", []string{"valid", "a\uFFFDb"}),
+		attribute.String("valid", "héllo"),
+		attribute.Int("int", 1),
+	}
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithUTF8Sanitization())
+	_, span := tp.Tracer("UTF8Sanitization").Start(
+		context.Background(),
+		"span",
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(trace.Link{SpanContext: sc, Attributes: attrs}),
+	)
+	span.AddEvent("event", trace.WithAttributes(attrs...))
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, want, got.Attributes())
+	require.Len(t, got.Events(), 1)
+	assert.ElementsMatch(t, want, got.Events()[0].Attributes)
+	require.Len(t, got.Links(), 1)
+	assert.ElementsMatch(t, want, got.Links()[0].Attributes)
+
+	// The span itself is not modified.
+	ro := span.(ReadOnlySpan)
+	assert.ElementsMatch(t, newAttrs(), ro.Attributes())
+	assert.ElementsMatch(t, newAttrs(), ro.Events()[0].Attributes)
+	assert.ElementsMatch(t, newAttrs(), ro.Links()[0].Attributes)
+}
+
+func TestWithCountAttributes(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithCountAttributes(),
+		WithSpanLimits(SpanLimits{
+			AttributeValueLengthLimit:   -1,
+			AttributeCountLimit:         -1,
+			EventCountLimit:             2,
+			LinkCountLimit:              -1,
+			AttributePerEventCountLimit: -1,
+			AttributePerLinkCountLimit:  -1,
+		}),
+	)
+	links := []trace.Link{{SpanContext: sc}, {SpanContext: sc}, {SpanContext: sc}}
+	_, span := tp.Tracer("CountAttributes").Start(
+		context.Background(),
+		"span",
+		trace.WithAttributes(attribute.String("key", "value")),
+		trace.WithLinks(links...),
+	)
+	for i := 0; i < 5; i++ {
+		span.AddEvent("event")
+	}
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	// Dropped events are counted.
+	assert.Equal(t, 3, got.DroppedEvents())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("key", "value"),
+		LinkCountKey.Int(3),
+		EventCountKey.Int(5),
+	}, got.Attributes())
+
+	// The span itself is not modified.
+	assert.Equal(t, []attribute.KeyValue{attribute.String("key", "value")}, span.(ReadOnlySpan).Attributes())
+}
+
+func TestStartIfSampled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sampled", func(t *testing.T) {
+		te := NewTestExporter()
+		tp := NewTracerProvider(WithSyncer(te), WithSampler(AlwaysSample()))
+		c, span, ok := StartIfSampled(ctx, tp.Tracer("StartIfSampled"), "span")
+		require.True(t, ok)
+		assert.True(t, span.IsRecording())
+		assert.Equal(t, span, trace.SpanFromContext(c))
+		span.End()
+		assert.Equal(t, 1, te.Len())
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		te := NewTestExporter()
+		tp := NewTracerProvider(WithSyncer(te), WithSampler(NeverSample()))
+		c, span, ok := StartIfSampled(ctx, tp.Tracer("StartIfSampled"), "span")
+		require.False(t, ok)
+		assert.False(t, span.IsRecording())
+		assert.Equal(t, ctx, c)
+		span.End()
+		assert.Equal(t, 0, te.Len())
+	})
+
+	t.Run("SampledParent", func(t *testing.T) {
+		tp := NewTracerProvider(WithSampler(ParentBased(NeverSample())))
+		parent := trace.ContextWithRemoteSpanContext(ctx, sc)
+		_, span, ok := StartIfSampled(parent, tp.Tracer("StartIfSampled"), "span")
+		require.True(t, ok)
+		assert.Equal(t, sc.TraceID(), span.SpanContext().TraceID())
+	})
+
+	t.Run("DroppedWithParent", func(t *testing.T) {
+		tp := NewTracerProvider(WithSampler(ParentBased(AlwaysSample())))
+		psc := sc.WithTraceFlags(0)
+		parent := trace.ContextWithRemoteSpanContext(ctx, psc)
+		c, span, ok := StartIfSampled(parent, tp.Tracer("StartIfSampled"), "span")
+		require.False(t, ok)
+		assert.Equal(t, parent, c)
+		assert.False(t, span.IsRecording())
+
+		// The dropped span continues the trace of its parent, like with Start.
+		_, started := tp.Tracer("StartIfSampled").Start(parent, "span")
+		assert.Equal(t, psc.TraceID(), span.SpanContext().TraceID())
+		assert.True(t, span.SpanContext().SpanID().IsValid())
+		assert.False(t, span.SpanContext().IsSampled())
+		assert.Equal(t, started.SpanContext().TraceFlags(), span.SpanContext().TraceFlags())
+	})
+
+	t.Run("OtherTracer", func(t *testing.T) {
+		tracer := trace.NewNoopTracerProvider().Tracer("StartIfSampled")
+		c, span, ok := StartIfSampled(ctx, tracer, "span")
+		require.False(t, ok)
+		assert.False(t, span.IsRecording())
+		assert.Equal(t, ctx, c)
+	})
+}
+
 func TestSetSpanStatus(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -898,6 +1307,80 @@ func TestSetSpanStatusWithoutMessageWhenStatusIsNotError(t *testing.T) {
 	}
 }
 
+func TestSetSpanStatusDoesNotDowngrade(t *testing.T) {
+	tp := NewTracerProvider(WithResource(resource.Empty()))
+	tr := tp.Tracer("SpanStatus")
+
+	errStatus := Status{Code: codes.Error, Description: "Error"}
+	tests := []struct {
+		name  string
+		calls []Status
+		want  Status
+	}{
+		{
+			name:  "UnsetToOk",
+			calls: []Status{{Code: codes.Unset}, {Code: codes.Ok}},
+			want:  Status{Code: codes.Ok},
+		},
+		{
+			name:  "OkToUnset",
+			calls: []Status{{Code: codes.Ok}, {Code: codes.Unset}},
+			want:  Status{Code: codes.Ok},
+		},
+		{
+			name:  "OkToError",
+			calls: []Status{{Code: codes.Ok}, errStatus},
+			want:  errStatus,
+		},
+		{
+			name:  "ErrorToOk",
+			calls: []Status{errStatus, {Code: codes.Ok}},
+			want:  errStatus,
+		},
+		{
+			name:  "ErrorToUnset",
+			calls: []Status{errStatus, {Code: codes.Unset}},
+			want:  errStatus,
+		},
+		{
+			name:  "ErrorToError",
+			calls: []Status{errStatus, {Code: codes.Error, Description: "other"}},
+			want:  Status{Code: codes.Error, Description: "other"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, span := tr.Start(context.Background(), test.name)
+			for _, c := range test.calls {
+				span.SetStatus(c.Code, c.Description)
+			}
+			assert.Equal(t, test.want, span.(ReadOnlySpan).Status())
+		})
+	}
+}
+
+func TestSpanKeepsParentTraceFlags(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	tr := tp.Tracer("TraceFlags")
+
+	for _, flags := range []trace.TraceFlags{
+		trace.FlagsSampled | trace.FlagsRandom,
+		0xff,
+	} {
+		parent := sc.WithTraceFlags(flags)
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+		name := fmt.Sprintf("span-%s", flags)
+		_, span := tr.Start(ctx, name)
+		span.End()
+
+		got, ok := te.GetSpan(name)
+		require.True(t, ok, "span %s not exported", name)
+		assert.Equal(t, flags, got.SpanContext().TraceFlags())
+	}
+}
+
 func cmpDiff(x, y interface{}) string {
 	return cmp.Diff(x, y,
 		cmp.AllowUnexported(snapshot{}),
@@ -1155,6 +1638,359 @@ func TestRecordingSpanRuntimeTracerTaskEnd(t *testing.T) {
 	}
 }
 
+func TestExecutionTracerDisabled(t *testing.T) {
+	if rt.IsEnabled() {
+		t.Skip("execution tracer already running")
+	}
+	require.NoError(t, rt.Start(io.Discard))
+	defer rt.Stop()
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(strconv.FormatBool(enabled), func(t *testing.T) {
+			tp := NewTracerProvider(WithSampler(AlwaysSample()), WithExecutionTracer(enabled))
+			_, apiSpan := tp.Tracer("TestExecutionTracerDisabled").Start(context.Background(), "foo")
+			s, ok := apiSpan.(*recordingSpan)
+			require.True(t, ok, "recording span not returned from always sampled Tracer")
+
+			s.mu.Lock()
+			hooked := s.executionTracerTaskEnd != nil
+			s.mu.Unlock()
+			assert.Equal(t, enabled, hooked)
+			s.End()
+		})
+	}
+}
+
+func TestBatchSpanProcessorMaxSpanAge(t *testing.T) {
+	const maxAge = time.Hour
+	te := NewTestExporter()
+	bsp := NewBatchSpanProcessor(te, WithMaxSpanAge(maxAge)).(*batchSpanProcessor)
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithSampler(AlwaysSample()))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+	tr := tp.Tracer("TestBatchSpanProcessorMaxSpanAge")
+
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	var now time.Time
+	bsp.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	_, ended := tr.Start(ctx, "ended", trace.WithTimestamp(start))
+	ended.End(trace.WithTimestamp(start.Add(time.Minute)))
+	_, leaked := tr.Start(ctx, "leaked", trace.WithTimestamp(start))
+	_, young := tr.Start(ctx, "young", trace.WithTimestamp(start.Add(maxAge/2)))
+
+	now = start.Add(maxAge / 2)
+	bsp.endTimedOutSpans()
+	assert.True(t, leaked.IsRecording(), "span ended before exceeding max age")
+
+	now = start.Add(maxAge + time.Minute)
+	bsp.endTimedOutSpans()
+	assert.False(t, leaked.IsRecording(), "span exceeding max age not ended")
+	assert.True(t, young.IsRecording(), "span ended before exceeding max age")
+
+	require.NoError(t, bsp.ForceFlush(ctx))
+	require.Equal(t, 2, te.Len())
+	got, ok := te.GetSpan("leaked")
+	require.True(t, ok, "timed out span not exported")
+	assert.Equal(t, now, got.EndTime())
+	assert.Equal(t, codes.Error, got.Status().Code)
+	assert.Contains(t, got.Attributes(), attribute.Bool("otel.span.timed_out", true))
+
+	got, ok = te.GetSpan("ended")
+	require.True(t, ok, "ended span not exported")
+	assert.NotContains(t, got.Attributes(), attribute.Bool("otel.span.timed_out", true))
+
+	young.End()
+}
+
+func TestBatchSpanProcessorPartialSpanExport(t *testing.T) {
+	const age = time.Minute
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	clock := &logicalClock{now: start}
+
+	te := NewTestExporter()
+	bsp := NewBatchSpanProcessor(te, WithPartialSpanExport(age)).(*batchSpanProcessor)
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithSampler(AlwaysSample()), WithTimeSource(clock.Now))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+	tr := tp.Tracer("TestBatchSpanProcessorPartialSpanExport")
+
+	ctx := context.Background()
+	attr := attribute.String("key", "value")
+	_, stream := tr.Start(ctx, "stream", trace.WithAttributes(attr))
+	_, short := tr.Start(ctx, "short")
+	short.End()
+
+	clock.Set(start.Add(age / 2))
+	bsp.exportPartialSpans()
+	require.NoError(t, bsp.ForceFlush(ctx))
+	require.Equal(t, 1, te.Len(), "partial span exported before exceeding age")
+
+	clock.Set(start.Add(age + time.Second))
+	bsp.exportPartialSpans()
+	// The partial span is only exported once.
+	bsp.exportPartialSpans()
+	require.NoError(t, bsp.ForceFlush(ctx))
+	require.Equal(t, 2, te.Len())
+	assert.True(t, stream.IsRecording(), "partial span export ended the span")
+
+	partial := te.Spans()[1]
+	assert.Equal(t, "stream", partial.Name())
+	assert.Equal(t, stream.SpanContext(), partial.SpanContext())
+	assert.Equal(t, clock.Now(), partial.EndTime(), "partial span not ended at the snapshot time")
+	assert.Equal(t, []attribute.KeyValue{attr, attribute.Bool("otel.span.partial", true)}, partial.Attributes())
+
+	clock.Set(start.Add(2 * age))
+	stream.End()
+	require.NoError(t, bsp.ForceFlush(ctx))
+	require.Equal(t, 3, te.Len())
+
+	final := te.Spans()[2]
+	assert.Equal(t, stream.SpanContext(), final.SpanContext())
+	assert.Equal(t, clock.Now(), final.EndTime())
+	assert.Equal(t, []attribute.KeyValue{attr}, final.Attributes())
+}
+
+// failingExporter fails to export any span.
+type failingExporter struct{}
+
+func (failingExporter) ExportSpans(context.Context, []ReadOnlySpan) error {
+	return errors.New("export failed")
+}
+func (failingExporter) Shutdown(context.Context) error { return nil }
+
+func TestBatchSpanProcessorDiskBufferPersistsEndedSpans(t *testing.T) {
+	const age = time.Minute
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	clock := &logicalClock{now: start}
+	path := filepath.Join(t.TempDir(), "spans")
+
+	// The exports fail, the queued spans stay persisted.
+	bsp := NewBatchSpanProcessor(
+		failingExporter{},
+		WithBatchTimeout(time.Hour),
+		WithDiskBuffer(path, 1<<20),
+		WithPartialSpanExport(age),
+		WithQueueLatencyAttribute(),
+	).(*batchSpanProcessor)
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithSampler(AlwaysSample()), WithTimeSource(clock.Now))
+	tr := tp.Tracer("TestBatchSpanProcessorDiskBufferPersistsEndedSpans")
+
+	ctx := context.Background()
+	attr := attribute.String("key", "value")
+	_, open := tr.Start(ctx, "open")
+	defer open.End()
+	_, ended := tr.Start(ctx, "ended", trace.WithAttributes(attr))
+	ended.End()
+
+	clock.Set(start.Add(2 * age))
+	bsp.exportPartialSpans()
+	assert.Error(t, bsp.ForceFlush(ctx))
+	require.NoError(t, bsp.Shutdown(ctx))
+	handler.Reset()
+
+	// The partial span and the queue latency are not persisted.
+	d, replayed, err := openDiskBuffer(path, 1<<20)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.close()) }()
+	require.Len(t, replayed, 1)
+	assert.Equal(t, "ended", replayed[0].Name())
+	assert.Equal(t, []attribute.KeyValue{attr}, replayed[0].Attributes())
+}
+
+func TestBatchSpanProcessorDiskBufferEvictsFailedSpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans")
+	tp := NewTracerProvider(WithSampler(AlwaysSample()))
+	tr := tp.Tracer("TestBatchSpanProcessorDiskBufferEvictsFailedSpans")
+	ctx := context.Background()
+
+	// Size the file to hold a few spans.
+	_, s := tr.Start(ctx, "failed")
+	s.End()
+	line, err := json.Marshal(diskEntry{Seq: 1, Span: newDiskSpan(s.(ReadOnlySpan))})
+	require.NoError(t, err)
+	maxBytes := int64(4 * (len(line) + 1))
+
+	bsp := NewBatchSpanProcessor(
+		failingExporter{},
+		WithBatchTimeout(time.Hour),
+		WithDiskBuffer(path, maxBytes),
+	)
+	tp.RegisterSpanProcessor(bsp)
+	for i := 0; i < 10; i++ {
+		_, s := tr.Start(ctx, "failed")
+		s.End()
+		assert.Error(t, bsp.ForceFlush(ctx))
+	}
+
+	// The failed spans do not prevent new spans from being persisted.
+	_, s = tr.Start(ctx, "new")
+	s.End()
+	require.NoError(t, bsp.Shutdown(ctx))
+	handler.Reset()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), maxBytes)
+
+	d, replayed, err := openDiskBuffer(path, maxBytes)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.close()) }()
+	require.NotEmpty(t, replayed)
+	assert.Less(t, len(replayed), 11, "failed spans not evicted")
+	assert.Equal(t, "new", replayed[len(replayed)-1].Name())
+}
+
+func TestWithTimeSource(t *testing.T) {
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithSampler(AlwaysSample()), WithTimeSource(clock))
+	tr := tp.Tracer("TestWithTimeSource")
+	ctx := context.Background()
+
+	_, span := tr.Start(ctx, "logical")
+	now = start.Add(time.Second)
+	span.AddEvent("event")
+	AddEvents(span, Event{Name: "events"})
+	now = start.Add(time.Minute)
+	span.End()
+
+	explicit := start.Add(-time.Hour)
+	_, span = tr.Start(ctx, "explicit", trace.WithTimestamp(explicit))
+	span.End(trace.WithTimestamp(explicit.Add(time.Second)))
+
+	got, ok := te.GetSpan("logical")
+	require.True(t, ok, "span not exported")
+	assert.Equal(t, start, got.StartTime())
+	assert.Equal(t, start.Add(time.Minute), got.EndTime())
+	require.Len(t, got.Events(), 2)
+	for _, e := range got.Events() {
+		assert.Equal(t, start.Add(time.Second), e.Time, e.Name)
+	}
+
+	got, ok = te.GetSpan("explicit")
+	require.True(t, ok, "span not exported")
+	assert.Equal(t, explicit, got.StartTime())
+	assert.Equal(t, explicit.Add(time.Second), got.EndTime())
+}
+
+func TestClockStepsBackward(t *testing.T) {
+	handler.Reset()
+	defer handler.Reset()
+
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithSampler(AlwaysSample()), WithTimeSource(clock))
+	tr := tp.Tracer("TestClockStepsBackward")
+	ctx := context.Background()
+
+	_, span := tr.Start(ctx, "stepped")
+	now = start.Add(-time.Minute)
+	span.End()
+
+	assert.Empty(t, handler.errs, "stepped clock reported")
+
+	now = start
+	_, span = tr.Start(ctx, "explicit")
+	span.End(trace.WithTimestamp(start.Add(-time.Second)))
+
+	got, ok := te.GetSpan("stepped")
+	require.True(t, ok, "span not exported")
+	assert.Equal(t, start, got.StartTime())
+	assert.Equal(t, start, got.EndTime(), "end time before start time")
+
+	// Explicit timestamps before the start time are replaced as well, and
+	// reported so they can be found.
+	got, ok = te.GetSpan("explicit")
+	require.True(t, ok, "span not exported")
+	assert.Equal(t, start, got.EndTime(), "end time before start time")
+	assert.Equal(t, start, span.(ReadOnlySpan).EndTime())
+	require.Len(t, handler.errs, 1)
+	assert.Contains(t, handler.errs[0].Error(), `span "explicit" end timestamp`)
+}
+
+// logicalClock is a time source for tests that is safe for concurrent use.
+type logicalClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *logicalClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *logicalClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func TestWithTimeSourceBatchSpanProcessorSchedule(t *testing.T) {
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	clock := &logicalClock{now: start}
+
+	te := NewTestExporter()
+	bsp := NewBatchSpanProcessor(te, WithBatchTimeout(time.Minute), WithMaxSpanAge(time.Hour))
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithSampler(AlwaysSample()), WithTimeSource(clock.Now))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	tr := tp.Tracer("TestWithTimeSourceBatchSpanProcessorSchedule")
+	_, span := tr.Start(context.Background(), "span")
+	span.End()
+	_, leaked := tr.Start(context.Background(), "leaked")
+
+	// The logical clock does not advance, neither does the schedule.
+	time.Sleep(10 * timeSourcePollInterval)
+	assert.Equal(t, 0, te.Len(), "batch exported before the BatchTimeout elapsed on the time source")
+
+	clock.Set(start.Add(time.Minute))
+	assert.Eventually(t, func() bool {
+		return te.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond, "batch not exported after the BatchTimeout elapsed on the time source")
+	assert.True(t, leaked.IsRecording(), "span ended before exceeding max age")
+
+	clock.Set(start.Add(2 * time.Hour))
+	assert.Eventually(t, func() bool {
+		return !leaked.IsRecording()
+	}, 5*time.Second, 10*time.Millisecond, "span not ended after the MaxSpanAge elapsed on the time source")
+}
+
+func TestWithTimeSourceBatchSpanProcessor(t *testing.T) {
+	const maxAge = time.Hour
+	start := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	clock := &logicalClock{now: start}
+
+	te := NewTestExporter()
+	bsp := NewBatchSpanProcessor(te, WithMaxSpanAge(maxAge)).(*batchSpanProcessor)
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithSampler(AlwaysSample()), WithTimeSource(clock.Now))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	ctx := context.Background()
+	_, span := tp.Tracer("TestWithTimeSourceBatchSpanProcessor").Start(ctx, "leaked")
+
+	// The age of the span is determined by the logical clock alone.
+	clock.Set(start.Add(maxAge / 2))
+	bsp.endTimedOutSpans()
+	assert.True(t, span.IsRecording(), "span ended before exceeding max age")
+
+	clock.Set(start.Add(maxAge + time.Minute))
+	bsp.endTimedOutSpans()
+	assert.False(t, span.IsRecording(), "span exceeding max age not ended")
+
+	require.NoError(t, bsp.ForceFlush(ctx))
+	got, ok := te.GetSpan("leaked")
+	require.True(t, ok, "timed out span not exported")
+	assert.Equal(t, start, got.StartTime())
+	assert.Equal(t, clock.Now(), got.EndTime())
+}
+
 func TestCustomStartEndTime(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithSampler(AlwaysSample()))
@@ -1400,6 +2236,27 @@ func TestWithResource(t *testing.T) {
 				WithResource(resource.NewSchemaless(attribute.String("rk3", "rv3"), attribute.Int64("rk4", 10)))},
 			want: mergeResource(t, resource.Environment(), resource.NewSchemaless(attribute.String("rk3", "rv3"), attribute.Int64("rk4", 10))),
 		},
+		{
+			name:    "without default resource keeps environment resource",
+			options: []TracerProviderOption{WithoutDefaultResource()},
+			want:    resource.Environment(),
+		},
+		{
+			name: "without default resource before explicit resource",
+			options: []TracerProviderOption{
+				WithoutDefaultResource(),
+				WithResource(resource.NewSchemaless(attribute.String("rk1", "rv1"))),
+			},
+			want: mergeResource(t, resource.Environment(), resource.NewSchemaless(attribute.String("rk1", "rv1"))),
+		},
+		{
+			name: "without default resource after explicit resource",
+			options: []TracerProviderOption{
+				WithResource(resource.NewSchemaless(attribute.String("rk1", "rv1"))),
+				WithoutDefaultResource(),
+			},
+			want: mergeResource(t, resource.Environment(), resource.NewSchemaless(attribute.String("rk1", "rv1"))),
+		},
 		{
 			name:    "overlapping attributes with environment resource",
 			options: []TracerProviderOption{WithResource(resource.NewSchemaless(attribute.String("rk1", "rv1"), attribute.Int64("rk5", 10)))},
@@ -16,7 +16,6 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
 	"context"
-	"time"
 
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/trace"
@@ -36,7 +35,49 @@ var _ trace.Tracer = &tracer{}
 // configured appropriately by any SpanOption passed.
 func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
 	config := trace.NewSpanStartConfig(options...)
+	return tr.start(ctx, tr.sample(ctx, name, &config), &config)
+}
+
+// StartIfSampled starts a Span with t, and returns it along with a context
+// containing it and true, only if the Span would be recorded. If the Sampler
+// of t decides to drop the Span, the passed ctx is returned unchanged along
+// with a non-recording Span and false.
+//
+// The sampling decision is made exactly as for t.Start, the parent found in
+// ctx is passed to the Sampler and honored the same way. The non-recording
+// Span returned for a dropped Span is the one t.Start returns, its
+// SpanContext has the trace ID of the parent and is not sampled. When t is a
+// Tracer of this SDK, no recording Span nor context is created if the Span
+// is dropped, this avoids the overhead of Start on hot paths where most
+// Spans are dropped. Because ctx is returned unchanged for dropped Spans,
+// Spans started from it will be children of the parent of the dropped Span.
+// To propagate the SpanContext of the dropped Span instead, add it to ctx
+// with trace.ContextWithSpan.
+//
+// When t is not a Tracer of this SDK the Span is started with t.Start, and
+// false is returned for non-recording Spans.
+func StartIfSampled(ctx context.Context, t trace.Tracer, name string, options ...trace.SpanStartOption) (context.Context, trace.Span, bool) {
+	tr, ok := t.(*tracer)
+	if !ok {
+		c, s := t.Start(ctx, name, options...)
+		if !s.IsRecording() {
+			return ctx, s, false
+		}
+		return c, s, true
+	}
+
+	config := trace.NewSpanStartConfig(options...)
+	ss := tr.sample(ctx, name, &config)
+	if !isRecording(ss.result) {
+		return ctx, tr.newNonRecordingSpan(ss.sc), false
+	}
+	c, s := tr.start(ctx, ss, &config)
+	return c, s, true
+}
 
+// start starts the span sampled as ss and returns it along with a context
+// containing it.
+func (tr *tracer) start(ctx context.Context, ss spanSampling, config *trace.SpanConfig) (context.Context, trace.Span) {
 	// For local spans created by this SDK, track child span count.
 	if p := trace.SpanFromContext(ctx); p != nil {
 		if sdkSpan, ok := p.(*recordingSpan); ok {
@@ -44,14 +85,14 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanS
 		}
 	}
 
-	s := tr.newSpan(ctx, name, &config)
+	s := tr.newSpan(ss, config)
 	if rw, ok := s.(ReadWriteSpan); ok && s.IsRecording() {
 		sps, _ := tr.provider.spanProcessors.Load().(spanProcessorStates)
 		for _, sp := range sps {
 			sp.sp.OnStart(ctx, rw)
 		}
 	}
-	if rtt, ok := s.(runtimeTracer); ok {
+	if rtt, ok := s.(runtimeTracer); ok && tr.provider.executionTracer {
 		ctx = rtt.runtimeTrace(ctx)
 	}
 
@@ -64,8 +105,23 @@ type runtimeTracer interface {
 	runtimeTrace(ctx context.Context) context.Context
 }
 
-// newSpan returns a new configured span.
-func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanConfig) trace.Span {
+// spanSampling is the sampling decision made for a span before it is
+// created.
+type spanSampling struct {
+	// name is the normalized name of the span.
+	name string
+	// psc is the SpanContext of the parent of the span.
+	psc trace.SpanContext
+	// sc is the SpanContext of the span.
+	sc     trace.SpanContext
+	result SamplingResult
+}
+
+// sample returns the sampling decision for a span named name started with
+// ctx and config.
+func (tr *tracer) sample(ctx context.Context, name string, config *trace.SpanConfig) spanSampling {
+	name = tr.provider.normalizeSpanName(name)
+
 	// If told explicitly to make this a new root use a zero value SpanContext
 	// as a parent which contains an invalid trace ID and is not remote.
 	var psc trace.SpanContext
@@ -87,14 +143,19 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 		sid = tr.provider.idGenerator.NewSpanID(ctx, tid)
 	}
 
-	samplingResult := tr.provider.sampler.ShouldSample(SamplingParameters{
+	sampler := tr.provider.loadSampler()
+	params := SamplingParameters{
 		ParentContext: ctx,
 		TraceID:       tid,
 		Name:          name,
 		Kind:          config.SpanKind(),
 		Attributes:    config.Attributes(),
 		Links:         config.Links(),
-	})
+	}
+	samplingResult := sampler.ShouldSample(params)
+	if tr.provider.samplerAttributes && isRecording(samplingResult) {
+		samplingResult.Attributes = withSamplerAttributes(samplingResult, sampler, params)
+	}
 
 	scc := trace.SpanContextConfig{
 		TraceID:    tid,
@@ -106,19 +167,28 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 	} else {
 		scc.TraceFlags = psc.TraceFlags() &^ trace.FlagsSampled
 	}
-	sc := trace.NewSpanContext(scc)
 
-	if !isRecording(samplingResult) {
-		return tr.newNonRecordingSpan(sc)
+	return spanSampling{
+		name:   name,
+		psc:    psc,
+		sc:     trace.NewSpanContext(scc),
+		result: samplingResult,
+	}
+}
+
+// newSpan returns a new configured span for the sampling decision ss.
+func (tr *tracer) newSpan(ss spanSampling, config *trace.SpanConfig) trace.Span {
+	if !isRecording(ss.result) {
+		return tr.newNonRecordingSpan(ss.sc)
 	}
-	return tr.newRecordingSpan(psc, sc, name, samplingResult, config)
+	return tr.newRecordingSpan(ss.psc, ss.sc, ss.name, ss.result, config)
 }
 
 // newRecordingSpan returns a new configured recordingSpan.
 func (tr *tracer) newRecordingSpan(psc, sc trace.SpanContext, name string, sr SamplingResult, config *trace.SpanConfig) *recordingSpan {
 	startTime := config.Timestamp()
 	if startTime.IsZero() {
-		startTime = time.Now()
+		startTime = tr.provider.now()
 	}
 
 	s := &recordingSpan{
@@ -144,6 +214,7 @@ func (tr *tracer) newRecordingSpan(psc, sc trace.SpanContext, name string, sr Sa
 		s.addLink(l)
 	}
 
+	s.SetAttributes(tr.provider.defaultSpanAttributes...)
 	s.SetAttributes(sr.Attributes...)
 	s.SetAttributes(config.Attributes()...)
 
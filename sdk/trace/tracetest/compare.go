@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest // import "go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+import (
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ComparerOption configures the SpanStub comparison of SpanStubComparer.
+type ComparerOption interface {
+	apply(comparerConfig) comparerConfig
+}
+
+type comparerConfig struct {
+	spanIDs    bool
+	timestamps bool
+}
+
+type comparerOptionFunc func(comparerConfig) comparerConfig
+
+func (fn comparerOptionFunc) apply(cfg comparerConfig) comparerConfig {
+	return fn(cfg)
+}
+
+// WithSpanIDs compares the span IDs of the SpanContext and Parent of
+// SpanStubs.
+func WithSpanIDs() ComparerOption {
+	return comparerOptionFunc(func(cfg comparerConfig) comparerConfig {
+		cfg.spanIDs = true
+		return cfg
+	})
+}
+
+// WithTimestamps compares the StartTime and EndTime of SpanStubs, and the
+// Time of their Events.
+func WithTimestamps() ComparerOption {
+	return comparerOptionFunc(func(cfg comparerConfig) comparerConfig {
+		cfg.timestamps = true
+		return cfg
+	})
+}
+
+// SpanStubComparer returns the options to compare SpanStubs with the
+// github.com/google/go-cmp/cmp package ignoring the fields that change from
+// one run of a test to the next. By default these are the span IDs of the
+// SpanContext and Parent, the StartTime and EndTime, and the Time of the
+// Events. The opts include these fields in the comparison.
+//
+// This replaces clearing these fields from SpanStubs before comparing them.
+// For example:
+//
+//	if diff := cmp.Diff(want, got, tracetest.SpanStubComparer()); diff != "" {
+//		t.Errorf("-want +got:\n%s", diff)
+//	}
+func SpanStubComparer(opts ...ComparerOption) cmp.Option {
+	var cfg comparerConfig
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	return cmp.Options{
+		cmp.Transformer("tracetest.SpanStub", func(s SpanStub) SpanStub {
+			if !cfg.spanIDs {
+				s.SpanContext = s.SpanContext.WithSpanID(trace.SpanID{})
+				s.Parent = s.Parent.WithSpanID(trace.SpanID{})
+			}
+			if !cfg.timestamps {
+				s.StartTime, s.EndTime = time.Time{}, time.Time{}
+				if s.Events != nil {
+					// Copy the events so those of the compared SpanStub
+					// are not modified.
+					events := make([]tracesdk.Event, len(s.Events))
+					for i, e := range s.Events {
+						e.Time = time.Time{}
+						events[i] = e
+					}
+					s.Events = events
+				}
+			}
+			return s
+		}),
+		cmp.AllowUnexported(attribute.Value{}),
+	}
+}
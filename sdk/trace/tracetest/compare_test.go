@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanStubComparer(t *testing.T) {
+	sr := NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tr := tp.Tracer("SpanStubComparer")
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	for i := 0; i < 2; i++ {
+		_, span := tr.Start(
+			ctx,
+			"span",
+			trace.WithAttributes(attribute.String("key", "value")),
+			trace.WithTimestamp(time.Unix(int64(i), 0)),
+		)
+		span.AddEvent("event", trace.WithAttributes(attribute.Int("n", 1)))
+		span.End()
+	}
+	stubs := SpanStubsFromReadOnlySpans(sr.Ended())
+	require.Len(t, stubs, 2)
+	a, b := stubs[0], stubs[1]
+	require.NotEqual(t, a.SpanContext.SpanID(), b.SpanContext.SpanID())
+
+	assert.Empty(t, cmp.Diff(a, b, SpanStubComparer()))
+	assert.NotEmpty(t, cmp.Diff(a, b, SpanStubComparer(WithSpanIDs())))
+	assert.NotEmpty(t, cmp.Diff(a, b, SpanStubComparer(WithTimestamps())))
+
+	b.Name = "other"
+	assert.NotEmpty(t, cmp.Diff(a, b, SpanStubComparer()))
+
+	// The compared SpanStubs are not modified.
+	assert.False(t, a.StartTime.IsZero())
+	assert.False(t, a.Events[0].Time.IsZero())
+	assert.True(t, a.SpanContext.SpanID().IsValid())
+}
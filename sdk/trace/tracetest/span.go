@@ -15,6 +15,7 @@
 package tracetest // import "go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 import (
+	"encoding/json"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -100,7 +101,73 @@ func SpanStubFromReadOnlySpan(ro tracesdk.ReadOnlySpan) SpanStub {
 	}
 }
 
+// MarshalJSON returns the JSON encoding of the SpanStub.
+//
+// The encoding is a JSON object with the following members, in this order:
+//
+//	Name                   string
+//	SpanContext            object, see trace.SpanContext.MarshalJSON
+//	Parent                 object, see trace.SpanContext.MarshalJSON
+//	SpanKind               number
+//	StartTime              string, RFC 3339 with nanoseconds
+//	EndTime                string, RFC 3339 with nanoseconds
+//	Attributes             array of {"Key": string, "Value": object}
+//	Events                 array, see tracesdk.Event.MarshalJSON
+//	Links                  array of {"SpanContext", "Attributes", "DroppedAttributeCount"}
+//	Status                 {"Code": string, "Description": string}
+//	DroppedAttributes      number
+//	DroppedEvents          number
+//	DroppedLinks           number
+//	ChildSpanCount         number
+//	Resource               array of {"Key": string, "Value": object}
+//	InstrumentationLibrary {"Name": string, "Version": string, "SchemaURL": string}
+//
+// Trace and span IDs are encoded as lowercase hex strings and attribute
+// values as {"Type": string, "Value": any} (see attribute.Value.MarshalJSON).
+// This shape is stable, new members will only ever be appended.
+func (s SpanStub) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name                   string
+		SpanContext            trace.SpanContext
+		Parent                 trace.SpanContext
+		SpanKind               trace.SpanKind
+		StartTime              time.Time
+		EndTime                time.Time
+		Attributes             []attribute.KeyValue
+		Events                 []tracesdk.Event
+		Links                  []tracesdk.Link
+		Status                 tracesdk.Status
+		DroppedAttributes      int
+		DroppedEvents          int
+		DroppedLinks           int
+		ChildSpanCount         int
+		Resource               *resource.Resource
+		InstrumentationLibrary instrumentation.Library
+	}{
+		Name:                   s.Name,
+		SpanContext:            s.SpanContext,
+		Parent:                 s.Parent,
+		SpanKind:               s.SpanKind,
+		StartTime:              s.StartTime,
+		EndTime:                s.EndTime,
+		Attributes:             s.Attributes,
+		Events:                 s.Events,
+		Links:                  s.Links,
+		Status:                 s.Status,
+		DroppedAttributes:      s.DroppedAttributes,
+		DroppedEvents:          s.DroppedEvents,
+		DroppedLinks:           s.DroppedLinks,
+		ChildSpanCount:         s.ChildSpanCount,
+		Resource:               s.Resource,
+		InstrumentationLibrary: s.InstrumentationLibrary,
+	})
+}
+
 // Snapshot returns a read-only copy of the SpanStub.
+//
+// This can be used to pass spans received or stored outside of the SDK, e.g.
+// by a relay, to the SpanProcessors and SpanExporters operating on
+// ReadOnlySpans.
 func (s SpanStub) Snapshot() tracesdk.ReadOnlySpan {
 	return spanSnapshot{
 		name:                 s.Name,
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const spanStubJSON = `{
+	"Name": "span",
+	"SpanContext": {
+		"TraceID": "0102030405060708090a0b0c0d0e0f10",
+		"SpanID": "0102030405060708",
+		"TraceFlags": "01",
+		"TraceState": "",
+		"Remote": false
+	},
+	"Parent": {
+		"TraceID": "0102030405060708090a0b0c0d0e0f10",
+		"SpanID": "0807060504030201",
+		"TraceFlags": "01",
+		"TraceState": "",
+		"Remote": true
+	},
+	"SpanKind": 2,
+	"StartTime": "2021-01-02T03:04:05.000000006Z",
+	"EndTime": "2021-01-02T03:04:06Z",
+	"Attributes": [
+		{
+			"Key": "key",
+			"Value": {
+				"Type": "STRING",
+				"Value": "value"
+			}
+		}
+	],
+	"Events": [
+		{
+			"Name": "event",
+			"Attributes": [
+				{
+					"Key": "count",
+					"Value": {
+						"Type": "INT64",
+						"Value": 1
+					}
+				}
+			],
+			"DroppedAttributeCount": 2,
+			"Time": "2021-01-02T03:04:05.5Z"
+		}
+	],
+	"Links": null,
+	"Status": {
+		"Code": "Error",
+		"Description": "failure"
+	},
+	"DroppedAttributes": 3,
+	"DroppedEvents": 4,
+	"DroppedLinks": 5,
+	"ChildSpanCount": 6,
+	"Resource": [
+		{
+			"Key": "service.name",
+			"Value": {
+				"Type": "STRING",
+				"Value": "test"
+			}
+		}
+	],
+	"InstrumentationLibrary": {
+		"Name": "library",
+		"Version": "v0.1.0",
+		"SchemaURL": ""
+	}
+}`
+
+func TestSpanStubMarshalJSON(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	start := time.Date(2021, time.January, 2, 3, 4, 5, 6, time.UTC)
+	stub := SpanStub{
+		Name: "span",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		}),
+		SpanKind:   trace.SpanKindServer,
+		StartTime:  start,
+		EndTime:    start.Add(time.Second).Truncate(time.Second),
+		Attributes: []attribute.KeyValue{attribute.String("key", "value")},
+		Events: []tracesdk.Event{{
+			Name:                  "event",
+			Attributes:            []attribute.KeyValue{attribute.Int("count", 1)},
+			DroppedAttributeCount: 2,
+			Time:                  start.Truncate(time.Second).Add(500 * time.Millisecond),
+		}},
+		Status:            tracesdk.Status{Code: codes.Error, Description: "failure"},
+		DroppedAttributes: 3,
+		DroppedEvents:     4,
+		DroppedLinks:      5,
+		ChildSpanCount:    6,
+		Resource:          resource.NewSchemaless(attribute.String("service.name", "test")),
+		InstrumentationLibrary: instrumentation.Library{
+			Name:    "library",
+			Version: "v0.1.0",
+		},
+	}
+
+	got, err := json.MarshalIndent(stub, "", "\t")
+	require.NoError(t, err)
+	assert.Equal(t, spanStubJSON, string(got))
+
+	// The output needs to be deterministic.
+	again, err := json.MarshalIndent(stub, "", "\t")
+	require.NoError(t, err)
+	assert.Equal(t, got, again)
+}
+
+func TestSpanStubSnapshot(t *testing.T) {
+	now := time.Now()
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+		Remote:  true,
+	})
+	stub := SpanStub{
+		Name:        "span",
+		SpanContext: sc,
+		Parent:      parent,
+		SpanKind:    trace.SpanKindServer,
+		StartTime:   now,
+		EndTime:     now.Add(time.Second),
+		Attributes:  []attribute.KeyValue{attribute.String("key", "value")},
+		Events: []tracesdk.Event{{
+			Name:       "event",
+			Attributes: []attribute.KeyValue{attribute.Int("int", 1)},
+			Time:       now,
+		}},
+		Links: []tracesdk.Link{{
+			SpanContext: parent,
+			Attributes:  []attribute.KeyValue{attribute.Bool("bool", true)},
+		}},
+		Status:            tracesdk.Status{Code: codes.Error, Description: "error"},
+		DroppedAttributes: 1,
+		DroppedEvents:     2,
+		DroppedLinks:      3,
+		ChildSpanCount:    4,
+		Resource:          resource.NewSchemaless(attribute.String("res", "value")),
+		InstrumentationLibrary: instrumentation.Library{
+			Name:      "lib",
+			Version:   "v0.1.0",
+			SchemaURL: "https://opentelemetry.io/schemas/1.0.0",
+		},
+	}
+
+	ro := stub.Snapshot()
+	assert.Equal(t, stub.Name, ro.Name())
+	assert.Equal(t, stub.SpanContext, ro.SpanContext())
+	assert.Equal(t, stub.Parent, ro.Parent())
+	assert.Equal(t, stub.SpanKind, ro.SpanKind())
+	assert.Equal(t, stub.StartTime, ro.StartTime())
+	assert.Equal(t, stub.EndTime, ro.EndTime())
+	assert.Equal(t, stub.Attributes, ro.Attributes())
+	assert.Equal(t, stub.Events, ro.Events())
+	assert.Equal(t, stub.Links, ro.Links())
+	assert.Equal(t, stub.Status, ro.Status())
+	assert.Equal(t, stub.DroppedAttributes, ro.DroppedAttributes())
+	assert.Equal(t, stub.DroppedEvents, ro.DroppedEvents())
+	assert.Equal(t, stub.DroppedLinks, ro.DroppedLinks())
+	assert.Equal(t, stub.ChildSpanCount, ro.ChildSpanCount())
+	assert.Equal(t, stub.Resource, ro.Resource())
+	assert.Equal(t, stub.InstrumentationLibrary, ro.InstrumentationScope())
+	assert.Equal(t, stub.InstrumentationLibrary, ro.InstrumentationLibrary())
+
+	// The conversion round-trips.
+	assert.Equal(t, stub, SpanStubFromReadOnlySpan(ro))
+}
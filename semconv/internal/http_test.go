@@ -909,12 +909,14 @@ func TestHTTPAttributesFromHTTPStatusCode(t *testing.T) {
 
 func TestSpanStatusFromHTTPStatusCode(t *testing.T) {
 	for code := 0; code < 1000; code++ {
-		expected := getExpectedCodeForHTTPCode(code, trace.SpanKindClient)
+		expected := codes.Unset
+		if code < 100 || code >= 400 {
+			expected = codes.Error
+		}
 		got, msg := SpanStatusFromHTTPStatusCode(code)
-		assert.Equalf(t, expected, got, "%s vs %s", expected, got)
+		assert.Equalf(t, expected, got, "%d", code)
 
-		_, valid := validateHTTPStatusCode(code)
-		if !valid {
+		if code < 100 || code >= 600 {
 			assert.NotEmpty(t, msg, "message should be set if error cannot be inferred from code")
 		} else {
 			assert.Empty(t, msg, "message should not be set if error can be inferred from code")
@@ -922,46 +924,79 @@ func TestSpanStatusFromHTTPStatusCode(t *testing.T) {
 	}
 }
 
+func TestSpanStatusFromHTTPStatusCodeBoundaries(t *testing.T) {
+	testCases := []struct {
+		code     int
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{99, codes.Error, "Invalid HTTP status code 99"},
+		{100, codes.Unset, ""},
+		{399, codes.Unset, ""},
+		{400, codes.Error, ""},
+		{499, codes.Error, ""},
+		{500, codes.Error, ""},
+		{599, codes.Error, ""},
+		{600, codes.Error, "Invalid HTTP status code 600"},
+	}
+	for _, tc := range testCases {
+		got, msg := SpanStatusFromHTTPStatusCode(tc.code)
+		assert.Equalf(t, tc.wantCode, got, "%d", tc.code)
+		assert.Equalf(t, tc.wantMsg, msg, "%d", tc.code)
+	}
+}
+
 func TestSpanStatusFromHTTPStatusCodeAndSpanKind(t *testing.T) {
 	for code := 0; code < 1000; code++ {
-		expected := getExpectedCodeForHTTPCode(code, trace.SpanKindClient)
-		got, msg := SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindClient)
-		assert.Equalf(t, expected, got, "%s vs %s", expected, got)
+		for _, kind := range []trace.SpanKind{trace.SpanKindClient, trace.SpanKindServer} {
+			expected := codes.Unset
+			switch {
+			case code < 100 || code >= 500:
+				expected = codes.Error
+			case code >= 400 && kind == trace.SpanKindClient:
+				expected = codes.Error
+			}
+			got, msg := SpanStatusFromHTTPStatusCodeAndSpanKind(code, kind)
+			assert.Equalf(t, expected, got, "%d %s", code, kind)
 
-		_, valid := validateHTTPStatusCode(code)
-		if !valid {
-			assert.NotEmpty(t, msg, "message should be set if error cannot be inferred from code")
-		} else {
-			assert.Empty(t, msg, "message should not be set if error can be inferred from code")
+			if code < 100 || code >= 600 {
+				assert.NotEmpty(t, msg, "message should be set if error cannot be inferred from code")
+			} else {
+				assert.Empty(t, msg, "message should not be set if error can be inferred from code")
+			}
 		}
 	}
-	code, _ := SpanStatusFromHTTPStatusCodeAndSpanKind(400, trace.SpanKindServer)
-	assert.Equalf(t, codes.Unset, code, "message should be set if error cannot be inferred from code")
 }
 
-func getExpectedCodeForHTTPCode(code int, spanKind trace.SpanKind) codes.Code {
-	if http.StatusText(code) == "" {
-		return codes.Error
-	}
-	switch code {
-	case
-		http.StatusUnauthorized,
-		http.StatusForbidden,
-		http.StatusNotFound,
-		http.StatusTooManyRequests,
-		http.StatusNotImplemented,
-		http.StatusServiceUnavailable,
-		http.StatusGatewayTimeout:
-		return codes.Error
-	}
-	category := code / 100
-	if category > 0 && category < 4 {
-		return codes.Unset
+func TestSpanStatusFromHTTPStatusCodeAndSpanKindBoundaries(t *testing.T) {
+	testCases := []struct {
+		code     int
+		kind     trace.SpanKind
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{99, trace.SpanKindClient, codes.Error, "Invalid HTTP status code 99"},
+		{100, trace.SpanKindClient, codes.Unset, ""},
+		{399, trace.SpanKindClient, codes.Unset, ""},
+		{400, trace.SpanKindClient, codes.Error, ""},
+		{499, trace.SpanKindClient, codes.Error, ""},
+		{500, trace.SpanKindClient, codes.Error, ""},
+		{599, trace.SpanKindClient, codes.Error, ""},
+		{600, trace.SpanKindClient, codes.Error, "Invalid HTTP status code 600"},
+		{99, trace.SpanKindServer, codes.Error, "Invalid HTTP status code 99"},
+		{100, trace.SpanKindServer, codes.Unset, ""},
+		{399, trace.SpanKindServer, codes.Unset, ""},
+		{400, trace.SpanKindServer, codes.Unset, ""},
+		{499, trace.SpanKindServer, codes.Unset, ""},
+		{500, trace.SpanKindServer, codes.Error, ""},
+		{599, trace.SpanKindServer, codes.Error, ""},
+		{600, trace.SpanKindServer, codes.Error, "Invalid HTTP status code 600"},
 	}
-	if spanKind == trace.SpanKindServer && category == 4 {
-		return codes.Unset
+	for _, tc := range testCases {
+		got, msg := SpanStatusFromHTTPStatusCodeAndSpanKind(tc.code, tc.kind)
+		assert.Equalf(t, tc.wantCode, got, "%d %s", tc.code, tc.kind)
+		assert.Equalf(t, tc.wantMsg, msg, "%d %s", tc.code, tc.kind)
 	}
-	return codes.Error
 }
 
 func assertElementsMatch(t *testing.T, expected, got []attribute.KeyValue, format string, args ...interface{}) {
@@ -41,6 +41,18 @@ func ContextWithRemoteSpanContext(parent context.Context, rsc SpanContext) conte
 	return ContextWithSpanContext(parent, rsc.WithRemote(true))
 }
 
+// StartFromRemote starts a Span with t as a child of the remote parent sc,
+// and returns it along with a context, derived from ctx, containing it. It is
+// equivalent to calling t.Start with ContextWithRemoteSpanContext(ctx, sc),
+// and is useful to continue a trace from a SpanContext stored with a job in
+// a queue.
+//
+// Any Span in ctx is ignored, the started Span is a child of sc. If sc is not
+// valid, the started Span is a new root Span.
+func StartFromRemote(ctx context.Context, t Tracer, sc SpanContext, name string, opts ...SpanStartOption) (context.Context, Span) {
+	return t.Start(ContextWithRemoteSpanContext(ctx, sc), name, opts...)
+}
+
 // SpanFromContext returns the current Span from ctx.
 //
 // If no Span is currently set in ctx an implementation of a Span that
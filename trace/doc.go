@@ -62,5 +62,15 @@ a default.
 		defer span.End()
 		// ...
 	}
+
+Attributes that are expensive to compute can be set with
+SetAttributesIfRecording. The passed function is only called if the Span is
+recording, so no work is done for Spans that are dropped.
+
+	trace.SetAttributesIfRecording(span, func() []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("request.body", string(body)),
+		}
+	})
 */
 package trace // import "go.opentelemetry.io/otel/trace"
@@ -17,6 +17,8 @@ package trace
 import (
 	"context"
 	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func TestNewNoopTracerProvider(t *testing.T) {
@@ -69,6 +71,11 @@ func TestNoopSpan(t *testing.T) {
 	if got, want := span.IsRecording(), false; got != want {
 		t.Errorf("span.IsRecording() returned %#v, want %#v", got, want)
 	}
+
+	SetAttributesIfRecording(span, func() []attribute.KeyValue {
+		t.Error("SetAttributesIfRecording() called fn of a noop span")
+		return nil
+	})
 }
 
 func TestNonRecordingSpanTracerStart(t *testing.T) {
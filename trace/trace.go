@@ -17,6 +17,7 @@ package trace // import "go.opentelemetry.io/otel/trace"
 import (
 	"bytes"
 	"context"
+	"encoding"
 	"encoding/hex"
 	"encoding/json"
 
@@ -29,6 +30,11 @@ const (
 	// with the sampling bit set means the span is sampled.
 	FlagsSampled = TraceFlags(0x01)
 
+	// FlagsRandom is a bitmask with the random bit set. A SpanContext with
+	// the random bit set means the right-most 7 bytes of its TraceID are
+	// randomly generated.
+	FlagsRandom = TraceFlags(0x02)
+
 	errInvalidHexID errorConst = "trace-id and span-id can only contain [0-9a-f] characters, all lowercase"
 
 	errInvalidTraceIDLength errorConst = "hex encoded trace-id must have length equals to 32"
@@ -38,6 +44,16 @@ const (
 	errNilSpanID           errorConst = "span-id can't be all zero"
 )
 
+var (
+	// ErrInvalidTraceID is returned by SpanContext.Validate for a
+	// SpanContext without a valid TraceID.
+	ErrInvalidTraceID error = errNilTraceID
+
+	// ErrInvalidSpanID is returned by SpanContext.Validate for a
+	// SpanContext without a valid SpanID.
+	ErrInvalidSpanID error = errNilSpanID
+)
+
 type errorConst string
 
 func (e errorConst) Error() string {
@@ -50,6 +66,8 @@ type TraceID [16]byte
 
 var nilTraceID TraceID
 var _ json.Marshaler = nilTraceID
+var _ encoding.TextMarshaler = nilTraceID
+var _ encoding.TextUnmarshaler = &nilTraceID
 
 // IsValid checks whether the trace TraceID is valid. A valid trace ID does
 // not consist of zeros only.
@@ -68,11 +86,32 @@ func (t TraceID) String() string {
 	return hex.EncodeToString(t[:])
 }
 
+// MarshalText implements encoding.TextMarshaler to encode a TraceID as a hex
+// string.
+func (t TraceID) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to decode a TraceID from
+// a hex string. The same text is accepted, and the same errors are returned,
+// as by TraceIDFromHex, except that the all-zero text MarshalText encodes an
+// unset TraceID to is accepted.
+func (t *TraceID) UnmarshalText(text []byte) error {
+	tid, err := TraceIDFromHex(string(text))
+	if err != nil && err != errNilTraceID {
+		return err
+	}
+	*t = tid
+	return nil
+}
+
 // SpanID is a unique identity of a span in a trace.
 type SpanID [8]byte
 
 var nilSpanID SpanID
 var _ json.Marshaler = nilSpanID
+var _ encoding.TextMarshaler = nilSpanID
+var _ encoding.TextUnmarshaler = &nilSpanID
 
 // IsValid checks whether the SpanID is valid. A valid SpanID does not consist
 // of zeros only.
@@ -91,6 +130,25 @@ func (s SpanID) String() string {
 	return hex.EncodeToString(s[:])
 }
 
+// MarshalText implements encoding.TextMarshaler to encode a SpanID as a hex
+// string.
+func (s SpanID) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to decode a SpanID from a
+// hex string. The same text is accepted, and the same errors are returned, as
+// by SpanIDFromHex, except that the all-zero text MarshalText encodes an
+// unset SpanID to is accepted.
+func (s *SpanID) UnmarshalText(text []byte) error {
+	sid, err := SpanIDFromHex(string(text))
+	if err != nil && err != errNilSpanID {
+		return err
+	}
+	*s = sid
+	return nil
+}
+
 // TraceIDFromHex returns a TraceID from a hex string if it is compliant with
 // the W3C trace-context specification.  See more at
 // https://www.w3.org/TR/trace-context/#trace-id
@@ -168,6 +226,20 @@ func (tf TraceFlags) WithSampled(sampled bool) TraceFlags { // nolint:revive  //
 	return tf &^ FlagsSampled
 }
 
+// IsRandom returns if the random bit is set in the TraceFlags.
+func (tf TraceFlags) IsRandom() bool {
+	return tf&FlagsRandom == FlagsRandom
+}
+
+// WithRandom sets the random bit in a new copy of the TraceFlags.
+func (tf TraceFlags) WithRandom(random bool) TraceFlags { // nolint:revive  // random is not a control flag.
+	if random {
+		return tf | FlagsRandom
+	}
+
+	return tf &^ FlagsRandom
+}
+
 // MarshalJSON implements a custom marshal function to encode TraceFlags
 // as a hex string.
 func (tf TraceFlags) MarshalJSON() ([]byte, error) {
@@ -218,6 +290,20 @@ func (sc SpanContext) IsValid() bool {
 	return sc.HasTraceID() && sc.HasSpanID()
 }
 
+// Validate returns an error describing why the SpanContext is not valid, or
+// nil if it is valid. ErrInvalidTraceID is returned if the SpanContext does
+// not have a valid TraceID, otherwise ErrInvalidSpanID is returned if it
+// does not have a valid SpanID.
+func (sc SpanContext) Validate() error {
+	if !sc.HasTraceID() {
+		return ErrInvalidTraceID
+	}
+	if !sc.HasSpanID() {
+		return ErrInvalidSpanID
+	}
+	return nil
+}
+
 // IsRemote indicates whether the SpanContext represents a remotely-created Span.
 func (sc SpanContext) IsRemote() bool {
 	return sc.remote
@@ -381,6 +467,16 @@ type Span interface {
 	TracerProvider() TracerProvider
 }
 
+// SetAttributesIfRecording sets the attributes returned by fn as attributes
+// of span the same way SetAttributes does. The fn is only called if span is
+// recording, avoiding the cost of computing attributes that would be dropped.
+func SetAttributesIfRecording(span Span, fn func() []attribute.KeyValue) {
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(fn()...)
+}
+
 // Link is the relationship between two Spans. The relationship can be within
 // the same Trace or across different Traces.
 //
@@ -17,10 +17,13 @@ package trace
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -67,6 +70,51 @@ func TestSpanContextIsValid(t *testing.T) {
 	}
 }
 
+func TestSpanContextValidate(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		tid  TraceID
+		sid  SpanID
+		want error
+	}{
+		{
+			name: "SpanContext.Validate() returns nil if sc has both an Trace ID and Span ID",
+			tid:  [16]byte{1},
+			sid:  [8]byte{42},
+			want: nil,
+		}, {
+			name: "SpanContext.Validate() returns ErrInvalidTraceID if sc has neither an Trace ID nor Span ID",
+			tid:  TraceID([16]byte{}),
+			sid:  [8]byte{},
+			want: ErrInvalidTraceID,
+		}, {
+			name: "SpanContext.Validate() returns ErrInvalidTraceID if sc has a Span ID but not a Trace ID",
+			tid:  TraceID([16]byte{}),
+			sid:  [8]byte{42},
+			want: ErrInvalidTraceID,
+		}, {
+			name: "SpanContext.Validate() returns ErrInvalidSpanID if sc has a Trace ID but not a Span ID",
+			tid:  TraceID([16]byte{1}),
+			sid:  [8]byte{},
+			want: ErrInvalidSpanID,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			sc := SpanContext{
+				traceID: testcase.tid,
+				spanID:  testcase.sid,
+			}
+			have := sc.Validate()
+			if !errors.Is(have, testcase.want) {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+			if (have == nil) != sc.IsValid() {
+				t.Errorf("Validate() = %v, but IsValid() = %v", have, sc.IsValid())
+			}
+		})
+	}
+}
+
 func TestSpanContextEqual(t *testing.T) {
 	a := SpanContext{
 		traceID: [16]byte{1},
@@ -292,6 +340,108 @@ func TestIsValidFromHex(t *testing.T) {
 	}
 }
 
+func TestTraceIDText(t *testing.T) {
+	for _, testcase := range []struct {
+		name    string
+		hex     string
+		tid     TraceID
+		wantErr error
+	}{
+		{
+			name: "Valid TraceID",
+			tid:  TraceID([16]byte{128, 241, 152, 238, 86, 52, 59, 168, 100, 254, 139, 42, 87, 211, 239, 247}),
+			hex:  "80f198ee56343ba864fe8b2a57d3eff7",
+		}, {
+			name:    "Invalid TraceID with invalid length",
+			hex:     "80f198ee56343ba864fe8b2a57d3eff",
+			wantErr: errInvalidTraceIDLength,
+		}, {
+			name:    "Invalid TraceID with invalid char",
+			hex:     "80f198ee56343ba864fe8b2a57d3efg7",
+			wantErr: errInvalidHexID,
+		}, {
+			name:    "Invalid TraceID with uppercase",
+			hex:     "80f198ee56343ba864fe8b2a57d3efF7",
+			wantErr: errInvalidHexID,
+		}, {
+			name: "Zero TraceID",
+			hex:  "00000000000000000000000000000000",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var tid TraceID
+			err := tid.UnmarshalText([]byte(testcase.hex))
+			assert.Equal(t, testcase.wantErr, err)
+			assert.Equal(t, testcase.tid, tid)
+			if err != nil {
+				return
+			}
+
+			text, err := tid.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, testcase.hex, string(text))
+		})
+	}
+}
+
+func TestSpanIDText(t *testing.T) {
+	for _, testcase := range []struct {
+		name    string
+		hex     string
+		sid     SpanID
+		wantErr error
+	}{
+		{
+			name: "Valid SpanID",
+			sid:  SpanID([8]byte{128, 241, 152, 238, 86, 52, 59, 168}),
+			hex:  "80f198ee56343ba8",
+		}, {
+			name:    "Invalid SpanID with invalid length",
+			hex:     "80f198ee56343ba",
+			wantErr: errInvalidSpanIDLength,
+		}, {
+			name:    "Invalid SpanID with invalid char",
+			hex:     "80f198ee56343bg8",
+			wantErr: errInvalidHexID,
+		}, {
+			name:    "Invalid SpanID with uppercase",
+			hex:     "80f198ee56343bA8",
+			wantErr: errInvalidHexID,
+		}, {
+			name: "Zero SpanID",
+			hex:  "0000000000000000",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var sid SpanID
+			err := sid.UnmarshalText([]byte(testcase.hex))
+			assert.Equal(t, testcase.wantErr, err)
+			assert.Equal(t, testcase.sid, sid)
+			if err != nil {
+				return
+			}
+
+			text, err := sid.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, testcase.hex, string(text))
+		})
+	}
+}
+
+func TestIDTextZeroValue(t *testing.T) {
+	type ids struct {
+		TraceID TraceID
+		SpanID  SpanID
+	}
+	b, err := json.Marshal(ids{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TraceID":"00000000000000000000000000000000","SpanID":"0000000000000000"}`, string(b))
+
+	got := ids{TraceID: TraceID{1}, SpanID: SpanID{1}}
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, ids{}, got)
+}
+
 func TestSpanContextHasTraceID(t *testing.T) {
 	for _, testcase := range []struct {
 		name string
@@ -417,6 +567,83 @@ func TestTraceFlagsWithSampled(t *testing.T) {
 	}
 }
 
+func TestTraceFlagsIsRandom(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		tf   TraceFlags
+		want bool
+	}{
+		{
+			name: "random",
+			tf:   FlagsRandom,
+			want: true,
+		}, {
+			name: "unused bits are ignored, still not random",
+			tf:   ^FlagsRandom,
+			want: false,
+		}, {
+			name: "unused bits are ignored, still random",
+			tf:   FlagsRandom | ^FlagsRandom,
+			want: true,
+		}, {
+			name: "not random/default",
+			want: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := testcase.tf.IsRandom()
+			if have != testcase.want {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+		})
+	}
+}
+
+func TestTraceFlagsWithRandom(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		start  TraceFlags
+		random bool
+		want   TraceFlags
+	}{
+		{
+			name:   "random unchanged",
+			start:  FlagsRandom,
+			want:   FlagsRandom,
+			random: true,
+		}, {
+			name:   "become random",
+			want:   FlagsRandom,
+			random: true,
+		}, {
+			name:   "sampled bit is kept, becomes random",
+			start:  FlagsSampled,
+			want:   FlagsSampled | FlagsRandom,
+			random: true,
+		}, {
+			name:   "unused bits are ignored, still not random",
+			start:  ^FlagsRandom,
+			want:   ^FlagsRandom,
+			random: false,
+		}, {
+			name:   "unused bits are ignored, no longer random",
+			start:  0xff,
+			want:   ^FlagsRandom,
+			random: false,
+		}, {
+			name:   "not random/default",
+			random: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := testcase.start.WithRandom(testcase.random)
+			if have != testcase.want {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+		})
+	}
+}
+
 func TestStringTraceID(t *testing.T) {
 	for _, testcase := range []struct {
 		name string
@@ -660,3 +887,35 @@ func TestLinkFromContext(t *testing.T) {
 	}
 	assert.Equal(t, link.Attributes[0], k1v1)
 }
+
+type attributesSpan struct {
+	noopSpan
+
+	recording bool
+	attrs     []attribute.KeyValue
+}
+
+func (s *attributesSpan) IsRecording() bool { return s.recording }
+
+func (s *attributesSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func TestSetAttributesIfRecording(t *testing.T) {
+	kv := attribute.String("key", "value")
+	var calls int
+	fn := func() []attribute.KeyValue {
+		calls++
+		return []attribute.KeyValue{kv}
+	}
+
+	span := &attributesSpan{recording: true}
+	SetAttributesIfRecording(span, fn)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []attribute.KeyValue{kv}, span.attrs)
+
+	span = &attributesSpan{}
+	SetAttributesIfRecording(span, fn)
+	assert.Equal(t, 1, calls, "fn called for a non-recording span")
+	assert.Empty(t, span.attrs)
+}